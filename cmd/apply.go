@@ -23,12 +23,17 @@ var (
 	copyrightHolderFlag string
 	conflictStrategyFlag string
 	applySetFlag        []string
+	withDepsFlag        bool
+	jobsFlag            int
+	projectFlag         []string
+	batchFlag           string
 )
 
 var applyCmd = &cobra.Command{
-	Use:   "apply [--lang <lang>] [--with <template...>] [<path>]",
+	Use:   "apply [--lang <lang>] [--with <template...>] [--project <path:lang>...] [--batch <file>] [<path>]",
 	Short: "Apply a language pack or tooling template to a project",
-	Args:  cobra.MaximumNArgs(1),
+	Args:   cobra.MaximumNArgs(1),
+	PreRun: pluginPreRun,
 	RunE: func(_ *cobra.Command, args []string) error {
 		// Store the original argument for the manifest, which must be relative.
 		manifestPathArg := "."
@@ -44,6 +49,26 @@ var applyCmd = &cobra.Command{
 			return err
 		}
 
+		resolver, err := newConflictResolver(conflictStrategyFlag)
+		if err != nil {
+			return err
+		}
+
+		// Projects from --project and --batch are applied in the same transaction.
+		projects, err := parseProjectFlags(projectFlag)
+		if err != nil {
+			return err
+		}
+		with := withFlag
+		if batchFlag != "" {
+			batch, bErr := loadBatchFile(batchFlag)
+			if bErr != nil {
+				return bErr
+			}
+			projects = append(projects, batch.Projects...)
+			with = append(append([]string{}, with...), batch.With...)
+		}
+
 		// Convert to absolute path for robust execution (npm, go build).
 		absPath, err := filepath.Abs(targetPath)
 		if err != nil {
@@ -52,17 +77,22 @@ var applyCmd = &cobra.Command{
 
 		rc := core.RunContext{
 			LangFlag:          langFlag,
-			WithFlag:          withFlag,
+			WithFlag:          with,
 			TargetPath:        absPath,         // Pass absolute path for execution stability.
 			ManifestPathArg:   manifestPathArg, // Pass Arg for manifest portability.
 			DryRun:            dryRun,          // dryRun is the global flag.
 			Force:             force,           // force is the global flag.
 			ConflictStrategy:  conflictStrategyFlag,
+			ConflictResolver:  resolver,
 			TemplateDir:       templateDirFlag,
 			RegistryCacheDir:   GetRegistryCacheDir(),
 			License:           licenseFlag,
 			CopyrightHolder:   copyrightHolderFlag,
 			SetVars:           setVars,
+			WithDeps:          withDepsFlag,
+			Jobs:              jobsFlag,
+			Projects:          projects,
+			ScbakeVersion:     version,
 		}
 
 		// Initialize modular UI reporter using the factory
@@ -80,8 +110,12 @@ func init() {
 	rootCmd.AddCommand(applyCmd)
 	applyCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "Language pack")
 	applyCmd.PersistentFlags().StringSliceVar(&withFlag, "with", []string{}, "Tooling templates")
+	applyCmd.PersistentFlags().StringArrayVar(&projectFlag, "project", []string{}, "Also set up a project (path:lang, can be repeated) in the same transaction")
+	applyCmd.PersistentFlags().StringVar(&batchFlag, "batch", "", "TOML file listing projects to set up in the same transaction")
+	applyCmd.PersistentFlags().IntVarP(&jobsFlag, "jobs", "j", 1, "Maximum number of independent tasks to run concurrently")
+	applyCmd.PersistentFlags().BoolVar(&withDepsFlag, "with-deps", false, "Also apply templates required by the selected templates")
 	applyCmd.PersistentFlags().StringArrayVar(&applySetFlag, "set", []string{}, "Set template variable (key=value, can be repeated)")
-	applyCmd.PersistentFlags().StringVar(&conflictStrategyFlag, "conflict-strategy", "fail", "Conflict resolution strategy: fail, overwrite, artifact, keep-local")
+	applyCmd.PersistentFlags().StringVar(&conflictStrategyFlag, "conflict-strategy", "fail", "Conflict resolution strategy: fail, overwrite, artifact, keep-local, merge, prompt")
 	applyCmd.PersistentFlags().StringVar(&licenseFlag, "license", "", "SPDX License ID")
 	applyCmd.PersistentFlags().StringVar(&copyrightHolderFlag, "copyright-holder", "", "Copyright holder name")
 }
@@ -0,0 +1,60 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package cmd
+
+import (
+	"fmt"
+	"scbake/internal/core"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// batchFile is the format of `scbake apply --batch`:
+//
+//	with = ["ci_github", "makefile"] # applied at the apply path
+//
+//	[[projects]]
+//	path = "backend"
+//	lang = "go"
+//	with = ["go_linter"]
+type batchFile struct {
+	With     []string           `toml:"with"`
+	Projects []core.ProjectSpec `toml:"projects"`
+}
+
+// parseProjectFlags parses repeated --project path:lang values.
+func parseProjectFlags(flags []string) ([]core.ProjectSpec, error) {
+	specs := make([]core.ProjectSpec, 0, len(flags))
+	for _, f := range flags {
+		i := strings.LastIndex(f, ":")
+		if i <= 0 || i == len(f)-1 {
+			return nil, fmt.Errorf("invalid --project value %q: expected path:lang", f)
+		}
+		specs = append(specs, core.ProjectSpec{Path: f[:i], Lang: f[i+1:]})
+	}
+	return specs, nil
+}
+
+// loadBatchFile strictly decodes a batch file, rejecting unknown keys.
+func loadBatchFile(path string) (*batchFile, error) {
+	var b batchFile
+	md, err := toml.DecodeFile(path, &b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for i, k := range undecoded {
+			keys[i] = k.String()
+		}
+		return nil, fmt.Errorf("unknown keys in %s: %s", path, strings.Join(keys, ", "))
+	}
+	for _, p := range b.Projects {
+		if p.Path == "" || p.Lang == "" {
+			return nil, fmt.Errorf("%s: every project needs a path and a lang", path)
+		}
+	}
+	return &b, nil
+}
@@ -4,13 +4,18 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"scbake/internal/doctor"
+	"scbake/internal/manifest"
 	"scbake/internal/types"
 	"scbake/internal/util/fileutil"
+	"scbake/pkg/lang"
 	"scbake/pkg/templates"
 	"strings"
 	"testing"
@@ -26,6 +31,17 @@ func resetFlags() {
 	withFlag = []string{}
 	dryRun = false
 	force = false
+	withDepsFlag = false
+	newWithDepsFlag = false
+	jobsFlag = 1
+	newJobsFlag = 1
+	projectFlag = []string{}
+	batchFlag = ""
+	newRecipeFlag = ""
+	doctorJSON = false
+	conflictStrategyFlag = "fail"
+	newConflictStrategyFlag = "fail"
+	doctorOffline = false
 }
 
 // executeCLI simulates command invocation by setting args on the root Cobra command.
@@ -282,6 +298,25 @@ func TestApply_UnknownTemplate(t *testing.T) {
 	}
 }
 
+// Verifies that an unknown --conflict-strategy is rejected before anything runs.
+func TestApply_InvalidConflictStrategy(t *testing.T) {
+	resetFlags()
+	t.Cleanup(resetFlags)
+
+	tmpDir := t.TempDir()
+	oldWD, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldWD) })
+	_ = os.Chdir(tmpDir)
+
+	err := executeCLI("apply", "--with", "editorconfig", "--conflict-strategy", "keep_local", ".")
+	if err == nil || !strings.Contains(err.Error(), `invalid conflict strategy "keep_local"`) {
+		t.Fatalf("expected an invalid strategy error, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".editorconfig")); !os.IsNotExist(err) {
+		t.Error("no file should be created for an invalid strategy")
+	}
+}
+
 // Verifies that re-running templates does not cause errors or transaction artifacts.
 func TestApply_IdempotentRun(t *testing.T) {
 	resetFlags()
@@ -309,3 +344,581 @@ func TestApply_IdempotentRun(t *testing.T) {
 
 	verifyTransactionCleanup(t, tmpDir)
 }
+
+// Verifies that a template package in --template-dir is usable with --with.
+func TestApply_TemplatePackage(t *testing.T) {
+	resetFlags()
+	t.Cleanup(func() { templateDirFlag = "" })
+
+	tmpDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(tmpDir, fileutil.ManifestFileName), []byte(""), fileutil.PrivateFilePerms)
+
+	pkgDir := filepath.Join(t.TempDir(), "acme_notice")
+	if err := os.MkdirAll(pkgDir, fileutil.DirPerms); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"template.toml": "[[files]]\ntemplate = \"NOTICE.tpl\"\noutput = \"NOTICE\"\n",
+		"NOTICE.tpl":    "Built with scbake {{ .Version }}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(pkgDir, name), []byte(content), fileutil.PrivateFilePerms); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oldWD, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldWD) })
+	_ = os.Chdir(tmpDir)
+
+	if err := executeCLI("apply", "--template-dir", filepath.Dir(pkgDir), "--with", "acme_notice", "."); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	//nolint:gosec // Test temp directory
+	content, err := os.ReadFile(filepath.Join(tmpDir, "NOTICE"))
+	if err != nil {
+		t.Fatalf("NOTICE not written: %v", err)
+	}
+	if want := "Built with scbake " + version + "\n"; string(content) != want {
+		t.Errorf("NOTICE = %q, want %q", content, want)
+	}
+}
+
+// Verifies that a language pack in --template-dir is listed, validated against
+// its schema and applied with --lang.
+func TestApply_LanguagePack(t *testing.T) {
+	resetFlags()
+	t.Cleanup(func() {
+		templateDirFlag = ""
+		applySetFlag = nil
+	})
+
+	tmpDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(tmpDir, fileutil.ManifestFileName), []byte(""), fileutil.PrivateFilePerms)
+
+	packDir := filepath.Join(t.TempDir(), "toy")
+	if err := os.MkdirAll(packDir, fileutil.DirPerms); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"lang.toml":   "schema = \"schema.json\"\n\n[[files]]\ntemplate = \"toy.txt.tpl\"\noutput = \"toy.txt\"\n",
+		"schema.json": `{"variables": {"toy_color": {"type": "string", "required": true}}}`,
+		"toy.txt.tpl": "{{ .Project.Name }} is {{ .Metadata.toy_color }}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(packDir, name), []byte(content), fileutil.PrivateFilePerms); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oldWD, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldWD) })
+	_ = os.Chdir(tmpDir)
+
+	if err := executeCLI("list", "langs", "--template-dir", filepath.Dir(packDir)); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	found := false
+	for _, l := range lang.ListLangs() {
+		found = found || l == "toy"
+	}
+	if !found {
+		t.Fatal("language pack should be registered")
+	}
+
+	err := executeCLI("apply", "--template-dir", filepath.Dir(packDir), "--lang", "toy", "app")
+	if err == nil || !strings.Contains(err.Error(), "toy_color") {
+		t.Fatalf("expected schema validation error for toy_color, got %v", err)
+	}
+
+	if err := executeCLI("apply", "--template-dir", filepath.Dir(packDir), "--lang", "toy", "--set", "toy_color=red", "app"); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	//nolint:gosec // Test temp directory
+	content, err := os.ReadFile(filepath.Join(tmpDir, "app", "toy.txt"))
+	if err != nil {
+		t.Fatalf("toy.txt not written: %v", err)
+	}
+	if want := "app is red\n"; string(content) != want {
+		t.Errorf("toy.txt = %q, want %q", content, want)
+	}
+}
+
+// Verifies that a plugin on PATH is registered and its tasks run in the
+// transaction: a failing command rolls back the file the plugin created.
+func TestApply_Plugin(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Skipping shell plugin test on Windows")
+	}
+	resetFlags()
+
+	tmpDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(tmpDir, fileutil.ManifestFileName), []byte(""), fileutil.PrivateFilePerms)
+
+	binDir := t.TempDir()
+	writePlugin := func(name, tasks string) {
+		script := "#!/bin/sh\ncase \"$(cat)\" in\n" +
+			"*'\"describe\"'*) echo '{\"protocol\": 1, \"name\": \"" + name + "\"}' ;;\n" +
+			"*) echo '" + tasks + "' ;;\nesac\n"
+		if err := os.WriteFile(filepath.Join(binDir, "scbake-plugin-"+name), []byte(script), fileutil.ExecFilePerms); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writePlugin("hello", `{"protocol": 1, "tasks": [{"type": "create", "path": "HELLO.md", "content": "hello"}]}`)
+	writePlugin("broken", `{"protocol": 1, "tasks": [{"type": "create", "path": "BROKEN.md", "content": "x"}, {"type": "exec", "cmd": "false"}]}`)
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	oldWD, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldWD) })
+	_ = os.Chdir(tmpDir)
+
+	if err := executeCLI("apply", "--with", "hello", "."); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	//nolint:gosec // Test temp directory
+	if content, err := os.ReadFile(filepath.Join(tmpDir, "HELLO.md")); err != nil || string(content) != "hello" {
+		t.Errorf("HELLO.md = %q (err: %v)", content, err)
+	}
+
+	if err := executeCLI("apply", "--with", "broken", "."); err == nil {
+		t.Fatal("expected the failing command to fail the apply")
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "BROKEN.md")); !os.IsNotExist(err) {
+		t.Errorf("BROKEN.md should have been rolled back, stat err: %v", err)
+	}
+}
+
+// Verifies that plugins are only run by commands that look up handlers, and
+// that the tasks request carries the --set values.
+func TestPlugin_Discovery(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Skipping shell plugin test on Windows")
+	}
+	resetFlags()
+
+	tmpDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(tmpDir, fileutil.ManifestFileName), []byte(""), fileutil.PrivateFilePerms)
+
+	// The plugin saves every request it gets in requests.log.
+	binDir := t.TempDir()
+	log := filepath.Join(binDir, "requests.log")
+	script := "#!/bin/sh\nreq=$(cat)\nprintf '%s\\n' \"$req\" >> '" + log + "'\ncase \"$req\" in\n" +
+		"*'\"describe\"'*) echo '{\"protocol\": 1, \"name\": \"hello\"}' ;;\n" +
+		"*) echo '{\"protocol\": 1, \"tasks\": [{\"type\": \"create\", \"path\": \"HELLO.md\", \"content\": \"hello\"}]}' ;;\nesac\n"
+	if err := os.WriteFile(filepath.Join(binDir, "scbake-plugin-hello"), []byte(script), fileutil.ExecFilePerms); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	oldWD, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldWD) })
+	_ = os.Chdir(tmpDir)
+
+	for _, args := range [][]string{{"list", "projects"}, {"template", "registry", "list"}} {
+		if err := executeCLI(args...); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+	}
+	if _, err := os.Stat(log); !os.IsNotExist(err) {
+		t.Fatalf("no plugin should run for list projects or template, stat err: %v", err)
+	}
+
+	if err := executeCLI("apply", "--with", "hello", "--set", "owner=acme", "."); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	//nolint:gosec // Test temp directory
+	requests, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"metadata":{"owner":"acme"}`; !strings.Contains(string(requests), want) {
+		t.Errorf("requests = %s, want the tasks request to contain %s", requests, want)
+	}
+}
+
+// Verifies that a template's language requirement is checked before anything runs.
+func TestApply_TemplateDependencies(t *testing.T) {
+	resetFlags()
+
+	tmpDir := t.TempDir()
+	manifest := "[[projects]]\nname = \"api\"\npath = \".\"\nlanguage = \"go\"\n"
+	_ = os.WriteFile(filepath.Join(tmpDir, fileutil.ManifestFileName), []byte(manifest), fileutil.PrivateFilePerms)
+
+	oldWD, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldWD) })
+	_ = os.Chdir(tmpDir)
+
+	err := executeCLI("apply", "--with", "maven_linter", ".")
+	if err == nil || !strings.Contains(err.Error(), `template "maven_linter" requires a project using spring (found: go)`) {
+		t.Fatalf("expected a language requirement error, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "checkstyle.xml")); !os.IsNotExist(err) {
+		t.Errorf("nothing should be written when dependencies fail, stat err: %v", err)
+	}
+
+	// A Gradle Spring project has no pom.xml for maven_linter.
+	manifest = "[[projects]]\nname = \"api\"\npath = \".\"\nlanguage = \"spring\"\nbuild_tool = \"gradle\"\n"
+	_ = os.WriteFile(filepath.Join(tmpDir, fileutil.ManifestFileName), []byte(manifest), fileutil.PrivateFilePerms)
+	err = executeCLI("apply", "--with", "maven_linter", ".")
+	if err == nil || !strings.Contains(err.Error(), `template "maven_linter" requires a project built with maven (found: gradle)`) {
+		t.Fatalf("expected a build tool requirement error, got %v", err)
+	}
+}
+
+// Verifies that --project and --batch set up several projects in one
+// transaction: a failure in the root templates rolls back every project.
+func TestApply_MultiProject(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Skipping shell plugin test on Windows")
+	}
+	resetFlags()
+	t.Cleanup(func() { templateDirFlag = "" })
+
+	tmpDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(tmpDir, fileutil.ManifestFileName), []byte(""), fileutil.PrivateFilePerms)
+
+	packDir := filepath.Join(t.TempDir(), "toy")
+	if err := os.MkdirAll(packDir, fileutil.DirPerms); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"lang.toml":   "[[files]]\ntemplate = \"toy.txt.tpl\"\noutput = \"toy.txt\"\n",
+		"toy.txt.tpl": "{{ .Project.Name }}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(packDir, name), []byte(content), fileutil.PrivateFilePerms); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	binDir := t.TempDir()
+	script := "#!/bin/sh\ncase \"$(cat)\" in\n" +
+		"*'\"describe\"'*) echo '{\"protocol\": 1, \"name\": \"broken\"}' ;;\n" +
+		"*) echo '{\"protocol\": 1, \"tasks\": [{\"type\": \"exec\", \"cmd\": \"false\"}]}' ;;\nesac\n"
+	if err := os.WriteFile(filepath.Join(binDir, "scbake-plugin-broken"), []byte(script), fileutil.ExecFilePerms); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	oldWD, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldWD) })
+	_ = os.Chdir(tmpDir)
+
+	err := executeCLI("apply", "--template-dir", filepath.Dir(packDir),
+		"--project", "api:toy", "--project", "web:toy", "--with", "broken", "--jobs", "2")
+	if err == nil {
+		t.Fatal("expected the failing root template to fail the apply")
+	}
+	for _, p := range []string{"api", "web"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, p, "toy.txt")); !os.IsNotExist(err) {
+			t.Errorf("%s/toy.txt should have been rolled back, stat err: %v", p, err)
+		}
+	}
+
+	resetFlags()
+	batch := "[[projects]]\npath = \"api\"\nlang = \"toy\"\n\n[[projects]]\npath = \"web\"\nlang = \"toy\"\n"
+	batchPath := filepath.Join(t.TempDir(), "batch.toml")
+	if err := os.WriteFile(batchPath, []byte(batch), fileutil.PrivateFilePerms); err != nil {
+		t.Fatal(err)
+	}
+	if err := executeCLI("apply", "--template-dir", filepath.Dir(packDir), "--batch", batchPath); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	for _, p := range []string{"api", "web"} {
+		//nolint:gosec // Test temp directory
+		content, err := os.ReadFile(filepath.Join(tmpDir, p, "toy.txt"))
+		if err != nil || string(content) != p+"\n" {
+			t.Errorf("%s/toy.txt = %q (err: %v)", p, content, err)
+		}
+	}
+	//nolint:gosec // Test temp directory
+	m, _ := os.ReadFile(filepath.Join(tmpDir, fileutil.ManifestFileName))
+	if !strings.Contains(string(m), `path = "api"`) || !strings.Contains(string(m), `path = "web"`) {
+		t.Errorf("manifest should record both projects:\n%s", m)
+	}
+}
+
+// Verifies that the language variables of one project are stored on its
+// manifest entry, so a second Go project does not inherit its module path.
+func TestApply_TwoGoProjects(t *testing.T) {
+	resetFlags()
+	t.Cleanup(func() { applySetFlag = nil })
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOPROXY", "off")
+
+	tmpDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(tmpDir, fileutil.ManifestFileName), []byte(""), fileutil.PrivateFilePerms)
+
+	oldWD, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldWD) })
+	_ = os.Chdir(tmpDir)
+
+	if err := executeCLI("apply", "--lang", "go", "--set", "module_path=example.com/acme/api", "api"); err != nil {
+		t.Fatalf("apply api failed: %v", err)
+	}
+	resetFlags()
+	applySetFlag = nil
+	if err := executeCLI("apply", "--lang", "go", "worker"); err != nil {
+		t.Fatalf("apply worker failed: %v", err)
+	}
+
+	for dir, want := range map[string]string{"api": "module example.com/acme/api\n", "worker": "module worker\n"} {
+		//nolint:gosec // Test temp directory
+		content, err := os.ReadFile(filepath.Join(tmpDir, dir, "go.mod"))
+		if err != nil || !strings.HasPrefix(string(content), want) {
+			t.Errorf("%s/go.mod = %q (err: %v), want it to start with %q", dir, content, err, want)
+		}
+	}
+
+	m, _, err := manifest.Load(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.Metadata["module_path"]; ok {
+		t.Errorf("module_path should not be stored in [metadata]: %v", m.Metadata)
+	}
+	if len(m.Projects) != 2 || m.Projects[0].Metadata["module_path"] != "example.com/acme/api" || m.Projects[1].Metadata["module_path"] != "" {
+		t.Errorf("module_path should be stored on the api project only: %+v", m.Projects)
+	}
+}
+
+// Verifies that the Spring and Svelte packs can be applied to one repository:
+// each reads its own generation mode variable.
+func TestApply_SpringAndSvelte(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Skipping shell script test on Windows")
+	}
+	resetFlags()
+	t.Cleanup(func() { applySetFlag = nil })
+
+	binDir := t.TempDir()
+	java := "#!/bin/sh\necho 'openjdk version \"21.0.2\" 2024-01-16' >&2\n"
+	if err := os.WriteFile(filepath.Join(binDir, "java"), []byte(java), fileutil.ExecFilePerms); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	tmpDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(tmpDir, fileutil.ManifestFileName), []byte(""), fileutil.PrivateFilePerms)
+
+	oldWD, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldWD) })
+	_ = os.Chdir(tmpDir)
+
+	err := executeCLI("apply", "--project", "api:spring", "--project", "web:svelte",
+		"--set", "spring_mode=embedded", "--set", "svelte_mode=embedded", "--set", "install=false")
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	for _, f := range []string{"api/pom.xml", "web/package.json"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, f)); err != nil {
+			t.Errorf("%s was not created: %v", f, err)
+		}
+	}
+
+	m, _, err := manifest.Load(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Projects) != 2 || m.Projects[0].Metadata["spring_mode"] != "embedded" || m.Projects[1].Metadata["svelte_mode"] != "embedded" {
+		t.Errorf("each project should record its own mode: %+v", m.Projects)
+	}
+}
+
+// Verifies that 'new --recipe' validates the recipe before creating the
+// directory, bootstraps its sub-projects, and that 'recipe save' captures
+// the result so it can be reused by name.
+func TestNew_Recipe(t *testing.T) {
+	resetFlags()
+	t.Cleanup(func() { templateDirFlag = "" })
+
+	tmpDir := t.TempDir()
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("APPDATA", configDir)
+
+	packDir := filepath.Join(t.TempDir(), "toy")
+	if err := os.MkdirAll(packDir, fileutil.DirPerms); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"lang.toml":   "schema = \"schema.json\"\n\n[[files]]\ntemplate = \"toy.txt.tpl\"\noutput = \"toy.txt\"\n",
+		"schema.json": `{"variables": {"toy_color": {"type": "string", "required": true}}}`,
+		"toy.txt.tpl": "{{ .Project.Name }} is {{ .Metadata.toy_color }}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(packDir, name), []byte(content), fileutil.PrivateFilePerms); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	recipes := map[string]string{
+		"bad.toml":  "name = \"toy\"\nversion = \"1.0.0\"\n\n[[projects]]\npath = \"svc\"\nlang = \"toy\"\n",
+		"good.toml": "name = \"toy\"\nversion = \"1.0.0\"\n\n[metadata]\ntoy_color = \"red\"\n\n[[projects]]\npath = \"svc\"\nlang = \"toy\"\n",
+	}
+	for name, content := range recipes {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), fileutil.PrivateFilePerms); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oldWD, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldWD) })
+	_ = os.Chdir(tmpDir)
+
+	err := executeCLI("new", "app", "--template-dir", filepath.Dir(packDir), "--recipe", "./bad.toml")
+	if err == nil || !strings.Contains(err.Error(), "toy_color") {
+		t.Fatalf("expected schema validation error for toy_color, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "app")); !os.IsNotExist(err) {
+		t.Errorf("no directory should be created for an invalid recipe, stat err: %v", err)
+	}
+
+	if err := executeCLI("new", "app", "--template-dir", filepath.Dir(packDir), "--recipe", "./good.toml"); err != nil {
+		t.Fatalf("new failed: %v", err)
+	}
+	//nolint:gosec // Test temp directory
+	if content, err := os.ReadFile(filepath.Join(tmpDir, "app", "svc", "toy.txt")); err != nil || string(content) != "svc is red\n" {
+		t.Errorf("svc/toy.txt = %q (err: %v)", content, err)
+	}
+
+	_ = os.Chdir(filepath.Join(tmpDir, "app"))
+	if err := executeCLI("recipe", "save", "acme"); err != nil {
+		t.Fatalf("recipe save failed: %v", err)
+	}
+	_ = os.Chdir(tmpDir)
+
+	if err := executeCLI("new", "app2", "--template-dir", filepath.Dir(packDir), "--recipe", "acme@2.0.0"); err == nil {
+		t.Error("expected a version mismatch error")
+	}
+	if err := executeCLI("new", "app2", "--template-dir", filepath.Dir(packDir), "--recipe", "acme@1.0.0"); err != nil {
+		t.Fatalf("new from saved recipe failed: %v", err)
+	}
+	//nolint:gosec // Test temp directory
+	if content, err := os.ReadFile(filepath.Join(tmpDir, "app2", "svc", "toy.txt")); err != nil || string(content) != "svc is red\n" {
+		t.Errorf("app2/svc/toy.txt = %q (err: %v)", content, err)
+	}
+}
+
+// Verifies that a saved recipe keeps the root project's language variables
+// to the root: a sub-project does not inherit the root's module path.
+func TestRecipe_RootProjectMetadata(t *testing.T) {
+	resetFlags()
+	t.Cleanup(func() {
+		newSetFlag = nil
+		applySetFlag = nil
+	})
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOPROXY", "off")
+
+	tmpDir := t.TempDir()
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("APPDATA", configDir)
+
+	oldWD, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldWD) })
+	_ = os.Chdir(tmpDir)
+
+	if err := executeCLI("new", "root", "--lang", "go", "--set", "module_path=github.com/acme/root"); err != nil {
+		t.Fatalf("new failed: %v", err)
+	}
+	resetFlags()
+	newSetFlag = nil
+	_ = os.Chdir(filepath.Join(tmpDir, "root"))
+	if err := executeCLI("apply", "--lang", "go", "backend"); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if err := executeCLI("recipe", "save", "acme-go"); err != nil {
+		t.Fatalf("recipe save failed: %v", err)
+	}
+	_ = os.Chdir(tmpDir)
+
+	resetFlags()
+	if err := executeCLI("new", "copy", "--recipe", "acme-go"); err != nil {
+		t.Fatalf("new from recipe failed: %v", err)
+	}
+	for dir, want := range map[string]string{
+		"copy":         "module github.com/acme/root\n",
+		"copy/backend": "module backend\n",
+	} {
+		//nolint:gosec // Test temp directory
+		content, err := os.ReadFile(filepath.Join(tmpDir, dir, "go.mod"))
+		if err != nil || !strings.HasPrefix(string(content), want) {
+			t.Errorf("%s/go.mod = %q (err: %v), want it to start with %q", dir, content, err, want)
+		}
+	}
+}
+
+// Verifies that doctor reports registered language packs and manifest
+// problems as JSON, and fails on errors.
+func TestDoctor_JSON(t *testing.T) {
+	resetFlags()
+	t.Cleanup(func() { templateDirFlag = "" })
+
+	tmpDir := t.TempDir()
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("XDG_CACHE_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("APPDATA", configDir)
+
+	packDir := filepath.Join(t.TempDir(), "toy")
+	if err := os.MkdirAll(packDir, fileutil.DirPerms); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"lang.toml":   "[[files]]\ntemplate = \"toy.txt.tpl\"\noutput = \"toy.txt\"\n",
+		"toy.txt.tpl": "{{ .Project.Name }}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(packDir, name), []byte(content), fileutil.PrivateFilePerms); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manifest := "[[projects]]\n  name = \"app\"\n  path = \".\"\n  language = \"cobol\"\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, fileutil.ManifestFileName), []byte(manifest), fileutil.PrivateFilePerms); err != nil {
+		t.Fatal(err)
+	}
+
+	oldWD, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldWD) })
+	_ = os.Chdir(tmpDir)
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	t.Cleanup(func() { rootCmd.SetOut(nil) })
+
+	err := executeCLI("doctor", "--json", "--offline", "--template-dir", filepath.Dir(packDir))
+	if err == nil {
+		t.Fatal("expected doctor to fail for an unknown language")
+	}
+
+	var report doctor.Report
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out.String())
+	}
+	if report.OK || report.Version != version {
+		t.Errorf("unexpected report header: ok=%v version=%q", report.OK, report.Version)
+	}
+
+	var unknownLang, toy bool
+	for _, c := range report.Checks {
+		if c.Category == doctor.CategoryManifest && c.Status == doctor.StatusError && strings.Contains(c.Message, "cobol") {
+			unknownLang = true
+		}
+		if c.Category == doctor.CategoryPrerequisites && c.Name == "toy" {
+			toy = true
+		}
+	}
+	if !unknownLang {
+		t.Error("expected an error for the unknown language")
+	}
+	if !toy {
+		t.Error("expected the toy language pack to be checked")
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"scbake/internal/doctor"
+	"scbake/internal/templateregistry"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorJSON    bool
+	doctorOffline bool
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the environment scbake runs in",
+	Long: `Checks everything an apply depends on and prints a fix for each problem:
+
+  - prerequisites of every built-in and registered language
+  - the project's scbake.toml: syntax, scbake_version, languages and templates
+  - transaction directories left in .scbake/tmp by an interrupted run
+  - registries.json and the reachability of each registry
+  - the template cache: writability and interrupted or empty pulls
+  - --template-dir files that match no known template file
+
+Exits with an error if any check fails; warnings do not fail.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	PreRun:       pluginPreRun,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		// The default paths are used even if registries.json is broken, so
+		// the report can say so instead of failing here.
+		configPath, cacheDir, err := templateregistry.DefaultPaths()
+		if err != nil {
+			return err
+		}
+
+		report := doctor.Run(cmd.Context(), doctor.Options{
+			StartPath:          ".",
+			TemplateDir:        templateDirFlag,
+			RegistryConfigPath: configPath,
+			RegistryCacheDir:   cacheDir,
+			Version:            version,
+			Offline:            doctorOffline,
+		})
+
+		out := cmd.OutOrStdout()
+		if doctorJSON {
+			enc := json.NewEncoder(out)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(report); err != nil {
+				return fmt.Errorf("failed to encode report: %w", err)
+			}
+		} else {
+			printReport(out, report)
+		}
+
+		if !report.OK {
+			_, errs := report.Counts()
+			return fmt.Errorf("%d check(s) failed", errs)
+		}
+		return nil
+	},
+}
+
+// printReport writes the report grouped by category, with the fix below
+// every warning and error.
+func printReport(w io.Writer, report *doctor.Report) {
+	category := ""
+	for _, c := range report.Checks {
+		if c.Category != category {
+			if category != "" {
+				fmt.Fprintln(w)
+			}
+			category = c.Category
+			fmt.Fprintf(w, "%s:\n", category)
+		}
+
+		marker := "✅"
+		switch c.Status {
+		case doctor.StatusWarn:
+			marker = "⚠️ "
+		case doctor.StatusError:
+			marker = "❌"
+		case doctor.StatusOK:
+		}
+		fmt.Fprintf(w, "  %s %s: %s\n", marker, c.Name, c.Message)
+		if c.Fix != "" {
+			fmt.Fprintf(w, "     → %s\n", c.Fix)
+		}
+	}
+
+	warnings, errs := report.Counts()
+	fmt.Fprintf(w, "\n%d error(s), %d warning(s)\n", errs, warnings)
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Print the report as JSON")
+	doctorCmd.Flags().BoolVar(&doctorOffline, "offline", false, "Skip the registry reachability check")
+	rootCmd.AddCommand(doctorCmd)
+}
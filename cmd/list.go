@@ -23,12 +23,14 @@ or the projects currently managed in this repository's ` + fileutil.ManifestFile
 	Run: func(_ *cobra.Command, args []string) {
 		switch args[0] {
 		case "langs":
+			registerPlugins()
 			fmt.Println("Available Language Packs:")
 			for _, l := range lang.ListLangs() {
 				fmt.Printf("  %s\n", l)
 			}
 
 		case "templates":
+			registerPlugins()
 			fmt.Println("Available Tooling Templates:")
 			for _, t := range templates.ListTemplates() {
 				fmt.Printf("  %s\n", t)
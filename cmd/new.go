@@ -4,9 +4,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"scbake/internal/core"
+	"scbake/internal/recipe"
+	"scbake/internal/types"
 	"scbake/internal/ui"
 	"scbake/internal/util/fileutil"
 
@@ -27,13 +30,19 @@ var (
 	newCopyrightHolderFlag string
 	newConflictStrategyFlag string
 	newSetFlag             []string
+	newWithDepsFlag        bool
+	newJobsFlag            int
+	newRecipeFlag          string
 )
 
 var newCmd = &cobra.Command{
-	Use:   "new <project-name> [--lang <lang>] [--with <template...>]",
+	Use:   "new <project-name> [--lang <lang>] [--with <template...>] [--recipe <name>]",
 	Short: "Create a new standalone project",
-	Long:  `Creates a new directory and applies the specified language pack and templates.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Creates a new directory and applies the specified language pack and templates.
+With --recipe, the language, templates, metadata and sub-projects come from a
+saved recipe (see 'scbake recipe save'); flags add to or override it.`,
+	Args:   cobra.ExactArgs(1),
+	PreRun: pluginPreRun,
 	RunE: func(_ *cobra.Command, args []string) error {
 		projectName := args[0]
 		dirCreated := false
@@ -58,6 +67,11 @@ var newCmd = &cobra.Command{
 func runNew(projectName string, dirCreated *bool) error {
 	reporter := ui.NewReporter(newCmdTotalSteps, dryRun)
 
+	resolver, err := newConflictResolver(newConflictStrategyFlag)
+	if err != nil {
+		return err
+	}
+
 	// Capture original working directory before any changes.
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -69,6 +83,46 @@ func runNew(projectName string, dirCreated *bool) error {
 		return fmt.Errorf("directory '%s' already exists", projectName)
 	}
 
+	// Parse --set key=value pairs
+	setVars, err := parseSetFlags(newSetFlag)
+	if err != nil {
+		return err
+	}
+
+	rc := core.RunContext{
+		LangFlag:          newLangFlag,
+		WithFlag:          newWithFlag,
+		TargetPath:        ".",
+		DryRun:            dryRun,
+		Force:             force,
+		ConflictStrategy:  newConflictStrategyFlag,
+		ConflictResolver:  resolver,
+		TemplateDir:       templateDirFlag,
+		RegistryCacheDir:   GetRegistryCacheDir(),
+		ManifestPathArg:   ".",
+		License:           newLicenseFlag,
+		CopyrightHolder:   newCopyrightHolderFlag,
+		SetVars:           setVars,
+		WithDeps:          newWithDepsFlag,
+		Jobs:              newJobsFlag,
+		ScbakeVersion:     version,
+	}
+
+	if newRecipeFlag != "" {
+		r, path, err := recipe.Find(newRecipeFlag, userRecipesDir(), GetRegistryCacheDir())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Using recipe %q (version %s) from %s\n", r.Name, r.Version, path)
+		applyRecipe(&rc, r)
+	}
+
+	// Fail on unknown handlers, unmet dependencies and schema errors
+	// before anything is created.
+	if err := core.ValidateInputs(rc, &types.Manifest{}); err != nil {
+		return err
+	}
+
 	// Initialize project directory
 	reporter.Step("📁", "Creating directory: "+projectName)
 	if !dryRun {
@@ -97,29 +151,8 @@ func runNew(projectName string, dirCreated *bool) error {
 		}
 	}
 
-	// Parse --set key=value pairs
-	setVars, err := parseSetFlags(newSetFlag)
-	if err != nil {
-		return err
-	}
-
 	// Delegate template and language pack application to the core executor
 	reporter.Step("🚀", "Applying templates...")
-	rc := core.RunContext{
-		LangFlag:          newLangFlag,
-		WithFlag:          newWithFlag,
-		TargetPath:        ".",
-		DryRun:            dryRun,
-		Force:             force,
-		ConflictStrategy:  newConflictStrategyFlag,
-		TemplateDir:       templateDirFlag,
-		RegistryCacheDir:   GetRegistryCacheDir(),
-		ManifestPathArg:   ".",
-		License:           newLicenseFlag,
-		CopyrightHolder:   newCopyrightHolderFlag,
-		SetVars:           setVars,
-	}
-
 	if err := core.RunApply(rc, reporter); err != nil {
 		return err
 	}
@@ -142,6 +175,22 @@ func parseSetFlags(flags []string) (map[string]string, error) {
 	return setVars, nil
 }
 
+// newConflictResolver validates --conflict-strategy and returns the interactive
+// resolver required by the "prompt" strategy.
+// Prompting is only possible when stdin and stdout are attached to a terminal.
+func newConflictResolver(strategy string) (types.ConflictResolver, error) {
+	if err := types.ValidateConflictStrategy(strategy); err != nil {
+		return nil, fmt.Errorf("--conflict-strategy: %w", err)
+	}
+	if strategy != "prompt" {
+		return nil, nil
+	}
+	if !ui.IsInteractive() {
+		return nil, errors.New("--conflict-strategy=prompt requires an interactive terminal; use a non-interactive strategy or [conflict_strategies] in " + fileutil.ManifestFileName)
+	}
+	return ui.NewConflictPrompter(os.Stdin, os.Stdout), nil
+}
+
 // splitOnce splits a string on the first occurrence of sep.
 func splitOnce(s, sep string) []string {
 	for i := 0; i < len(s); i++ {
@@ -156,8 +205,11 @@ func init() {
 	// The rootCmd registration is handled in cmd/root.go init()
 	newCmd.Flags().StringVar(&newLangFlag, "lang", "", "Language project pack to apply")
 	newCmd.Flags().StringSliceVar(&newWithFlag, "with", []string{}, "Tooling template(s) to apply")
+	newCmd.Flags().StringVar(&newRecipeFlag, "recipe", "", "Recipe name, name@version or file to bootstrap from")
+	newCmd.Flags().IntVarP(&newJobsFlag, "jobs", "j", 1, "Maximum number of independent tasks to run concurrently")
+	newCmd.Flags().BoolVar(&newWithDepsFlag, "with-deps", false, "Also apply templates required by the selected templates")
 	newCmd.Flags().StringArrayVar(&newSetFlag, "set", []string{}, "Set template variable (key=value, can be repeated)")
-	newCmd.Flags().StringVar(&newConflictStrategyFlag, "conflict-strategy", "fail", "Conflict resolution strategy: fail, overwrite, artifact, keep-local")
+	newCmd.Flags().StringVar(&newConflictStrategyFlag, "conflict-strategy", "fail", "Conflict resolution strategy: fail, overwrite, artifact, keep-local, merge, prompt")
 	newCmd.Flags().StringVar(&newLicenseFlag, "license", "", "SPDX License ID (required for compliance)")
 	newCmd.Flags().StringVar(&newCopyrightHolderFlag, "copyright-holder", "", "Copyright holder name (required for compliance)")
 }
@@ -0,0 +1,130 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"scbake/pkg/declarative"
+	"scbake/pkg/lang"
+	"scbake/pkg/plugin"
+	"scbake/pkg/templates"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// builtinTemplates are the compiled-in template names, which packages cannot replace.
+	builtinTemplates = toSet(templates.ListTemplates())
+
+	// builtinLangs are the compiled-in language names, which packs cannot replace.
+	builtinLangs = toSet(lang.ListLangs())
+)
+
+// registerPackages registers the declarative template packages and language
+// packs found in the registry cache, the user's language directory (langs
+// only) and the template directory, in that order, so later sources replace
+// earlier packages of the same name. Invalid packages are reported and
+// skipped so they cannot break unrelated commands.
+func registerPackages(templateDir, registryCacheDir string) {
+	for _, root := range []string{registryCacheDir, templateDir} {
+		for _, dir := range findPackages(root, declarative.TemplateManifestFile) {
+			pkg, err := declarative.LoadTemplatePackage(dir)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  Skipping template package: %v\n", err)
+				continue
+			}
+			if builtinTemplates[pkg.Name] {
+				fmt.Fprintf(os.Stderr, "⚠️  Skipping template package %s: %q is a built-in template\n", dir, pkg.Name)
+				continue
+			}
+			templates.Register(pkg.Name, pkg)
+		}
+	}
+
+	for _, root := range []string{registryCacheDir, userLangsDir(), templateDir} {
+		for _, dir := range findPackages(root, declarative.LanguageManifestFile) {
+			pack, err := declarative.LoadLanguagePack(dir)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  Skipping language pack: %v\n", err)
+				continue
+			}
+			if builtinLangs[pack.Name] {
+				fmt.Fprintf(os.Stderr, "⚠️  Skipping language pack %s: %q is a built-in language\n", dir, pack.Name)
+				continue
+			}
+			lang.Register(pack.Name, pack)
+		}
+	}
+}
+
+// pluginPreRun registers the subprocess plugins before commands that look up
+// languages and templates. Discovery runs every plugin executable, so it is
+// left out of commands such as help, template and recipe.
+func pluginPreRun(_ *cobra.Command, _ []string) {
+	registerPlugins()
+}
+
+// registerPlugins registers the subprocess plugins found in the user's
+// plugins directory and on PATH. Plugins that fail to describe themselves or
+// clash with a built-in handler are reported and skipped.
+func registerPlugins() {
+	dirs := append([]string{userPluginsDir()}, filepath.SplitList(os.Getenv("PATH"))...)
+	for _, path := range plugin.Discover(dirs...) {
+		p, err := plugin.Load(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Skipping plugin: %v\n", err)
+			continue
+		}
+		if p.Kind == plugin.KindLang {
+			if builtinLangs[p.Name] {
+				fmt.Fprintf(os.Stderr, "⚠️  Skipping plugin %s: %q is a built-in language\n", path, p.Name)
+				continue
+			}
+			lang.Register(p.Name, p)
+			continue
+		}
+		if builtinTemplates[p.Name] {
+			fmt.Fprintf(os.Stderr, "⚠️  Skipping plugin %s: %q is a built-in template\n", path, p.Name)
+			continue
+		}
+		templates.Register(p.Name, p)
+	}
+}
+
+// findPackages lists package directories below root, reporting search errors.
+func findPackages(root, file string) []string {
+	dirs, err := declarative.FindPackages(root, file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+	}
+	return dirs
+}
+
+// userLangsDir returns ~/.config/scbake/langs (or the platform equivalent).
+func userLangsDir() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "scbake", "langs")
+}
+
+// userPluginsDir returns ~/.config/scbake/plugins (or the platform equivalent).
+func userPluginsDir() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "scbake", "plugins")
+}
+
+func toSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, i := range items {
+		set[i] = true
+	}
+	return set
+}
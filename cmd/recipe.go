@@ -0,0 +1,126 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"scbake/internal/core"
+	"scbake/internal/manifest"
+	"scbake/internal/recipe"
+	"scbake/internal/util/fileutil"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	recipeOutputFlag      string
+	recipeVersionFlag     string
+	recipeDescriptionFlag string
+)
+
+var recipeCmd = &cobra.Command{
+	Use:   "recipe",
+	Short: "Manage project recipes for 'scbake new --recipe'",
+}
+
+var recipeSaveCmd = &cobra.Command{
+	Use:   "save <name> [--version <version>] [--output <file>]",
+	Short: "Save the current " + fileutil.ManifestFileName + " as a recipe",
+	Long: `Captures the projects, templates and metadata recorded in the nearest
+` + fileutil.ManifestFileName + ` as a recipe. Recipes are saved to ~/.config/scbake/recipes/
+unless --output is given, and can be shared through template registries
+(under recipes/ in the archive).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		m, rootPath, err := manifest.Load(".")
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", fileutil.ManifestFileName, err)
+		}
+
+		r, err := recipe.FromManifest(args[0], recipeVersionFlag, m)
+		if err != nil {
+			return err
+		}
+		r.Description = recipeDescriptionFlag
+		if r.Lang == "" && len(r.With) == 0 && len(r.Projects) == 0 {
+			return fmt.Errorf("%s in %s records no projects or templates", fileutil.ManifestFileName, rootPath)
+		}
+
+		out := recipeOutputFlag
+		if out == "" {
+			dir := userRecipesDir()
+			if dir == "" {
+				return errors.New("cannot determine the user config directory; use --output")
+			}
+			out = filepath.Join(dir, args[0]+".toml")
+		}
+		if _, err := os.Stat(out); err == nil && !force {
+			return fmt.Errorf("recipe %s already exists (use --force to overwrite)", out)
+		}
+
+		if err := r.Save(out); err != nil {
+			return err
+		}
+		fmt.Printf("Saved recipe %q (version %s) to %s\n", r.Name, r.Version, out)
+		return nil
+	},
+}
+
+// userRecipesDir returns ~/.config/scbake/recipes (or the platform equivalent).
+func userRecipesDir() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "scbake", "recipes")
+}
+
+// applyRecipe merges a recipe into the run context of `scbake new`. Flags
+// take precedence: --lang replaces the recipe's language, --with adds
+// templates and --set, --license and --copyright-holder override metadata.
+func applyRecipe(rc *core.RunContext, r *recipe.Recipe) {
+	if rc.LangFlag == "" {
+		rc.LangFlag = r.Lang
+	}
+	rc.WithFlag = append(append([]string{}, r.With...), rc.WithFlag...)
+
+	setVars := make(map[string]string, len(r.Metadata)+len(rc.SetVars))
+	for k, v := range r.Metadata {
+		setVars[k] = v
+	}
+	if rc.License != "" {
+		delete(setVars, "license")
+	}
+	if rc.CopyrightHolder != "" {
+		delete(setVars, "copyright_holder")
+	}
+	for k, v := range rc.SetVars {
+		setVars[k] = v
+	}
+	rc.SetVars = setVars
+
+	// The root project's variables only apply to the recipe's language.
+	if len(r.ProjectMetadata) > 0 && rc.LangFlag == r.Lang {
+		rc.ProjectMetadata = make(map[string]string, len(r.ProjectMetadata))
+		for k, v := range r.ProjectMetadata {
+			rc.ProjectMetadata[k] = v
+		}
+	}
+
+	for _, p := range r.Projects {
+		rc.Projects = append(rc.Projects, core.ProjectSpec{Path: filepath.FromSlash(p.Path), Lang: p.Lang, With: p.With, Metadata: p.Metadata})
+	}
+}
+
+func init() {
+	recipeSaveCmd.Flags().StringVarP(&recipeOutputFlag, "output", "o", "", "Write the recipe to this file")
+	recipeSaveCmd.Flags().StringVar(&recipeVersionFlag, "version", "1.0.0", "Recipe version")
+	recipeSaveCmd.Flags().StringVar(&recipeDescriptionFlag, "description", "", "Recipe description")
+
+	recipeCmd.AddCommand(recipeSaveCmd)
+	rootCmd.AddCommand(recipeCmd)
+}
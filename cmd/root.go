@@ -27,6 +27,12 @@ var rootCmd = &cobra.Command{
 	Long: `scbake is a single-binary CLI for scaffolding new projects
 and applying layered infrastructure templates.`,
 
+	// Make declarative template packages available to every subcommand.
+	// Plugins are only run by the commands that use handlers (pluginPreRun).
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		registerPackages(templateDirFlag, GetRegistryCacheDir())
+	},
+
 	// If the user just types 'scbake', show the version
 	Run: func(cmd *cobra.Command, _ []string) {
 		v, _ := cmd.Flags().GetBool("version")
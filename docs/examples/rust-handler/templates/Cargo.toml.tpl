@@ -1,8 +0,0 @@
-[package]
-name = "{{ index .Projects 0 | .Name }}"
-version = "0.1.0"
-edition = "2021"
-
-[dependencies]
-
-[dev-dependencies]
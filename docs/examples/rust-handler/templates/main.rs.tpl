@@ -1,3 +0,0 @@
-fn main() {
-    println!("Hello from {{ index .Projects 0 | .Name }}!");
-}
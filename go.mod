@@ -6,6 +6,7 @@ toolchain go1.22.11
 
 require (
 	github.com/BurntSushi/toml v1.3.2
+	github.com/mitchellh/go-spdx v0.1.0
 	github.com/spf13/cobra v1.10.1
 )
 
@@ -14,7 +15,6 @@ require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/klauspost/cpuid/v2 v2.2.3 // indirect
 	github.com/minio/sha256-simd v1.0.1 // indirect
-	github.com/mitchellh/go-spdx v0.1.0 // indirect
 	github.com/spf13/pflag v1.0.9 // indirect
 	golang.org/x/sys v0.0.0-20220704084225-05e143d24a9e // indirect
 )
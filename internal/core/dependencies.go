@@ -0,0 +1,238 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"scbake/internal/types"
+	"scbake/internal/util/fileutil"
+	"scbake/pkg/templates"
+	"sort"
+	"strings"
+)
+
+// resolution is the template set selected for a run after dependency resolution.
+type resolution struct {
+	// Templates lists the templates to apply, required templates before the
+	// templates that need them.
+	Templates []string
+
+	// Included maps auto-included templates to the template that required them.
+	Included map[string]string
+
+	// Suggestions lists "<template> suggests <template>" for suggestions that
+	// are neither selected nor already applied.
+	Suggestions []string
+}
+
+// resolveTemplates checks the declared dependencies of the requested
+// templates against each other, the templates already recorded at the target
+// path and the languages of the projects in scope. Missing required templates
+// are added when rc.WithDeps is set; every other problem is collected and
+// reported at once.
+//
+//nolint:cyclop // Each dependency kind is a short, independent check.
+func resolveTemplates(rc RunContext, m *types.Manifest) (*resolution, error) {
+	res := &resolution{Included: map[string]string{}}
+	applied := appliedTemplates(m, rc.ManifestPathArg)
+	langs := scopeLanguages(rc, m)
+
+	selected := make(map[string]bool, len(rc.WithFlag))
+	for _, name := range rc.WithFlag {
+		selected[name] = true
+	}
+
+	var errs []error
+	deps := map[string]types.Dependencies{}
+
+	var visit func(name string)
+	visit = func(name string) {
+		if _, ok := deps[name]; ok {
+			return
+		}
+		d, err := templates.GetDependencies(name)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		deps[name] = d
+
+		for _, req := range d.Requires {
+			switch {
+			case selected[req] || applied[req]:
+			case rc.WithDeps:
+				res.Included[req] = name
+				selected[req] = true
+				visit(req)
+			default:
+				errs = append(errs, fmt.Errorf("template %q requires template %q (add --with %s or use --with-deps)", name, req, req))
+			}
+		}
+		res.Templates = append(res.Templates, name)
+	}
+	for _, name := range rc.WithFlag {
+		visit(name)
+	}
+
+	for _, name := range res.Templates {
+		d := deps[name]
+		for _, c := range d.Conflicts {
+			if selected[c] {
+				errs = append(errs, fmt.Errorf("template %q conflicts with template %q", name, c))
+			} else if applied[c] {
+				errs = append(errs, fmt.Errorf("template %q conflicts with template %q, already applied to %s", name, c, rc.ManifestPathArg))
+			}
+		}
+		if len(d.RequiresLanguages) > 0 && !anyIn(d.RequiresLanguages, langs) {
+			errs = append(errs, fmt.Errorf("template %q requires a project using %s (found: %s)",
+				name, strings.Join(d.RequiresLanguages, " or "), describeLanguages(langs)))
+		}
+		for _, l := range d.ConflictsLanguages {
+			if langs[l] {
+				errs = append(errs, fmt.Errorf("template %q cannot be applied to %s projects", name, l))
+			}
+		}
+		for _, s := range d.Suggests {
+			if !selected[s] && !applied[s] {
+				res.Suggestions = append(res.Suggestions, fmt.Sprintf("%s suggests %s", name, s))
+			}
+		}
+	}
+
+	errs = append(errs, checkBuildTools(rc, m, res.Templates, deps, langs)...)
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("template dependencies not satisfied:\n%w", errors.Join(errs...))
+	}
+	return res, nil
+}
+
+// appliedTemplates returns the templates the manifest records at path.
+func appliedTemplates(m *types.Manifest, path string) map[string]bool {
+	applied := map[string]bool{}
+	if m == nil {
+		return applied
+	}
+	for _, t := range m.Templates {
+		if t.Path == path {
+			applied[t.Name] = true
+		}
+	}
+	if p := findProject(m, path); p != nil {
+		for _, t := range p.Templates {
+			applied[t] = true
+		}
+	}
+	return applied
+}
+
+// scopeLanguages returns the languages of the projects a template applied at
+// the target path affects: the project registered there, or every project
+// when the path is not a project (e.g. the repository root), plus --lang.
+func scopeLanguages(rc RunContext, m *types.Manifest) map[string]bool {
+	langs := map[string]bool{}
+	if rc.LangFlag != "" {
+		langs[rc.LangFlag] = true
+	}
+	if m == nil {
+		return langs
+	}
+	if p := findProject(m, rc.ManifestPathArg); p != nil {
+		langs[p.Language] = true
+		return langs
+	}
+	for _, p := range m.Projects {
+		langs[p.Language] = true
+	}
+	return langs
+}
+
+// checkBuildTools checks the build tool requirements of names against the
+// projects in scope. Templates whose language requirement already failed are
+// not checked again.
+func checkBuildTools(rc RunContext, m *types.Manifest, names []string, deps map[string]types.Dependencies, langs map[string]bool) []error {
+	var errs []error
+	var tools map[string]bool
+	for _, name := range names {
+		d := deps[name]
+		if len(d.RequiresBuildTools) == 0 || (len(d.RequiresLanguages) > 0 && !anyIn(d.RequiresLanguages, langs)) {
+			continue
+		}
+		if tools == nil {
+			var err error
+			if tools, err = scopeBuildTools(rc, m); err != nil {
+				return []error{err}
+			}
+		}
+		if !anyIn(d.RequiresBuildTools, tools) {
+			errs = append(errs, fmt.Errorf("template %q requires a project built with %s (found: %s)",
+				name, strings.Join(d.RequiresBuildTools, " or "), describeBuildTools(tools)))
+		}
+	}
+	return errs
+}
+
+// scopeBuildTools returns the build tools of the projects in the same scope
+// as scopeLanguages, including the project --lang sets up.
+func scopeBuildTools(rc RunContext, m *types.Manifest) (map[string]bool, error) {
+	tools := map[string]bool{}
+	if rc.LangFlag != "" {
+		tool, err := langBuildTool(rc, m)
+		if err != nil {
+			return nil, err
+		}
+		tools[tool] = true
+	}
+	if m == nil {
+		return tools, nil
+	}
+	if p := findProject(m, rc.ManifestPathArg); p != nil {
+		tools[p.BuildTool] = true
+		return tools, nil
+	}
+	for _, p := range m.Projects {
+		tools[p.BuildTool] = true
+	}
+	return tools, nil
+}
+
+func anyIn(items []string, set map[string]bool) bool {
+	for _, i := range items {
+		if set[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// describeBuildTools formats a build tool set for error messages.
+func describeBuildTools(tools map[string]bool) string {
+	names := make([]string, 0, len(tools))
+	for t := range tools {
+		if t != "" {
+			names = append(names, t)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// describeLanguages formats a language set for error messages.
+func describeLanguages(langs map[string]bool) string {
+	names := make([]string, 0, len(langs))
+	for l := range langs {
+		if l != "" {
+			names = append(names, l)
+		}
+	}
+	if len(names) == 0 {
+		return "no projects in " + fileutil.ManifestFileName
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package core
+
+import (
+	"path/filepath"
+	"reflect"
+	"scbake/internal/types"
+	"strings"
+	"testing"
+)
+
+// depsHandler declares fixed dependencies for testing.
+type depsHandler struct {
+	noSchemaHandler
+	deps types.Dependencies
+}
+
+func (d *depsHandler) Dependencies() types.Dependencies { return d.deps }
+
+func registerDeps(t *testing.T, handlers map[string]types.Dependencies) {
+	t.Helper()
+	for name, deps := range handlers {
+		t.Cleanup(registerAndRestore(name, &depsHandler{deps: deps}))
+	}
+}
+
+func TestResolveTemplates_Requires(t *testing.T) {
+	registerDeps(t, map[string]types.Dependencies{
+		"dep_ci":   {Requires: []string{"dep_vcs"}, Suggests: []string{"dep_extra"}},
+		"dep_vcs":  {},
+		"dep_base": {Requires: []string{"dep_ci"}},
+	})
+
+	rc := RunContext{WithFlag: []string{"dep_base"}, ManifestPathArg: "."}
+	_, err := resolveTemplates(rc, &types.Manifest{})
+	if err == nil || !strings.Contains(err.Error(), `template "dep_base" requires template "dep_ci"`) {
+		t.Fatalf("expected a missing requirement error, got %v", err)
+	}
+
+	rc.WithDeps = true
+	res, err := resolveTemplates(rc, &types.Manifest{})
+	if err != nil {
+		t.Fatalf("resolveTemplates failed: %v", err)
+	}
+	if want := []string{"dep_vcs", "dep_ci", "dep_base"}; !reflect.DeepEqual(res.Templates, want) {
+		t.Errorf("Templates = %v, want %v", res.Templates, want)
+	}
+	if res.Included["dep_vcs"] != "dep_ci" || res.Included["dep_ci"] != "dep_base" {
+		t.Errorf("Included = %v", res.Included)
+	}
+	if want := []string{"dep_ci suggests dep_extra"}; !reflect.DeepEqual(res.Suggestions, want) {
+		t.Errorf("Suggestions = %v, want %v", res.Suggestions, want)
+	}
+
+	// Templates already recorded at the path satisfy requirements.
+	m := &types.Manifest{Templates: []types.Template{{Name: "dep_ci", Path: "."}}}
+	res, err = resolveTemplates(RunContext{WithFlag: []string{"dep_base"}, ManifestPathArg: "."}, m)
+	if err != nil || !reflect.DeepEqual(res.Templates, []string{"dep_base"}) {
+		t.Errorf("applied template should satisfy the requirement, got %v (err: %v)", res, err)
+	}
+}
+
+func TestResolveTemplates_Conflicts(t *testing.T) {
+	registerDeps(t, map[string]types.Dependencies{
+		"dep_a": {Conflicts: []string{"dep_b"}},
+		"dep_b": {},
+	})
+
+	_, err := resolveTemplates(RunContext{WithFlag: []string{"dep_a", "dep_b"}, ManifestPathArg: "."}, &types.Manifest{})
+	if err == nil || !strings.Contains(err.Error(), `template "dep_a" conflicts with template "dep_b"`) {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+
+	m := &types.Manifest{Templates: []types.Template{{Name: "dep_b", Path: "svc"}}}
+	_, err = resolveTemplates(RunContext{WithFlag: []string{"dep_a"}, ManifestPathArg: "svc"}, m)
+	if err == nil || !strings.Contains(err.Error(), "already applied to svc") {
+		t.Errorf("expected a conflict with the applied template, got %v", err)
+	}
+}
+
+func TestResolveTemplates_Languages(t *testing.T) {
+	registerDeps(t, map[string]types.Dependencies{
+		"dep_mvn":  {RequiresLanguages: []string{"spring"}},
+		"dep_nogo": {ConflictsLanguages: []string{"go"}},
+	})
+	m := &types.Manifest{Projects: []types.Project{
+		{Name: "api", Path: "api", Language: "go"},
+		{Name: "web", Path: "web", Language: "spring"},
+	}}
+
+	// At a Go project only the project's own language counts.
+	_, err := resolveTemplates(RunContext{WithFlag: []string{"dep_mvn", "dep_nogo"}, ManifestPathArg: "api"}, m)
+	if err == nil ||
+		!strings.Contains(err.Error(), `template "dep_mvn" requires a project using spring (found: go)`) ||
+		!strings.Contains(err.Error(), `template "dep_nogo" cannot be applied to go projects`) {
+		t.Errorf("expected both language errors, got %v", err)
+	}
+
+	// At the root every project is in scope.
+	if _, err := resolveTemplates(RunContext{WithFlag: []string{"dep_mvn"}, ManifestPathArg: "."}, m); err != nil {
+		t.Errorf("spring project at the root should satisfy the requirement: %v", err)
+	}
+
+	// --lang counts for new projects.
+	_, err = resolveTemplates(RunContext{WithFlag: []string{"dep_mvn"}, LangFlag: "spring", ManifestPathArg: "new"}, &types.Manifest{})
+	if err != nil {
+		t.Errorf("--lang spring should satisfy the requirement: %v", err)
+	}
+
+	_, err = resolveTemplates(RunContext{WithFlag: []string{"dep_mvn"}, ManifestPathArg: "."}, &types.Manifest{})
+	if err == nil || !strings.Contains(err.Error(), "found: no projects in scbake.toml") {
+		t.Errorf("expected an error without projects, got %v", err)
+	}
+}
+
+func TestResolveTemplates_BuildTools(t *testing.T) {
+	registerDeps(t, map[string]types.Dependencies{
+		"dep_mvn": {RequiresLanguages: []string{"spring"}, RequiresBuildTools: []string{"maven"}},
+	})
+	m := &types.Manifest{Projects: []types.Project{
+		{Name: "api", Path: "api", Language: "spring", BuildTool: "gradle"},
+		{Name: "web", Path: "web", Language: "go"},
+	}}
+
+	_, err := resolveTemplates(RunContext{WithFlag: []string{"dep_mvn"}, ManifestPathArg: "api"}, m)
+	if err == nil || !strings.Contains(err.Error(), `template "dep_mvn" requires a project built with maven (found: gradle)`) {
+		t.Errorf("expected a build tool error, got %v", err)
+	}
+
+	// A failed language requirement is not reported twice.
+	_, err = resolveTemplates(RunContext{WithFlag: []string{"dep_mvn"}, ManifestPathArg: "web"}, m)
+	if err == nil || strings.Contains(err.Error(), "built with") {
+		t.Errorf("expected only the language error, got %v", err)
+	}
+
+	// --lang counts with the build tool its handler selects.
+	rc := RunContext{
+		WithFlag:        []string{"dep_mvn"},
+		LangFlag:        "spring",
+		TargetPath:      filepath.Join(t.TempDir(), "new"),
+		ManifestPathArg: "new",
+		SetVars:         map[string]string{"build_tool": "gradle"},
+	}
+	if _, err := resolveTemplates(rc, &types.Manifest{}); err == nil || !strings.Contains(err.Error(), "found: gradle") {
+		t.Errorf("expected a build tool error for a new Gradle project, got %v", err)
+	}
+	rc.SetVars["build_tool"] = "maven"
+	if _, err := resolveTemplates(rc, &types.Manifest{}); err != nil {
+		t.Errorf("a new Maven project should satisfy the requirement: %v", err)
+	}
+}
@@ -4,26 +4,65 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"scbake/internal/types"
+	"scbake/pkg/tasks"
 	"sort"
 )
 
-// Execute runs the plan and reports progress via the provided Reporter.
+// Execute runs the plan one task at a time and reports progress via the provided Reporter.
 func Execute(plan *types.Plan, tc types.TaskContext, reporter types.Reporter) error {
+	return ExecuteJobs(plan, tc, reporter, 1)
+}
+
+// ExecuteJobs runs the plan with up to jobs tasks at a time. Tasks are
+// ordered by priority and their declared schedule (see buildGraph); only
+// tasks with disjoint resources run concurrently. Dry runs are always
+// sequential so the printed plan is stable.
+func ExecuteJobs(plan *types.Plan, tc types.TaskContext, reporter types.Reporter, jobs int) error {
 	sort.SliceStable(plan.Tasks, func(i, j int) bool {
 		return plan.Tasks[i].Priority() < plan.Tasks[j].Priority()
 	})
 
-	for i, task := range plan.Tasks {
-		reporter.TaskStart(task.Description(), i+1, len(plan.Tasks))
+	g, err := buildGraph(plan.Tasks)
+	if err != nil {
+		return fmt.Errorf("invalid plan: %w", err)
+	}
+
+	if jobs <= 1 || tc.DryRun {
+		return executeSequential(g, tc, reporter)
+	}
+	return executeParallel(g, tc, reporter, jobs)
+}
+
+func executeSequential(g *graph, tc types.TaskContext, reporter types.Reporter) error {
+	order, err := g.order()
+	if err != nil {
+		return err
+	}
+
+	for n, i := range order {
+		task := g.tasks[i]
+		run, reason, err := shouldRun(task, tc)
+		if err != nil {
+			return fmt.Errorf("task failed (%s): %w", task.Description(), err)
+		}
+		if !run {
+			reporter.TaskSkipped(task.Description(), reason, n+1, len(order))
+			continue
+		}
+		if err := reportSkippedParts(task, tc, reporter, n+1, len(order)); err != nil {
+			return err
+		}
+
+		reporter.TaskStart(task.Description(), n+1, len(order))
 
-		var err error
 		if !tc.DryRun {
 			err = task.Execute(tc)
 		}
 
-		reporter.TaskEnd(err)
+		reporter.TaskEnd(n+1, err)
 
 		if err != nil {
 			return fmt.Errorf("task failed (%s): %w", task.Description(), err)
@@ -32,3 +71,142 @@ func Execute(plan *types.Plan, tc types.TaskContext, reporter types.Reporter) er
 
 	return nil
 }
+
+// result is a finished task in a parallel run.
+type result struct {
+	index int
+	err   error
+}
+
+// executeParallel dispatches ready tasks to at most jobs goroutines. Ready
+// tasks start in priority order. The first failure cancels the context of
+// running tasks and stops dispatching; the run returns once every started
+// task has finished, so the caller's rollback sees all tracked paths.
+// Progress is reported from this goroutine: tasks are numbered and started
+// when they are dispatched and ended when they finish.
+//
+//nolint:cyclop // The dispatch loop is a single state machine.
+func executeParallel(g *graph, tc types.TaskContext, reporter types.Reporter, jobs int) error {
+	parent := tc.Ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+	tc.Ctx = ctx
+
+	total := len(g.tasks)
+	dependents := g.dependents()
+	pending := make([]int, total)
+	var ready []int
+	for j, p := range g.prereqs {
+		pending[j] = len(p)
+		if pending[j] == 0 {
+			ready = append(ready, j)
+		}
+	}
+
+	release := func(i int) {
+		for _, d := range dependents[i] {
+			pending[d]--
+			if pending[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+		sort.Ints(ready)
+	}
+
+	results := make(chan result)
+	running, dispatched := 0, 0
+	numbers := make([]int, total)
+	var firstErr error
+
+	for {
+		for firstErr == nil && running < jobs && len(ready) > 0 {
+			i := ready[0]
+			ready = ready[1:]
+			task := g.tasks[i]
+
+			run, reason, err := shouldRun(task, tc)
+			if err != nil {
+				firstErr = fmt.Errorf("task failed (%s): %w", task.Description(), err)
+				cancel()
+				break
+			}
+			if !run {
+				dispatched++
+				reporter.TaskSkipped(task.Description(), reason, dispatched, total)
+				release(i)
+				continue
+			}
+			if err := reportSkippedParts(task, tc, reporter, dispatched+1, total); err != nil {
+				firstErr = err
+				cancel()
+				break
+			}
+
+			dispatched++
+			numbers[i] = dispatched
+			reporter.TaskStart(task.Description(), dispatched, total)
+			running++
+			go func(i int, task types.Task) {
+				results <- result{index: i, err: task.Execute(tc)}
+			}(i, task)
+		}
+
+		if running == 0 {
+			break
+		}
+
+		r := <-results
+		running--
+		task := g.tasks[r.index]
+		reporter.TaskEnd(numbers[r.index], r.err)
+
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("task failed (%s): %w", task.Description(), r.err)
+				cancel()
+			}
+			continue
+		}
+		release(r.index)
+	}
+
+	return firstErr
+}
+
+// reportSkippedParts reports the parts a task will skip (see
+// types.SkippingTask), numbered like the task itself.
+func reportSkippedParts(task types.Task, tc types.TaskContext, reporter types.Reporter, current, total int) error {
+	st, ok := task.(types.SkippingTask)
+	if !ok {
+		return nil
+	}
+	parts, err := st.SkippedParts(tc)
+	if err != nil {
+		return fmt.Errorf("task failed (%s): %w", task.Description(), err)
+	}
+	for _, p := range parts {
+		reporter.TaskSkipped(task.Description()+": "+p.Description, p.Reason, current, total)
+	}
+	return nil
+}
+
+// shouldRun evaluates a task's `when` condition, if it has one.
+// Conditions are evaluated in dry-run mode too, so the plan shows skipped tasks.
+func shouldRun(task types.Task, tc types.TaskContext) (bool, string, error) {
+	ct, ok := task.(types.ConditionalTask)
+	if !ok || ct.Condition() == "" {
+		return true, "", nil
+	}
+
+	if pt, ok := task.(*projectTask); ok {
+		tc = pt.scope(tc)
+	}
+	run, err := tasks.EvalTaskCondition(ct.Condition(), tc)
+	if err != nil {
+		return false, "", err
+	}
+	return run, "when: " + ct.Condition(), nil
+}
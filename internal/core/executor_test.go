@@ -0,0 +1,112 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package core
+
+import (
+	"scbake/internal/types"
+	"scbake/pkg/tasks"
+	"testing"
+	"testing/fstest"
+)
+
+// conditionalMockTask is a MockTask with a `when` expression.
+type conditionalMockTask struct {
+	MockTask
+	when string
+	ran  bool
+}
+
+func (m *conditionalMockTask) Condition() string { return m.when }
+
+func (m *conditionalMockTask) Execute(tc types.TaskContext) error {
+	m.ran = true
+	return m.MockTask.Execute(tc)
+}
+
+// recordingReporter captures skipped tasks and ignores everything else.
+type recordingReporter struct {
+	started []string
+	skipped []string
+}
+
+func (r *recordingReporter) Step(_, _ string)       {}
+func (r *recordingReporter) SetTotalSteps(_ int)    {}
+func (r *recordingReporter) TaskEnd(_ int, _ error) {}
+func (r *recordingReporter) TaskStart(desc string, _, _ int) {
+	r.started = append(r.started, desc)
+}
+func (r *recordingReporter) TaskSkipped(desc, _ string, _, _ int) {
+	r.skipped = append(r.skipped, desc)
+}
+
+func TestExecute_ConditionalTasks(t *testing.T) {
+	goTask := &conditionalMockTask{MockTask: MockTask{Name: "go only", Prio: 1}, when: `has_language "go"`}
+	springTask := &conditionalMockTask{MockTask: MockTask{Name: "spring only", Prio: 2}, when: `has_language "spring"`}
+	plainTask := &conditionalMockTask{MockTask: MockTask{Name: "always", Prio: 3}}
+
+	plan := &types.Plan{Tasks: []types.Task{springTask, goTask, plainTask}}
+	tc := types.TaskContext{
+		Manifest: &types.Manifest{Projects: []types.Project{{Name: "api", Language: "go"}}},
+	}
+	reporter := &recordingReporter{}
+
+	if err := Execute(plan, tc, reporter); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if !goTask.ran || !plainTask.ran {
+		t.Error("Tasks with true or empty conditions should run")
+	}
+	if springTask.ran {
+		t.Error("Task with false condition should not run")
+	}
+	if len(reporter.skipped) != 1 || reporter.skipped[0] != "spring only" {
+		t.Errorf("Expected the spring task to be reported as skipped, got %v", reporter.skipped)
+	}
+	if len(reporter.started) != 2 {
+		t.Errorf("Expected 2 started tasks, got %v", reporter.started)
+	}
+}
+
+// TestExecute_SkippedTreeEntries checks that tree entries excluded by their
+// conditions are reported as skipped in a dry run, where the tree is not
+// executed.
+func TestExecute_SkippedTreeEntries(t *testing.T) {
+	tree := &tasks.CreateTreeTask{
+		TemplateFS: fstest.MapFS{
+			"tree/main.go.tpl":    {Data: []byte("package main\n")},
+			"tree/scripts/run.sh": {Data: []byte("#!/bin/sh\n")},
+		},
+		Root:       "tree",
+		Desc:       "Render tree",
+		Conditions: map[string]string{"scripts/*": `eq .Metadata.scripts "true"`},
+	}
+	plan := &types.Plan{Tasks: []types.Task{tree}}
+	tc := types.TaskContext{DryRun: true, Manifest: &types.Manifest{}}
+	reporter := &recordingReporter{}
+
+	if err := Execute(plan, tc, reporter); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if len(reporter.skipped) != 1 || reporter.skipped[0] != "Render tree: scripts/run.sh" {
+		t.Errorf("Expected the scripts entry to be reported as skipped, got %v", reporter.skipped)
+	}
+	if len(reporter.started) != 1 {
+		t.Errorf("Expected the tree task to be listed, got %v", reporter.started)
+	}
+}
+
+func TestExecute_InvalidCondition(t *testing.T) {
+	task := &conditionalMockTask{MockTask: MockTask{Name: "broken"}, when: `eq .Metadata.x`}
+	plan := &types.Plan{Tasks: []types.Task{task}}
+	tc := types.TaskContext{Manifest: &types.Manifest{}}
+
+	if err := Execute(plan, tc, &recordingReporter{}); err == nil {
+		t.Error("Expected error for invalid when expression")
+	}
+	if task.ran {
+		t.Error("Task with invalid condition must not run")
+	}
+}
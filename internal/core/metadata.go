@@ -0,0 +1,91 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package core
+
+import (
+	"fmt"
+	"io/fs"
+	"scbake/internal/schema"
+	"scbake/internal/types"
+	"scbake/pkg/lang"
+	"scbake/pkg/templates"
+)
+
+// langMetadata returns the values of the variables rc's language declares,
+// as the run sees them (see runMetadata), with schema defaults filled in.
+// They are stored on the project rather than in [metadata], so identifiers
+// such as module_path don't leak into the next project. Invalid values are
+// reported by validateSelectedTemplates.
+func langMetadata(rc RunContext, m *types.Manifest) (map[string]string, error) {
+	sfs, path, err := lang.GetSchema(rc.LangFlag)
+	s, err := readOptionalSchema(sfs, path, err)
+	if err != nil {
+		return nil, fmt.Errorf("schema lookup for %q: %w", rc.LangFlag, err)
+	}
+	if s == nil {
+		return nil, nil
+	}
+
+	run := runMetadata(rc, m)
+	values := make(map[string]string, len(s.Variables))
+	for name := range s.Variables {
+		if v, ok := run[name]; ok {
+			values[name] = v
+		}
+	}
+	schema.Validate(s, values)
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values, nil
+}
+
+// projectVars returns the metadata keys that belong to the projects of runs:
+// the variables of their languages, unless a template of any run declares
+// them too. Such keys are not written to [metadata].
+func projectVars(runs []RunContext) (map[string]bool, error) {
+	vars := map[string]bool{}
+	for _, r := range runs {
+		if r.LangFlag == "" {
+			continue
+		}
+		sfs, path, err := lang.GetSchema(r.LangFlag)
+		s, err := readOptionalSchema(sfs, path, err)
+		if err != nil {
+			return nil, fmt.Errorf("schema lookup for %q: %w", r.LangFlag, err)
+		}
+		if s == nil {
+			continue
+		}
+		for name := range s.Variables {
+			vars[name] = true
+		}
+	}
+
+	for _, r := range runs {
+		for _, name := range r.WithFlag {
+			sfs, path, err := templates.GetSchema(name)
+			s, err := readOptionalSchema(sfs, path, err)
+			if err != nil {
+				return nil, fmt.Errorf("schema lookup for %q: %w", name, err)
+			}
+			if s == nil {
+				continue
+			}
+			for v := range s.Variables {
+				delete(vars, v)
+			}
+		}
+	}
+	return vars, nil
+}
+
+// readOptionalSchema reads the schema a GetSchema lookup returned, or returns
+// nil if the handler declares none.
+func readOptionalSchema(sfs fs.FS, path string, err error) (*schema.Schema, error) {
+	if err != nil || sfs == nil {
+		return nil, err
+	}
+	return schema.ReadSchema(sfs, path)
+}
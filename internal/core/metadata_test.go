@@ -0,0 +1,80 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package core
+
+import (
+	"reflect"
+	"scbake/internal/types"
+	"testing"
+)
+
+func TestLangMetadata(t *testing.T) {
+	m := &types.Manifest{
+		Metadata: map[string]string{"module_path": "example.com/legacy", "license": "MIT"},
+		Projects: []types.Project{{Path: "api", Language: "go", Metadata: map[string]string{"module_path": "example.com/api", "layout": "http-service"}}},
+	}
+
+	// The project's stored values win over [metadata]; --set wins over both.
+	rc := RunContext{LangFlag: "go", ManifestPathArg: "api", SetVars: map[string]string{"go_version": "1.23"}}
+	got, err := langMetadata(rc, m)
+	if err != nil {
+		t.Fatalf("langMetadata failed: %v", err)
+	}
+	want := map[string]string{"module_path": "example.com/api", "layout": "http-service", "go_version": "1.23"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("langMetadata = %v, want %v", got, want)
+	}
+
+	// A new project gets the schema defaults; non-language keys are left out.
+	rc = RunContext{LangFlag: "go", ManifestPathArg: "worker", SetVars: map[string]string{"module_path": "example.com/worker"}}
+	if got, err = langMetadata(rc, m); err != nil {
+		t.Fatalf("langMetadata failed: %v", err)
+	}
+	want = map[string]string{"module_path": "example.com/worker", "layout": "cli", "go_version": "1.22"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("langMetadata = %v, want %v", got, want)
+	}
+}
+
+func TestProjectVars(t *testing.T) {
+	runs := []RunContext{
+		{LangFlag: "go", ManifestPathArg: "api"},
+		{WithFlag: []string{"ci_github"}, ManifestPathArg: "."},
+	}
+	vars, err := projectVars(runs)
+	if err != nil {
+		t.Fatalf("projectVars failed: %v", err)
+	}
+	// ci_github reads go_version from [metadata], so it stays there.
+	want := map[string]bool{"module_path": true, "layout": true}
+	if !reflect.DeepEqual(vars, want) {
+		t.Errorf("projectVars = %v, want %v", vars, want)
+	}
+}
+
+func TestSplitRuns_ProjectMetadata(t *testing.T) {
+	rc := RunContext{
+		SetVars: map[string]string{"go_version": "1.23", "module_path": "example.com/root"},
+		Projects: []ProjectSpec{
+			{Path: "api", Lang: "go", Metadata: map[string]string{"module_path": "example.com/api"}},
+			{Path: "worker", Lang: "go"},
+		},
+	}
+	runs, err := splitRuns(rc)
+	if err != nil {
+		t.Fatalf("splitRuns failed: %v", err)
+	}
+	if got := runs[0].SetVars["module_path"]; got != "example.com/api" {
+		t.Errorf("api module_path = %q, want the project's value", got)
+	}
+	if got := runs[0].SetVars["go_version"]; got != "1.23" {
+		t.Errorf("api go_version = %q, want the --set value", got)
+	}
+	if got := runs[1].SetVars["module_path"]; got != "example.com/root" {
+		t.Errorf("worker module_path = %q, want the --set value", got)
+	}
+	if got := rc.SetVars["module_path"]; got != "example.com/root" {
+		t.Errorf("splitRuns modified the --set values: %v", rc.SetVars)
+	}
+}
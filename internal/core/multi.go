@@ -0,0 +1,233 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"scbake/internal/types"
+)
+
+// ProjectSpec is one project of a multi-project apply.
+type ProjectSpec struct {
+	Path string   `toml:"path"` // Relative path, like the path argument of a single apply.
+	Lang string   `toml:"lang"` // Language pack to apply.
+	With []string `toml:"with"` // Templates to apply to this project only.
+	// Metadata sets language variables for this project only, overriding
+	// --set values (e.g. a module_path per Go project).
+	Metadata map[string]string `toml:"metadata"`
+}
+
+// planApply builds the plan for a run. Without RunContext.Projects it is a
+// single buildPlan; otherwise every project is planned at its own path and
+// the --lang/--with part of rc is planned last at rc's path, seeing all new
+// projects. It returns the run contexts with their resolved template sets
+// for schema validation and records which metadata keys belong to the
+// projects (see projectVars). The prerequisites of every language are checked
+// first (see checkPrerequisites).
+func planApply(rc RunContext, m *types.Manifest) (*types.Plan, *manifestChanges, *resolution, []RunContext, error) {
+	runs, err := splitRuns(rc)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if err := checkPrerequisites(runs, m); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	var (
+		plan    *types.Plan
+		changes *manifestChanges
+		res     *resolution
+	)
+	if len(rc.Projects) == 0 {
+		if plan, _, changes, res, err = buildPlan(rc, m); err != nil {
+			return nil, nil, nil, nil, err
+		}
+		rc.WithFlag = res.Templates
+		runs = []RunContext{rc}
+	} else if plan, changes, res, runs, err = buildMultiPlan(rc, m); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if changes.ProjectVars, err = projectVars(runs); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return plan, changes, res, runs, nil
+}
+
+// splitRuns returns a run context per project of rc.Projects, in order,
+// followed by the --lang/--with part of rc if it selects anything.
+func splitRuns(rc RunContext) ([]RunContext, error) {
+	var runs []RunContext
+	seen := map[string]bool{}
+
+	for _, p := range rc.Projects {
+		path := filepath.Clean(p.Path)
+		if seen[path] {
+			return nil, fmt.Errorf("project %s is listed more than once", p.Path)
+		}
+		seen[path] = true
+		if p.Lang == "" {
+			return nil, fmt.Errorf("project %s: no language given", p.Path)
+		}
+
+		absPath, err := filepath.Abs(p.Path)
+		if err != nil {
+			return nil, fmt.Errorf("project %s: failed to resolve path: %w", p.Path, err)
+		}
+
+		prc := rc
+		prc.Projects = nil
+		prc.ProjectMetadata = nil
+		prc.LangFlag = p.Lang
+		prc.WithFlag = deduplicateTemplates(p.With)
+		prc.TargetPath = absPath
+		prc.ManifestPathArg = p.Path
+		if len(p.Metadata) > 0 {
+			prc.SetVars = make(map[string]string, len(rc.SetVars)+len(p.Metadata))
+			for k, v := range rc.SetVars {
+				prc.SetVars[k] = v
+			}
+			for k, v := range p.Metadata {
+				prc.SetVars[k] = v
+			}
+		}
+		runs = append(runs, prc)
+	}
+
+	if rc.LangFlag != "" || len(rc.WithFlag) > 0 {
+		root := rc
+		root.Projects = nil
+		runs = append(runs, root)
+	}
+	return runs, nil
+}
+
+// buildMultiPlan plans every project of rc.Projects plus the optional
+// root part of rc into a single plan with a single set of manifest changes.
+func buildMultiPlan(rc RunContext, m *types.Manifest) (*types.Plan, *manifestChanges, *resolution, []RunContext, error) {
+	runs, err := splitRuns(rc)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	plan := &types.Plan{Tasks: []types.Task{}}
+	changes := &manifestChanges{}
+	res := &resolution{Included: map[string]string{}}
+
+	// Later projects and the root part see the projects planned before them.
+	future := m.DeepCopy()
+
+	for i, r := range runs {
+		isProject := i < len(rc.Projects)
+
+		rplan, _, rchanges, rres, err := buildPlan(r, future)
+		if err != nil {
+			if isProject {
+				return nil, nil, nil, nil, fmt.Errorf("project %s: %w", r.ManifestPathArg, err)
+			}
+			return nil, nil, nil, nil, err
+		}
+
+		label := ""
+		if isProject {
+			label = r.ManifestPathArg
+			for _, t := range rplan.Tasks {
+				plan.Tasks = append(plan.Tasks, &projectTask{Task: t, targetPath: r.TargetPath, path: r.ManifestPathArg})
+			}
+		} else {
+			plan.Tasks = append(plan.Tasks, rplan.Tasks...)
+		}
+		mergeChanges(changes, rchanges)
+		mergeResolution(res, rres, label)
+		future.Projects = append(future.Projects, rchanges.Projects...)
+
+		runs[i].WithFlag = rres.Templates
+	}
+
+	return plan, changes, res, runs, nil
+}
+
+func mergeChanges(dst, src *manifestChanges) {
+	dst.Projects = append(dst.Projects, src.Projects...)
+	dst.Templates = append(dst.Templates, src.Templates...)
+}
+
+// mergeResolution adds src to dst, labeling templates with their project path.
+func mergeResolution(dst, src *resolution, path string) {
+	label := func(name string) string {
+		if path == "" {
+			return name
+		}
+		return fmt.Sprintf("%s (%s)", name, path)
+	}
+	for _, t := range src.Templates {
+		dst.Templates = append(dst.Templates, label(t))
+	}
+	for t, by := range src.Included {
+		dst.Included[label(t)] = by
+	}
+	for _, s := range src.Suggestions {
+		dst.Suggestions = append(dst.Suggestions, label(s))
+	}
+}
+
+// projectTask runs a task planned for one project of a multi-project apply
+// with that project's target path and manifest entry. Unless the task
+// declares its own resources, it claims the project directory, so tasks of
+// different projects may run in parallel (see --jobs). Task IDs are
+// namespaced with the project path.
+type projectTask struct {
+	types.Task
+	targetPath string
+	path       string
+}
+
+func (t *projectTask) Execute(tc types.TaskContext) error {
+	return t.Task.Execute(t.scope(tc))
+}
+
+// scope returns tc as the wrapped task sees it, also when its condition is
+// evaluated.
+func (t *projectTask) scope(tc types.TaskContext) types.TaskContext {
+	tc.TargetPath = t.targetPath
+	tc.Project = findProject(tc.Manifest, t.path)
+	return tc
+}
+
+func (t *projectTask) Condition() string {
+	if ct, ok := t.Task.(types.ConditionalTask); ok {
+		return ct.Condition()
+	}
+	return ""
+}
+
+// SkippedParts forwards the parts the wrapped task skips (see
+// types.SkippingTask), evaluated for the task's project.
+func (t *projectTask) SkippedParts(tc types.TaskContext) ([]types.SkippedPart, error) {
+	st, ok := t.Task.(types.SkippingTask)
+	if !ok {
+		return nil, nil
+	}
+	return st.SkippedParts(t.scope(tc))
+}
+
+func (t *projectTask) Scheduling() types.Schedule {
+	var s types.Schedule
+	if st, ok := t.Task.(types.ScheduledTask); ok {
+		s = st.Scheduling()
+	}
+
+	scoped := types.Schedule{Resources: s.Resources}
+	if s.ID != "" {
+		scoped.ID = t.path + ":" + s.ID
+	}
+	for _, id := range s.After {
+		scoped.After = append(scoped.After, t.path+":"+id)
+	}
+	if len(scoped.Resources) == 0 {
+		scoped.Resources = []string{t.targetPath}
+	}
+	return scoped
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package core
+
+import (
+	"reflect"
+	"scbake/internal/types"
+	"scbake/pkg/tasks"
+	"testing"
+	"testing/fstest"
+)
+
+func TestProjectTask(t *testing.T) {
+	var got types.TaskContext
+	inner := scheduled("npm install", 100, types.Schedule{ID: "deps", After: []string{"init"}})
+	inner.run = func(tc types.TaskContext) error {
+		got = tc
+		return nil
+	}
+	task := &projectTask{Task: inner, targetPath: "/repo/web", path: "web"}
+
+	want := types.Schedule{ID: "web:deps", After: []string{"web:init"}, Resources: []string{"/repo/web"}}
+	if s := task.Scheduling(); !reflect.DeepEqual(s, want) {
+		t.Errorf("Scheduling() = %+v, want %+v", s, want)
+	}
+
+	m := &types.Manifest{Projects: []types.Project{{Name: "web", Path: "web", Language: "svelte"}}}
+	if err := task.Execute(types.TaskContext{TargetPath: "/repo", Manifest: m}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if got.TargetPath != "/repo/web" || got.Project == nil || got.Project.Name != "web" {
+		t.Errorf("task ran with TargetPath %q and project %+v", got.TargetPath, got.Project)
+	}
+}
+
+// TestProjectTask_SkippedParts checks that tree entries skipped by a project
+// task are reported, with conditions evaluated against the project.
+func TestProjectTask_SkippedParts(t *testing.T) {
+	tree := &tasks.CreateTreeTask{
+		TemplateFS: fstest.MapFS{
+			"tree/main.go.tpl":    {Data: []byte("package main\n")},
+			"tree/scripts/run.sh": {Data: []byte("#!/bin/sh\n")},
+		},
+		Root:       "tree",
+		Desc:       "Render tree",
+		Conditions: map[string]string{"scripts/*": `eq .Metadata.scripts "true"`},
+	}
+	plan := &types.Plan{Tasks: []types.Task{
+		&projectTask{Task: tree, targetPath: "/repo/api", path: "api"},
+		&projectTask{Task: tree, targetPath: "/repo/web", path: "web"},
+	}}
+	m := &types.Manifest{Projects: []types.Project{
+		{Name: "api", Path: "api", Language: "go", Metadata: map[string]string{"scripts": "true"}},
+		{Name: "web", Path: "web", Language: "svelte"},
+	}}
+	reporter := &recordingReporter{}
+
+	if err := Execute(plan, types.TaskContext{DryRun: true, Manifest: m}, reporter); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if len(reporter.skipped) != 1 || reporter.skipped[0] != "Render tree: scripts/run.sh" {
+		t.Errorf("Expected the web scripts entry to be reported as skipped, got %v", reporter.skipped)
+	}
+}
+
+func TestBuildMultiPlan_Invalid(t *testing.T) {
+	rc := RunContext{Projects: []ProjectSpec{{Path: "api", Lang: "go"}, {Path: "./api", Lang: "go"}}}
+	if _, _, _, _, err := buildMultiPlan(rc, &types.Manifest{}); err == nil {
+		t.Error("expected an error for a project listed twice")
+	}
+}
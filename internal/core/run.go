@@ -37,17 +37,26 @@ type RunContext struct {
 	DryRun             bool
 	Force              bool
 	ConflictStrategy   string
+	ConflictResolver   types.ConflictResolver // Used when ConflictStrategy is "prompt".
 	TemplateDir        string
 	RegistryCacheDir   string
 	License            string
 	CopyrightHolder    string
 	SetVars           map[string]string
+	ProjectMetadata   map[string]string // Language variables of the project at ManifestPathArg only; --set wins.
+	WithDeps          bool // Auto-include templates required by --with templates.
+	Jobs              int  // Maximum number of tasks run concurrently; 0 or 1 runs sequentially.
+	Projects          []ProjectSpec // Further projects planned into the same transaction.
+	ScbakeVersion     string // Exposed to templates as .Version.
 }
 
 // A struct to hold all proposed manifest changes
 type manifestChanges struct {
 	Projects  []types.Project
 	Templates []types.Template
+	// ProjectVars are metadata keys stored on the projects (see
+	// projectVars); they are not written to [metadata].
+	ProjectVars map[string]bool
 }
 
 // RunApply orchestrates the template application process.
@@ -88,37 +97,27 @@ func RunApply(rc RunContext, reporter types.Reporter) error {
 	// Deduplicate requested templates to ensure idempotency
 	rc.WithFlag = deduplicateTemplates(rc.WithFlag)
 
-	plan, _, changes, err := buildPlan(rc)
+	plan, changes, res, runs, err := planApply(rc, m)
 	if err != nil {
 		return err
 	}
+	reportResolution(res, rc.DryRun)
 
 	// Prepare task context with timeout
 	// Deep copy manifest to ensure modifications don't affect original
 	futureManifest := m.DeepCopy()
 
 	// Update metadata from context if provided
-	if rc.License != "" || rc.CopyrightHolder != "" || len(rc.SetVars) > 0 {
-		if futureManifest.Metadata == nil {
-			futureManifest.Metadata = make(map[string]string)
-		}
-		if rc.License != "" {
-			futureManifest.Metadata["license"] = rc.License
-		}
-		if rc.CopyrightHolder != "" {
-			futureManifest.Metadata["copyright_holder"] = rc.CopyrightHolder
-		}
-		for k, v := range rc.SetVars {
-			futureManifest.Metadata[k] = v
-		}
-	}
+	applyMetadata(futureManifest, rc)
 
-	futureManifest.Projects = append(futureManifest.Projects, changes.Projects...)
-	futureManifest.Templates = append(futureManifest.Templates, changes.Templates...)
+	updateManifest(futureManifest, changes)
 
 	// Validate all selected templates against their schemas before executing
-	if err := validateSelectedTemplates(rc, futureManifest.Metadata); err != nil {
-		return err
+	for _, r := range runs {
+		projectMetadata := futureManifest.ProjectMetadata(findProject(futureManifest, r.ManifestPathArg))
+		if err := validateSelectedTemplates(r, futureManifest.Metadata, projectMetadata); err != nil {
+			return err
+		}
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
@@ -128,19 +127,30 @@ func RunApply(rc RunContext, reporter types.Reporter) error {
 		Ctx:               ctx,
 		DryRun:            rc.DryRun,
 		Manifest:          futureManifest,
+		Project:           findProject(futureManifest, rc.ManifestPathArg),
+		ScbakeVersion:     rc.ScbakeVersion,
 		TargetPath:        rc.TargetPath,
+		RootPath:          rootPath,
 		Force:             rc.Force,
 		ConflictStrategy:  rc.ConflictStrategy,
+		ConflictResolver:  rc.ConflictResolver,
 		TemplateDir:       rc.TemplateDir,
 		RegistryCacheDir:   rc.RegistryCacheDir,
 		Tx:                tx,
+		Conflicts:         &types.ConflictReport{},
 	}
 
 	if rc.DryRun {
 		return Execute(plan, tc, reporter)
 	}
 
-	return executeAndFinalize(reporter, plan, tc, m, changes, rootPath, tx)
+	jobs := rc.Jobs
+	if usesPrompt(rc.ConflictStrategy, m) {
+		// Prompts read from the terminal, one drifted file at a time.
+		jobs = 1
+	}
+
+	return executeAndFinalize(reporter, plan, tc, m, changes, rootPath, tx, jobs)
 }
 
 //nolint:cyclop // Complex finalization logic requires multiple linear steps
@@ -152,22 +162,25 @@ func executeAndFinalize(
 	changes *manifestChanges,
 	rootPath string,
 	tx *transaction.Manager,
+	jobs int,
 ) error {
 	reporter.Step("🚀", "Executing plan...")
-	if err := Execute(plan, tc, reporter); err != nil {
+	if err := ExecuteJobs(plan, tc, reporter, jobs); err != nil {
 		return err
 	}
 
 	reporter.Step("✍️", "Updating manifest...")
 	updateManifest(m, changes)
 
-	// Persist metadata
+	// Persist metadata; language variables were stored on their projects.
 	if tc.Manifest.Metadata != nil {
 		if m.Metadata == nil {
 			m.Metadata = make(map[string]string)
 		}
 		for k, v := range tc.Manifest.Metadata {
-			m.Metadata[k] = v
+			if !changes.ProjectVars[k] {
+				m.Metadata[k] = v
+			}
 		}
 	}
 
@@ -199,19 +212,66 @@ func executeAndFinalize(
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	reportConflicts(tc.Conflicts)
+
+	return nil
+}
+
+// usesPrompt reports whether the run may ask the user about drifted files,
+// either through --conflict-strategy or a [conflict_strategies] override.
+func usesPrompt(strategy string, m *types.Manifest) bool {
+	if strategy == "prompt" {
+		return true
+	}
+	for _, s := range m.ConflictStrategies {
+		if s == "prompt" {
+			return true
+		}
+	}
+	return false
+}
+
+// reportConflicts lists files left with merge conflict markers so the user can resolve them.
+func reportConflicts(conflicts *types.ConflictReport) {
+	files := conflicts.Files()
+	if len(files) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\n⚠️  %d file(s) contain merge conflicts that need manual resolution:\n", len(files))
+	for _, f := range files {
+		fmt.Fprintf(os.Stderr, "  - %s\n", f)
+	}
+}
+
+// findProject returns the project registered at path, or nil if there is none.
+func findProject(m *types.Manifest, path string) *types.Project {
+	for i := range m.Projects {
+		if filepath.Clean(m.Projects[i].Path) == filepath.Clean(path) {
+			return &m.Projects[i]
+		}
+	}
 	return nil
 }
 
-// updateManifest merges new projects and templates into the existing manifest structure.
+// updateManifest merges new projects and templates into the existing manifest
+// structure. Projects that already exist get the new project metadata.
 func updateManifest(m *types.Manifest, changes *manifestChanges) {
 	// Update Projects (ensure no duplicates by path)
-	existingProjects := make(map[string]bool)
-	for _, proj := range m.Projects {
-		existingProjects[proj.Path] = true
+	existingProjects := make(map[string]int)
+	for i, proj := range m.Projects {
+		existingProjects[proj.Path] = i
 	}
 	for _, newProj := range changes.Projects {
-		if !existingProjects[newProj.Path] {
+		i, ok := existingProjects[newProj.Path]
+		if !ok {
 			m.Projects = append(m.Projects, newProj)
+			continue
+		}
+		if len(newProj.Metadata) > 0 && m.Projects[i].Metadata == nil {
+			m.Projects[i].Metadata = make(map[string]string, len(newProj.Metadata))
+		}
+		for k, v := range newProj.Metadata {
+			m.Projects[i].Metadata[k] = v
 		}
 	}
 	// Update Templates (ensure no duplicates by name + path)
@@ -228,26 +288,105 @@ func updateManifest(m *types.Manifest, changes *manifestChanges) {
 	}
 }
 
+// applyMetadata copies --license, --copyright-holder and --set values into m.
+func applyMetadata(m *types.Manifest, rc RunContext) {
+	if rc.License == "" && rc.CopyrightHolder == "" && len(rc.SetVars) == 0 {
+		return
+	}
+	if m.Metadata == nil {
+		m.Metadata = make(map[string]string)
+	}
+	if rc.License != "" {
+		m.Metadata["license"] = rc.License
+	}
+	if rc.CopyrightHolder != "" {
+		m.Metadata["copyright_holder"] = rc.CopyrightHolder
+	}
+	for k, v := range rc.SetVars {
+		m.Metadata[k] = v
+	}
+}
+
+// runMetadata returns the metadata a run executes with: m's metadata,
+// overridden by the metadata of the project at rc's path and
+// rc.ProjectMetadata, updated with the run's flags, as applyMetadata does for
+// the executing manifest.
+func runMetadata(rc RunContext, m *types.Manifest) map[string]string {
+	metadata := m.ProjectMetadata(findProject(m, rc.ManifestPathArg))
+	future := &types.Manifest{Metadata: make(map[string]string, len(metadata)+len(rc.ProjectMetadata))}
+	for k, v := range metadata {
+		future.Metadata[k] = v
+	}
+	for k, v := range rc.ProjectMetadata {
+		future.Metadata[k] = v
+	}
+	applyMetadata(future, rc)
+	return future.Metadata
+}
+
+// ValidateInputs checks that every language and template of a run exists,
+// that template dependencies are satisfied and that the metadata passes all
+// schemas, without asking handlers for tasks or touching the filesystem.
+// Callers that create directories (e.g. `scbake new`) use it to fail first.
+// m is the manifest the run would start from.
+func ValidateInputs(rc RunContext, m *types.Manifest) error {
+	rc.WithFlag = deduplicateTemplates(rc.WithFlag)
+	runs, err := splitRuns(rc)
+	if err != nil {
+		return err
+	}
+
+	future := m.DeepCopy()
+	applyMetadata(future, rc)
+
+	for _, r := range runs {
+		if r.LangFlag != "" {
+			if _, err := lang.GetHandler(r.LangFlag); err != nil {
+				return err
+			}
+		}
+		if len(r.WithFlag) > 0 {
+			res, err := resolveTemplates(r, future)
+			if err != nil {
+				return err
+			}
+			r.WithFlag = res.Templates
+		}
+		if err := validateSelectedTemplates(r, future.Metadata, runMetadata(r, future)); err != nil {
+			return err
+		}
+		if r.LangFlag != "" {
+			buildTool, err := langBuildTool(r, future)
+			if err != nil {
+				return err
+			}
+			future.Projects = append(future.Projects, types.Project{Path: r.ManifestPathArg, Language: r.LangFlag, BuildTool: buildTool})
+		}
+	}
+	return nil
+}
+
 // validateSelectedTemplates checks every requested template and language
 // handler's schema against the provided metadata. It collects all errors and
 // reports them at once so the user can fix everything in a single pass.
-// Language handlers that implement SchemaProvider are also validated.
+// Language handlers that implement SchemaProvider are also validated, against
+// projectMetadata, the metadata of the project the run sets up.
 //
 //nolint:cyclop // Two independent branches (with + lang) each have standard checks.
-func validateSelectedTemplates(rc RunContext, metadata map[string]string) error {
+func validateSelectedTemplates(rc RunContext, metadata, projectMetadata map[string]string) error {
 	var allErrors []string
 
 	// Validate --with template handlers
 	for _, name := range rc.WithFlag {
-		efs, path, err := templates.GetSchema(name)
+		sfs, path, err := templates.GetSchema(name)
 		if err != nil {
 			return fmt.Errorf("schema lookup for %q: %w", name, err)
 		}
-		if efs == nil {
+		if sfs == nil {
 			continue
 		}
 
-		s, err := schema.ReadSchema(*efs, path)
+		s, err := schema.ReadSchema(sfs, path)
 		if err != nil {
 			return fmt.Errorf("failed to read schema for %q: %w", name, err)
 		}
@@ -260,17 +399,17 @@ func validateSelectedTemplates(rc RunContext, metadata map[string]string) error
 
 	// Validate --lang handlers that implement SchemaProvider
 	if rc.LangFlag != "" {
-		efs, path, err := lang.GetSchema(rc.LangFlag)
+		sfs, path, err := lang.GetSchema(rc.LangFlag)
 		if err != nil {
 			return fmt.Errorf("schema lookup for %q: %w", rc.LangFlag, err)
 		}
-		if efs != nil {
-			s, rErr := schema.ReadSchema(*efs, path)
+		if sfs != nil {
+			s, rErr := schema.ReadSchema(sfs, path)
 			if rErr != nil {
 				return fmt.Errorf("failed to read schema for %q: %w", rc.LangFlag, rErr)
 			}
 
-			result := schema.Validate(s, metadata)
+			result := schema.Validate(s, projectMetadata)
 			if result.HasErrors() {
 				allErrors = append(allErrors, fmt.Sprintf("language %q:\n%s", rc.LangFlag, result.Error()))
 			}
@@ -301,26 +440,36 @@ func deduplicateTemplates(requested []string) []string {
 	return result
 }
 
-// buildPlan constructs the list of tasks based on CLI flags.
-func buildPlan(rc RunContext) (*types.Plan, string, *manifestChanges, error) {
+// buildPlan constructs the list of tasks based on CLI flags. Template
+// dependencies are resolved against m before any handler is asked for tasks.
+func buildPlan(rc RunContext, m *types.Manifest) (*types.Plan, string, *manifestChanges, *resolution, error) {
 	plan := &types.Plan{Tasks: []types.Task{}}
 	changes := &manifestChanges{}
 	commitMessage := "scbake: Apply templates"
 	didSomething := false
 
+	res := &resolution{}
+	if len(rc.WithFlag) > 0 {
+		var err error
+		if res, err = resolveTemplates(rc, m); err != nil {
+			return nil, "", nil, nil, err
+		}
+		rc.WithFlag = res.Templates
+	}
+
 	if rc.LangFlag != "" {
 		didSomething = true
-		msg, err := handleLangFlag(rc, plan, changes)
+		msg, err := handleLangFlag(rc, m, plan, changes)
 		if err != nil {
-			return nil, "", nil, err
+			return nil, "", nil, nil, err
 		}
 		commitMessage = msg
 	}
 
 	if len(rc.WithFlag) > 0 {
 		didSomething = true
-		if err := handleWithFlag(rc, plan, changes); err != nil {
-			return nil, "", nil, err
+		if err := handleWithFlag(rc, m, plan, changes); err != nil {
+			return nil, "", nil, nil, err
 		}
 		// Update commit message based on what was done
 		if rc.LangFlag == "" {
@@ -330,37 +479,79 @@ func buildPlan(rc RunContext) (*types.Plan, string, *manifestChanges, error) {
 
 	// Only fail if neither a language nor tooling was specified.
 	if !didSomething {
-		return nil, "", nil, errors.New("no language or templates specified")
+		return nil, "", nil, nil, errors.New("no language or templates specified")
 	}
 
-	return plan, commitMessage, changes, nil
+	return plan, commitMessage, changes, res, nil
 }
 
-// checkLangPrerequisites validates that required binaries are available for the language.
-func checkLangPrerequisites(langFlag string) error {
-	switch langFlag {
-	case "go":
-		return preflight.CheckBinaries("go")
-	case "svelte":
-		return preflight.CheckBinaries("npm")
-	case "spring":
-		return preflight.CheckBinaries("curl", "unzip", "java")
+// reportResolution lists suggested templates and, in dry-run mode, the
+// resolved template set including auto-included dependencies.
+func reportResolution(res *resolution, dryRun bool) {
+	if dryRun && len(res.Templates) > 0 {
+		names := make([]string, len(res.Templates))
+		for i, t := range res.Templates {
+			names[i] = t
+			if by, ok := res.Included[t]; ok {
+				names[i] = fmt.Sprintf("%s (required by %s)", t, by)
+			}
+		}
+		fmt.Printf("  [DRY RUN] Templates: %s\n", strings.Join(names, ", "))
+	}
+	for _, s := range res.Suggestions {
+		fmt.Printf("  💡 %s\n", s)
 	}
-	return nil
 }
 
-// handleLangFlag processes the --lang flag, adding language tasks and project info.
-func handleLangFlag(rc RunContext, plan *types.Plan, changes *manifestChanges) (string, error) {
-	if err := checkLangPrerequisites(rc.LangFlag); err != nil {
-		return "", err
+// checkPrerequisites checks the binaries the languages of every run need,
+// with preflight.Check, before anything is planned. Handlers declare them
+// through lang.PreflightProvider, with version constraints, or through
+// lang.PrerequisitesProvider. Every unmet requirement is reported at once.
+func checkPrerequisites(runs []RunContext, m *types.Manifest) error {
+	var reqs []preflight.Requirement
+	for _, r := range runs {
+		if r.LangFlag == "" {
+			continue
+		}
+		h, err := lang.GetHandler(r.LangFlag)
+		if err != nil {
+			// Unknown languages are reported by buildPlan.
+			continue
+		}
+
+		langReqs, err := lang.Requirements(h, r.TargetPath, runMetadata(r, m))
+		if err != nil {
+			return fmt.Errorf("failed to determine prerequisites for lang '%s': %w", r.LangFlag, err)
+		}
+
+		label := r.LangFlag
+		if r.ManifestPathArg != "." && r.ManifestPathArg != "" {
+			label = fmt.Sprintf("%s at %s", r.LangFlag, r.ManifestPathArg)
+		}
+		for _, req := range langReqs {
+			if req.Reason == "" {
+				req.Reason = label
+			} else {
+				req.Reason = label + ", " + req.Reason
+			}
+			reqs = append(reqs, req)
+		}
 	}
 
+	if len(reqs) == 0 {
+		return nil
+	}
+	return preflight.Check(context.Background(), reqs...).Err()
+}
+
+// handleLangFlag processes the --lang flag, adding language tasks and project info.
+func handleLangFlag(rc RunContext, m *types.Manifest, plan *types.Plan, changes *manifestChanges) (string, error) {
 	handler, err := lang.GetHandler(rc.LangFlag)
 	if err != nil {
 		return "", err
 	}
 
-	langTasks, err := handler.GetTasks(rc.TargetPath, rc.TemplateDir, rc.RegistryCacheDir)
+	langTasks, err := handlerTasks(handler, rc, m)
 	if err != nil {
 		return "", fmt.Errorf("failed to get tasks for lang '%s': %w", rc.LangFlag, err)
 	}
@@ -376,24 +567,61 @@ func handleLangFlag(rc RunContext, plan *types.Plan, changes *manifestChanges) (
 		return "", fmt.Errorf("could not determine project name: %w", err)
 	}
 
+	buildTool, err := langBuildTool(rc, m)
+	if err != nil {
+		return "", err
+	}
+
+	metadata, err := langMetadata(rc, m)
+	if err != nil {
+		return "", err
+	}
+
 	changes.Projects = append(changes.Projects, types.Project{
-		Name:     projectName,
-		Path:     rc.ManifestPathArg,
-		Language: rc.LangFlag,
+		Name:      projectName,
+		Path:      rc.ManifestPathArg,
+		Language:  rc.LangFlag,
+		BuildTool: buildTool,
+		Metadata:  metadata,
 	})
 
 	return fmt.Sprintf("scbake: Apply '%s' to %s", rc.LangFlag, rc.ManifestPathArg), nil
 }
 
+// langBuildTool returns the build tool of the project rc's --lang sets up, or
+// "" if the language has one build tool or is unknown.
+func langBuildTool(rc RunContext, m *types.Manifest) (string, error) {
+	// Unknown languages are reported by the callers.
+	handler, _ := lang.GetHandler(rc.LangFlag)
+	bp, ok := handler.(lang.BuildToolProvider)
+	if !ok {
+		return "", nil
+	}
+	tool, err := bp.BuildTool(rc.TargetPath, runMetadata(rc, m))
+	if err != nil {
+		return "", fmt.Errorf("failed to determine build tool for lang '%s': %w", rc.LangFlag, err)
+	}
+	return tool, nil
+}
+
+// handlerTasks asks a language or template handler for its tasks. Handlers
+// implementing types.MetadataTaskProvider also get the run's metadata.
+func handlerTasks(h templates.Handler, rc RunContext, m *types.Manifest) ([]types.Task, error) {
+	if mp, ok := h.(types.MetadataTaskProvider); ok {
+		return mp.GetTasksWithMetadata(rc.TargetPath, rc.TemplateDir, rc.RegistryCacheDir, runMetadata(rc, m))
+	}
+	return h.GetTasks(rc.TargetPath, rc.TemplateDir, rc.RegistryCacheDir)
+}
+
 // handleWithFlag processes the --with flag, adding template tasks.
-func handleWithFlag(rc RunContext, plan *types.Plan, changes *manifestChanges) error {
+func handleWithFlag(rc RunContext, m *types.Manifest, plan *types.Plan, changes *manifestChanges) error {
 	for _, tmplName := range rc.WithFlag {
 		handler, err := templates.GetHandler(tmplName)
 		if err != nil {
 			return err
 		}
 
-		tmplTasks, err := handler.GetTasks(rc.TargetPath, rc.TemplateDir, rc.RegistryCacheDir)
+		tmplTasks, err := handlerTasks(handler, rc, m)
 		if err != nil {
 			return fmt.Errorf("failed to get tasks for template '%s': %w", tmplName, err)
 		}
@@ -405,10 +633,12 @@ func handleWithFlag(rc RunContext, plan *types.Plan, changes *manifestChanges) e
 		}
 	}
 
-	// Simplified change tracking for root templates:
-	changes.Templates = append(changes.Templates, types.Template{
-		Name: "root-templates",
-		Path: rc.ManifestPathArg,
-	})
+	// Record every applied template so later runs can check dependencies against it.
+	for _, tmplName := range rc.WithFlag {
+		changes.Templates = append(changes.Templates, types.Template{
+			Name: tmplName,
+			Path: rc.ManifestPathArg,
+		})
+	}
 	return nil
 }
@@ -10,9 +10,12 @@ import (
 	"path/filepath"
 	"scbake/internal/filesystem/transaction"
 	"scbake/internal/manifest"
+	"scbake/internal/preflight"
 	"scbake/internal/types"
 	"scbake/internal/ui"
 	"scbake/internal/util/fileutil"
+	"scbake/pkg/lang"
+	"strings"
 	"testing"
 )
 
@@ -105,7 +108,7 @@ func TestExecuteAndFinalize_Rollback(t *testing.T) {
 	// We use 3 as the denominator to ensure the UI doesn't drift.
 	reporter := ui.NewPlainReporter(3, false)
 	// Execute should fail
-	err = executeAndFinalize(reporter, plan, tc, m, changes, rootPath, tx)
+	err = executeAndFinalize(reporter, plan, tc, m, changes, rootPath, tx, 1)
 
 	// Assert Failure
 	if err == nil {
@@ -165,7 +168,7 @@ func TestExecuteAndFinalize_ManagedFilesRecords(t *testing.T) {
 	}
 
 	reporter := ui.NewPlainReporter(3, false)
-	if err := executeAndFinalize(reporter, plan, tc, m, changes, rootPath, tx); err != nil {
+	if err := executeAndFinalize(reporter, plan, tc, m, changes, rootPath, tx, 1); err != nil {
 		t.Fatalf("Execution failed: %v", err)
 	}
 
@@ -211,7 +214,7 @@ func TestExecuteAndFinalize_EmptyPlan(t *testing.T) {
 	}
 
 	reporter := ui.NewPlainReporter(0, false)
-	if err := executeAndFinalize(reporter, plan, tc, m, changes, rootPath, tx); err != nil {
+	if err := executeAndFinalize(reporter, plan, tc, m, changes, rootPath, tx, 1); err != nil {
 		t.Fatalf("Empty plan execution should succeed: %v", err)
 	}
 }
@@ -262,7 +265,7 @@ func TestExecuteAndFinalize_Success(t *testing.T) {
 
 	// executeAndFinalize calls Step() exactly 3 times.
 	reporter := ui.NewPlainReporter(3, false)
-	if err := executeAndFinalize(reporter, plan, tc, m, changes, rootPath, tx); err != nil {
+	if err := executeAndFinalize(reporter, plan, tc, m, changes, rootPath, tx, 1); err != nil {
 		t.Fatalf("Execution failed: %v", err)
 	}
 
@@ -290,3 +293,65 @@ func TestExecuteAndFinalize_Success(t *testing.T) {
 	}
 	// Note: We don't fail on IsNotExist, as that is a valid clean state.
 }
+
+func TestFindProject(t *testing.T) {
+	m := &types.Manifest{Projects: []types.Project{
+		{Name: "api", Path: "backend/api"},
+		{Name: "root", Path: "."},
+	}}
+
+	if p := findProject(m, "./backend/api/"); p == nil || p.Name != "api" {
+		t.Errorf("findProject(backend/api) = %v, want api", p)
+	}
+	if p := findProject(m, "."); p == nil || p.Name != "root" {
+		t.Errorf("findProject(.) = %v, want root", p)
+	}
+	if p := findProject(m, "web"); p != nil {
+		t.Errorf("findProject(web) = %v, want nil", p)
+	}
+}
+
+// preflightHandler declares requirements that can never be met, one of them
+// depending on the metadata of the run.
+type preflightHandler struct{}
+
+func (h *preflightHandler) GetTasks(string, string, string) ([]types.Task, error) {
+	return []types.Task{&MockTask{Name: "noop", Prio: 100}}, nil
+}
+
+func (h *preflightHandler) Requirements(_ string, metadata map[string]string) ([]preflight.Requirement, error) {
+	return []preflight.Requirement{{Binary: "scbake-missing-" + metadata["tool"], Constraint: ">=2", Reason: "tool = " + metadata["tool"]}}, nil
+}
+
+func (h *preflightHandler) RequiredBinaries() []string { return []string{"scbake-missing-declared"} }
+
+// TestCheckPrerequisites checks that the requirements of every run are
+// reported together, labeled with their language and project.
+func TestCheckPrerequisites(t *testing.T) {
+	lang.Register("preflight-test", &preflightHandler{})
+
+	runs := []RunContext{
+		{LangFlag: "preflight-test", ManifestPathArg: "web", SetVars: map[string]string{"tool": "a"}},
+		{LangFlag: "preflight-test", ManifestPathArg: "."},
+		{WithFlag: []string{"makefile"}, ManifestPathArg: "."},
+	}
+	err := checkPrerequisites(runs, &types.Manifest{Metadata: map[string]string{"tool": "b"}})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{
+		"scbake-missing-a >=2 (preflight-test at web, tool = a): not found in $PATH",
+		"scbake-missing-declared (preflight-test at web): not found in $PATH",
+		"scbake-missing-b >=2 (preflight-test, tool = b): not found in $PATH",
+		"scbake-missing-declared (preflight-test): not found in $PATH",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in:\n%v", want, err)
+		}
+	}
+
+	// Runs without a language and unknown languages are not checked here.
+	if err := checkPrerequisites([]RunContext{{LangFlag: "no-such-lang"}, runs[2]}, &types.Manifest{}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
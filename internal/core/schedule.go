@@ -0,0 +1,170 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"scbake/internal/types"
+	"strings"
+)
+
+// graph is a plan's dependency graph. Tasks are in priority order and
+// prereqs[j] lists the indices of tasks that must finish before task j.
+type graph struct {
+	tasks   []types.Task
+	prereqs [][]int
+}
+
+// buildGraph derives the dependency graph of tasks, which must already be
+// sorted by priority:
+//  1. Every task waits for the tasks named in its Schedule.After.
+//  2. Otherwise, a task waits for every earlier task it shares a resource
+//     with, so the priority bands still order conflicting work. Tasks without
+//     resources share everything with everyone.
+//
+// Explicit dependencies win over the priority order; cycles are rejected.
+func buildGraph(tasks []types.Task) (*graph, error) {
+	n := len(tasks)
+	scheds := make([]types.Schedule, n)
+	ids := make(map[string]int, n)
+	for i, t := range tasks {
+		if st, ok := t.(types.ScheduledTask); ok {
+			scheds[i] = st.Scheduling()
+		}
+		if id := scheds[i].ID; id != "" {
+			if j, dup := ids[id]; dup {
+				return nil, fmt.Errorf("duplicate task ID %q (%s, %s)", id, tasks[j].Description(), t.Description())
+			}
+			ids[id] = i
+		}
+	}
+
+	g := &graph{tasks: tasks, prereqs: make([][]int, n)}
+	explicit := make([][]int, n)
+	for j, s := range scheds {
+		for _, id := range s.After {
+			i, ok := ids[id]
+			if !ok {
+				return nil, fmt.Errorf("task %q waits for unknown task ID %q", tasks[j].Description(), id)
+			}
+			explicit[j] = append(explicit[j], i)
+		}
+		g.prereqs[j] = append(g.prereqs[j], explicit[j]...)
+	}
+
+	// precedes[a][b] reports that a must (transitively) finish before b by
+	// explicit dependencies, in which case the priority order is ignored.
+	precedes := make([][]bool, n)
+	for b := range tasks {
+		precedes[b] = make([]bool, n)
+	}
+	for b := range tasks {
+		stack := append([]int{}, explicit[b]...)
+		for len(stack) > 0 {
+			a := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if precedes[a][b] {
+				continue
+			}
+			precedes[a][b] = true
+			stack = append(stack, explicit[a]...)
+		}
+	}
+
+	for j := range tasks {
+		for i := 0; i < j; i++ {
+			if precedes[j][i] || precedes[i][j] || !overlaps(scheds[i].Resources, scheds[j].Resources) {
+				continue
+			}
+			g.prereqs[j] = append(g.prereqs[j], i)
+		}
+	}
+
+	if _, err := g.order(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// order returns a topological order of the graph that prefers the priority
+// order whenever the dependencies allow it.
+func (g *graph) order() ([]int, error) {
+	n := len(g.tasks)
+	pending := make([]int, n)
+	dependents := g.dependents()
+	for j, p := range g.prereqs {
+		pending[j] = len(p)
+	}
+
+	order := make([]int, 0, n)
+	done := make([]bool, n)
+	for len(order) < n {
+		next := -1
+		for i := 0; i < n; i++ {
+			if !done[i] && pending[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return nil, g.cycleError(done)
+		}
+		done[next] = true
+		order = append(order, next)
+		for _, d := range dependents[next] {
+			pending[d]--
+		}
+	}
+	return order, nil
+}
+
+// dependents inverts prereqs.
+func (g *graph) dependents() [][]int {
+	deps := make([][]int, len(g.tasks))
+	for j, p := range g.prereqs {
+		for _, i := range p {
+			deps[i] = append(deps[i], j)
+		}
+	}
+	return deps
+}
+
+func (g *graph) cycleError(done []bool) error {
+	var names []string
+	for i, t := range g.tasks {
+		if !done[i] {
+			names = append(names, t.Description())
+		}
+	}
+	return errors.New("task dependency cycle among: " + strings.Join(names, ", "))
+}
+
+// overlaps reports whether two resource lists share a resource. An empty
+// list stands for every resource.
+func overlaps(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for _, x := range a {
+		for _, y := range b {
+			if resourceOverlap(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resourceOverlap reports whether x and y are equal or one contains the other.
+func resourceOverlap(x, y string) bool {
+	x, y = path.Clean(filepath.ToSlash(x)), path.Clean(filepath.ToSlash(y))
+	if x == y || x == "." || y == "." {
+		return true
+	}
+	return strings.HasPrefix(y, strings.TrimSuffix(x, "/")+"/") ||
+		strings.HasPrefix(x, strings.TrimSuffix(y, "/")+"/")
+}
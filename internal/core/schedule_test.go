@@ -0,0 +1,195 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"scbake/internal/types"
+	"scbake/pkg/templates"
+	"scbake/pkg/templates/community"
+	"scbake/pkg/templates/editorconfig"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// scheduledMockTask is a MockTask with a schedule and an optional body.
+type scheduledMockTask struct {
+	MockTask
+	sched types.Schedule
+	run   func(tc types.TaskContext) error
+}
+
+func (m *scheduledMockTask) Scheduling() types.Schedule { return m.sched }
+
+func (m *scheduledMockTask) Execute(tc types.TaskContext) error {
+	if m.run != nil {
+		return m.run(tc)
+	}
+	return m.MockTask.Execute(tc)
+}
+
+func scheduled(name string, prio int, sched types.Schedule) *scheduledMockTask {
+	return &scheduledMockTask{MockTask: MockTask{Name: name, Prio: prio}, sched: sched}
+}
+
+func TestBuildGraph(t *testing.T) {
+	tasks := []types.Task{
+		scheduled("svelte deps", 100, types.Schedule{ID: "npm", Resources: []string{"/repo/web"}}),
+		scheduled("go deps", 100, types.Schedule{Resources: []string{"/repo/api"}}),
+		scheduled("web lint", 1200, types.Schedule{Resources: []string{"/repo/web/eslint.config.js"}}),
+		&MockTask{Name: "git", Prio: 2000},
+	}
+	g, err := buildGraph(tasks)
+	if err != nil {
+		t.Fatalf("buildGraph failed: %v", err)
+	}
+	want := [][]int{nil, nil, {0}, {0, 1, 2}}
+	if !reflect.DeepEqual(g.prereqs, want) {
+		t.Errorf("prereqs = %v, want %v", g.prereqs, want)
+	}
+
+	// An explicit dependency reverses the priority order.
+	tasks = []types.Task{
+		scheduled("early", 100, types.Schedule{After: []string{"late"}}),
+		scheduled("late", 200, types.Schedule{ID: "late"}),
+	}
+	if g, err = buildGraph(tasks); err != nil {
+		t.Fatalf("buildGraph failed: %v", err)
+	}
+	if order, _ := g.order(); !reflect.DeepEqual(order, []int{1, 0}) {
+		t.Errorf("order = %v, want [1 0]", order)
+	}
+}
+
+func TestBuildGraph_Invalid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		tasks []types.Task
+		want  string
+	}{
+		"unknown": {[]types.Task{scheduled("a", 1, types.Schedule{After: []string{"nope"}})}, `unknown task ID "nope"`},
+		"duplicate": {[]types.Task{
+			scheduled("a", 1, types.Schedule{ID: "x"}),
+			scheduled("b", 2, types.Schedule{ID: "x"}),
+		}, `duplicate task ID "x"`},
+		"cycle": {[]types.Task{
+			scheduled("a", 1, types.Schedule{ID: "a", After: []string{"b"}}),
+			scheduled("b", 2, types.Schedule{ID: "b", After: []string{"a"}}),
+		}, "cycle among: a, b"},
+	} {
+		if _, err := buildGraph(tc.tasks); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: error = %v, want it to contain %q", name, err, tc.want)
+		}
+	}
+}
+
+func TestExecuteJobs_RunsIndependentTasksConcurrently(t *testing.T) {
+	// Both tasks wait until the other has started, which only succeeds in parallel.
+	var started sync.WaitGroup
+	started.Add(2)
+	meet := func(types.TaskContext) error {
+		started.Done()
+		done := make(chan struct{})
+		go func() { started.Wait(); close(done) }()
+		select {
+		case <-done:
+			return nil
+		case <-time.After(5 * time.Second):
+			return errors.New("tasks did not run concurrently")
+		}
+	}
+
+	last := scheduled("last", 2000, types.Schedule{})
+	web := scheduled("web", 100, types.Schedule{Resources: []string{"web"}})
+	web.run = meet
+	api := scheduled("api", 100, types.Schedule{Resources: []string{"api"}})
+	api.run = meet
+
+	plan := &types.Plan{Tasks: []types.Task{last, web, api}}
+	reporter := &recordingReporter{}
+	if err := ExecuteJobs(plan, types.TaskContext{Manifest: &types.Manifest{}}, reporter, 2); err != nil {
+		t.Fatalf("ExecuteJobs failed: %v", err)
+	}
+	if len(reporter.started) != 3 || reporter.started[2] != "last" {
+		t.Errorf("exclusive task should run after both others, got %v", reporter.started)
+	}
+}
+
+func TestExecuteJobs_FailureStopsDependents(t *testing.T) {
+	failing := scheduled("failing", 100, types.Schedule{Resources: []string{"web"}})
+	failing.ShouldFail = true
+	dependent := scheduled("dependent", 200, types.Schedule{Resources: []string{"web"}})
+	ran := false
+	dependent.run = func(types.TaskContext) error {
+		ran = true
+		return nil
+	}
+
+	plan := &types.Plan{Tasks: []types.Task{failing, dependent}}
+	err := ExecuteJobs(plan, types.TaskContext{Manifest: &types.Manifest{}}, &recordingReporter{}, 4)
+	if err == nil || !strings.Contains(err.Error(), "failing") {
+		t.Errorf("expected the failure to be reported, got %v", err)
+	}
+	if ran {
+		t.Error("task sharing a resource with the failed task must not run")
+	}
+}
+
+// concurrencyReporter tracks how many tasks are running at once.
+type concurrencyReporter struct {
+	recordingReporter
+	active, peak int
+}
+
+func (r *concurrencyReporter) TaskStart(desc string, current, total int) {
+	r.recordingReporter.TaskStart(desc, current, total)
+	r.active++
+	r.peak = max(r.peak, r.active)
+}
+
+func (r *concurrencyReporter) TaskEnd(_ int, _ error) { r.active-- }
+
+// TestExecuteJobs_BuiltinTemplatesOverlap checks that the built-in template
+// tasks declare disjoint resources, so one apply with --jobs runs them
+// concurrently.
+func TestExecuteJobs_BuiltinTemplatesOverlap(t *testing.T) {
+	dir := t.TempDir()
+	var plan types.Plan
+	for _, h := range []templates.Handler{&editorconfig.Handler{}, &community.Handler{}} {
+		tasks, err := h.GetTasks(dir, "", "")
+		if err != nil {
+			t.Fatalf("GetTasks failed: %v", err)
+		}
+		plan.Tasks = append(plan.Tasks, tasks...)
+	}
+
+	g, err := buildGraph(plan.Tasks)
+	if err != nil {
+		t.Fatalf("buildGraph failed: %v", err)
+	}
+	for i, p := range g.prereqs {
+		if len(p) > 0 {
+			t.Errorf("%q waits on %v, want no prerequisites", g.tasks[i].Description(), p)
+		}
+	}
+
+	reporter := &concurrencyReporter{}
+	tc := types.TaskContext{TargetPath: dir, Manifest: &types.Manifest{}}
+	if err := ExecuteJobs(&plan, tc, reporter, 4); err != nil {
+		t.Fatalf("ExecuteJobs failed: %v", err)
+	}
+	if reporter.peak < 2 {
+		t.Errorf("expected overlapping tasks, at most %d ran at once", reporter.peak)
+	}
+	if len(reporter.started) != len(plan.Tasks) {
+		t.Errorf("expected %d started tasks, got %v", len(plan.Tasks), reporter.started)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "GOVERNANCE.md")); err != nil {
+		t.Errorf("GOVERNANCE.md was not created: %v", err)
+	}
+}
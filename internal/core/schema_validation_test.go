@@ -5,6 +5,7 @@ package core
 
 import (
 	"embed"
+	"io/fs"
 	"scbake/internal/types"
 	"scbake/pkg/templates"
 	"testing"
@@ -20,7 +21,7 @@ func (s *schemaHandler) GetTasks(_ string, _ string, _ string) ([]types.Task, er
 	return nil, nil
 }
 
-func (s *schemaHandler) SchemaFS() embed.FS { return schemaHandlerFS }
+func (s *schemaHandler) SchemaFS() fs.FS { return schemaHandlerFS }
 
 func (s *schemaHandler) SchemaPath() string { return "testdata/has_schema.json" }
 
@@ -46,7 +47,7 @@ func TestValidateSelectedTemplates_WithSchema(t *testing.T) {
 		WithFlag: []string{"has-schema"},
 	}
 
-	err := validateSelectedTemplates(rc, map[string]string{})
+	err := validateSelectedTemplates(rc, map[string]string{}, nil)
 	if err == nil {
 		t.Fatal("expected error for missing required variable 'service_id'")
 	}
@@ -60,7 +61,7 @@ func TestValidateSelectedTemplates_WithSchemaAndSet(t *testing.T) {
 		WithFlag: []string{"has-schema"},
 	}
 
-	err := validateSelectedTemplates(rc, map[string]string{"service_id": "srv-123"})
+	err := validateSelectedTemplates(rc, map[string]string{"service_id": "srv-123"}, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -72,7 +73,7 @@ func TestValidateSelectedTemplates_NoSchema(t *testing.T) {
 	rc := RunContext{
 		WithFlag: []string{"no-schema"},
 	}
-	err := validateSelectedTemplates(rc, map[string]string{})
+	err := validateSelectedTemplates(rc, map[string]string{}, nil)
 	if err != nil {
 		t.Fatalf("unexpected error for handler without schema: %v", err)
 	}
@@ -82,7 +83,7 @@ func TestValidateSelectedTemplates_TemplateNotFound(t *testing.T) {
 	rc := RunContext{
 		WithFlag: []string{"nonexistent-template"},
 	}
-	err := validateSelectedTemplates(rc, map[string]string{})
+	err := validateSelectedTemplates(rc, map[string]string{}, nil)
 	if err == nil {
 		t.Fatal("expected error for unknown template")
 	}
@@ -95,13 +96,13 @@ func TestValidateSelectedTemplates_LangWithSchema(t *testing.T) {
 		LangFlag: "go",
 	}
 	// go_version is optional with pattern, so no value should pass
-	err := validateSelectedTemplates(rc, map[string]string{})
+	err := validateSelectedTemplates(rc, map[string]string{}, map[string]string{})
 	if err != nil {
 		t.Fatalf("optional var with pattern should pass when unset: %v", err)
 	}
 
 	// Setting a valid go_version should also pass
-	err = validateSelectedTemplates(rc, map[string]string{"go_version": "1.23"})
+	err = validateSelectedTemplates(rc, map[string]string{"go_version": "1.23"}, map[string]string{"go_version": "1.23"})
 	if err != nil {
 		t.Fatalf("expected valid go_version to pass: %v", err)
 	}
@@ -111,7 +112,7 @@ func TestValidateSelectedTemplates_LangWithSchemaInvalidPattern(t *testing.T) {
 	rc := RunContext{
 		LangFlag: "go",
 	}
-	err := validateSelectedTemplates(rc, map[string]string{"go_version": "v1.22"})
+	err := validateSelectedTemplates(rc, map[string]string{"go_version": "v1.22"}, map[string]string{"go_version": "v1.22"})
 	if err == nil {
 		t.Fatal("expected error for go_version not matching pattern ^1\\.\\d+$")
 	}
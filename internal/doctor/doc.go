@@ -0,0 +1,5 @@
+// Package doctor diagnoses the environment scbake runs in: language
+// prerequisites, the project manifest, leftover transactions, template
+// registries, the registry cache and the template override directory. Every
+// finding carries a suggested fix.
+package doctor
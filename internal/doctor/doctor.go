@@ -0,0 +1,115 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package doctor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+// Check outcomes. Errors make the report fail; warnings do not.
+const (
+	StatusOK    Status = "ok"
+	StatusWarn  Status = "warn"
+	StatusError Status = "error"
+)
+
+// Check categories, in the order Run reports them.
+const (
+	CategoryManifest      = "manifest"
+	CategoryPrerequisites = "prerequisites"
+	CategoryTransactions  = "transactions"
+	CategoryRegistries    = "registries"
+	CategoryCache         = "cache"
+	CategoryOverrides     = "overrides"
+)
+
+// Check is a single finding.
+type Check struct {
+	Category string `json:"category"`
+	Name     string `json:"name"`          // What was checked, e.g. a language or a path.
+	Status   Status `json:"status"`        // ok, warn or error.
+	Message  string `json:"message"`       // What was found.
+	Fix      string `json:"fix,omitempty"` // How to fix it; empty for ok.
+}
+
+// Report is the result of Run.
+type Report struct {
+	Version string  `json:"version"`
+	OK      bool    `json:"ok"` // No check has StatusError.
+	Checks  []Check `json:"checks"`
+}
+
+// Counts returns the number of warnings and errors.
+func (r *Report) Counts() (warnings, errors int) {
+	for _, c := range r.Checks {
+		switch c.Status {
+		case StatusWarn:
+			warnings++
+		case StatusError:
+			errors++
+		case StatusOK:
+		}
+	}
+	return warnings, errors
+}
+
+func (r *Report) add(category, name string, status Status, message, fix string) {
+	r.Checks = append(r.Checks, Check{Category: category, Name: name, Status: status, Message: message, Fix: fix})
+}
+
+// Options select what Run inspects.
+type Options struct {
+	StartPath          string // The project root is discovered from here, as by apply.
+	TemplateDir        string // --template-dir or $SCBAKE_TEMPLATE_DIR; "" if unset.
+	RegistryConfigPath string // registries.json.
+	RegistryCacheDir   string // Cache of pulled templates.
+	Version            string // Version of the running scbake, e.g. v0.4.1.
+	Offline            bool   // Skip the registry reachability check.
+}
+
+// Run performs all checks. The language prerequisites cover every
+// registered language, so declarative packs and plugins must be registered
+// before. Run never modifies the project or the cache.
+func Run(ctx context.Context, opts Options) *Report {
+	r := &Report{Version: opts.Version}
+
+	m, root := checkManifest(r, opts)
+	checkPrerequisites(ctx, r, m, root)
+	checkTransactions(r, root)
+	registries := checkRegistries(ctx, r, opts)
+	checkCache(r, opts.RegistryCacheDir, registries)
+	checkOverrides(r, opts.TemplateDir)
+
+	_, errs := r.Counts()
+	r.OK = errs == 0
+	return r
+}
+
+// writable reports whether files can be created in dir or, if it does not
+// exist yet, in its nearest existing parent, where it would be created. The
+// probe file is removed again.
+func writable(dir string) error {
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	f, err := os.CreateTemp(dir, ".scbake-doctor-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	_ = f.Close()
+	return os.Remove(name)
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package doctor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"scbake/internal/util/fileutil"
+	"strings"
+	"testing"
+)
+
+// writeFile creates path with content, including its parent directories.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), fileutil.DirPerms); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), fileutil.PrivateFilePerms); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// findCheck returns the first check of category whose name and message
+// contain the given substrings.
+func findCheck(r *Report, category, name, message string) *Check {
+	for i, c := range r.Checks {
+		if c.Category == category && strings.Contains(c.Name, name) && strings.Contains(c.Message, message) {
+			return &r.Checks[i]
+		}
+	}
+	return nil
+}
+
+// TestRun_Healthy checks that an empty environment passes.
+func TestRun_Healthy(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PATH", dir) // No tools: unused languages only warn.
+
+	report := Run(context.Background(), Options{
+		StartPath:          dir,
+		RegistryConfigPath: filepath.Join(dir, "config", "registries.json"),
+		RegistryCacheDir:   filepath.Join(dir, "cache"),
+		Version:            "v0.4.1",
+		Offline:            true,
+	})
+
+	if !report.OK {
+		t.Fatalf("expected the report to pass, got %+v", report.Checks)
+	}
+	for _, c := range report.Checks {
+		if c.Status != StatusOK && c.Category != CategoryPrerequisites {
+			t.Errorf("unexpected %s: %+v", c.Status, c)
+		}
+		if c.Status != StatusOK && c.Fix == "" {
+			t.Errorf("%s without a fix: %+v", c.Status, c)
+		}
+	}
+}
+
+// TestRun_Problems checks that every kind of problem is reported with the
+// right status.
+func TestRun_Problems(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PATH", dir)
+
+	root := filepath.Join(dir, "project")
+	writeFile(t, filepath.Join(root, fileutil.ManifestFileName), `scbake_version = "v9.0.0"
+
+[[projects]]
+  name = "api"
+  path = "api"
+  language = "go"
+  templates = ["go_linter", "no_such_template"]
+
+[[projects]]
+  name = "legacy"
+  path = "legacy"
+  language = "cobol"
+  templates = []
+`)
+	writeFile(t, filepath.Join(root, "api", "go.mod"), "module api\n\ngo 1.22\n")
+	writeFile(t, filepath.Join(root, fileutil.InternalDir, fileutil.TmpDir, "tx-1", "backup"), "")
+
+	configPath := filepath.Join(dir, "registries.json")
+	writeFile(t, configPath, `{"registries": [{"name": "acme", "url": "ftp://acme.com"}]}`)
+
+	cacheDir := filepath.Join(dir, "cache")
+	writeFile(t, filepath.Join(cacheDir, "gone", "ci", "main.yml.tpl"), "")
+
+	templateDir := filepath.Join(dir, "overrides")
+	writeFile(t, filepath.Join(templateDir, "main.yml.tpl"), "")             // ci_github
+	writeFile(t, filepath.Join(templateDir, "partials", "header.tpl"), "")   // partial
+	writeFile(t, filepath.Join(templateDir, "SECURITY.md.tpl"), "")          // belongs in templates/
+	writeFile(t, filepath.Join(templateDir, "pkg", "template.toml"), "x = ") // package, not an override
+
+	report := Run(context.Background(), Options{
+		StartPath:          filepath.Join(root, "api"),
+		TemplateDir:        templateDir,
+		RegistryConfigPath: configPath,
+		RegistryCacheDir:   cacheDir,
+		Version:            "v0.4.1",
+		Offline:            true,
+	})
+
+	if report.OK {
+		t.Error("expected the report to fail")
+	}
+	for _, tc := range []struct {
+		category, name, message string
+		status                  Status
+	}{
+		{CategoryManifest, fileutil.ManifestFileName, "newer than this scbake v0.4.1", StatusWarn},
+		{CategoryManifest, "project api", `unknown template "no_such_template"`, StatusWarn},
+		{CategoryManifest, "project legacy", `unknown language "cobol"`, StatusError},
+		{CategoryManifest, "project legacy", "does not exist", StatusWarn},
+		{CategoryPrerequisites, "go at api", "go >=1.22", StatusError},
+		{CategoryPrerequisites, "spring", "not found", StatusWarn},
+		{CategoryTransactions, "tx-1", "leftover transaction", StatusWarn},
+		{CategoryRegistries, "registry acme", "invalid URL", StatusError},
+		{CategoryCache, "gone", "not configured", StatusWarn},
+		{CategoryOverrides, "SECURITY.md.tpl", "matches no known template file", StatusWarn},
+	} {
+		c := findCheck(report, tc.category, tc.name, tc.message)
+		if c == nil {
+			t.Errorf("missing %s check %q: %q", tc.category, tc.name, tc.message)
+			continue
+		}
+		if c.Status != tc.status {
+			t.Errorf("expected %s for %+v", tc.status, c)
+		}
+		if c.Fix == "" {
+			t.Errorf("expected a fix for %+v", c)
+		}
+	}
+
+	if c := findCheck(report, CategoryOverrides, "SECURITY.md.tpl", ""); c != nil && !strings.Contains(c.Fix, "templates/SECURITY.md.tpl") {
+		t.Errorf("expected a rename suggestion, got %q", c.Fix)
+	}
+	for _, name := range []string{"main.yml.tpl", "header.tpl", "template.toml"} {
+		if c := findCheck(report, CategoryOverrides, name, ""); c != nil {
+			t.Errorf("%s should not be reported: %+v", name, c)
+		}
+	}
+}
+
+// TestRun_BrokenConfig checks that unparseable files are errors.
+func TestRun_BrokenConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PATH", dir)
+	writeFile(t, filepath.Join(dir, fileutil.ManifestFileName), "projects = [")
+	configPath := filepath.Join(dir, "registries.json")
+	writeFile(t, configPath, "{")
+
+	report := Run(context.Background(), Options{
+		StartPath:          dir,
+		TemplateDir:        filepath.Join(dir, "missing"),
+		RegistryConfigPath: configPath,
+		Offline:            true,
+	})
+
+	for _, tc := range []struct{ category, message string }{
+		{CategoryManifest, "failed to decode manifest"},
+		{CategoryRegistries, "parsing registry config"},
+		{CategoryOverrides, ""},
+	} {
+		c := findCheck(report, tc.category, "", tc.message)
+		if c == nil || c.Status != StatusError {
+			t.Errorf("expected a %s error %q, got %+v", tc.category, tc.message, c)
+		}
+	}
+}
+
+func TestCheckManifestVersion(t *testing.T) {
+	for _, tc := range []struct {
+		manifest, binary string
+		want             Status
+	}{
+		{"v0.4.1", "v0.4.1", StatusOK},
+		{"v0.3.0", "v0.4.1", StatusOK},
+		{"", "v0.4.1", StatusOK},
+		{"v0.5.0", "v0.4.1", StatusWarn},
+		{"v0.5.0", "dev", StatusOK},
+		{"latest", "v0.4.1", StatusWarn},
+	} {
+		r := &Report{}
+		checkManifestVersion(r, fileutil.ManifestFileName, tc.manifest, tc.binary)
+		got := StatusOK
+		if len(r.Checks) > 0 {
+			got = r.Checks[0].Status
+		}
+		if got != tc.want {
+			t.Errorf("checkManifestVersion(%q, %q) = %s, want %s", tc.manifest, tc.binary, got, tc.want)
+		}
+	}
+}
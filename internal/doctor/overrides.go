@@ -0,0 +1,172 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package doctor
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"scbake/pkg/declarative"
+	"scbake/pkg/lang"
+	"scbake/pkg/tasks"
+	"scbake/pkg/templates"
+	"sort"
+	"strings"
+)
+
+// checkOverrides checks that every file in the template override directory
+// replaces or extends a known template file. Files that match nothing are
+// silently ignored by apply, which usually means a typo or a path from an
+// older scbake.
+func checkOverrides(r *Report, templateDir string) {
+	if templateDir == "" {
+		r.add(CategoryOverrides, "--template-dir", StatusOK, "not set; embedded templates are used", "")
+		return
+	}
+	info, err := os.Stat(templateDir)
+	if err != nil {
+		r.add(CategoryOverrides, templateDir, StatusError, err.Error(),
+			"create the directory, or point --template-dir and $SCBAKE_TEMPLATE_DIR at an existing one")
+		return
+	}
+	if !info.IsDir() {
+		r.add(CategoryOverrides, templateDir, StatusError, "not a directory",
+			"point --template-dir and $SCBAKE_TEMPLATE_DIR at a directory")
+		return
+	}
+
+	known, dirs := knownPaths()
+	packages := packageDirs(templateDir)
+
+	var unknown []string
+	err = filepath.WalkDir(templateDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if packages[p] || d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(templateDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !overridesKnown(rel, known, dirs) {
+			unknown = append(unknown, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		r.add(CategoryOverrides, templateDir, StatusError, err.Error(), "fix the directory permissions")
+		return
+	}
+
+	for _, rel := range unknown {
+		fix := "remove the file, or move it to the path of the template file it should replace (see the handler sources)"
+		if suggestions := suggest(rel, known); len(suggestions) > 0 {
+			fix = "rename it to " + strings.Join(suggestions, " or ")
+		}
+		r.add(CategoryOverrides, filepath.Join(templateDir, filepath.FromSlash(rel)), StatusWarn,
+			"matches no known template file and is ignored", fix)
+	}
+	if len(unknown) == 0 {
+		r.add(CategoryOverrides, templateDir, StatusOK, "every file overrides a known template file", "")
+	}
+}
+
+// knownPaths returns the template files of all registered languages and
+// templates, relative to the override directory, and the directories they
+// are in. Files added below such a directory extend a tree.
+func knownPaths() (files, dirs map[string]bool) {
+	files = map[string]bool{}
+	dirs = map[string]bool{}
+
+	var handlers []interface{}
+	for _, name := range lang.ListLangs() {
+		if h, err := lang.GetHandler(name); err == nil {
+			handlers = append(handlers, h)
+		}
+	}
+	for _, name := range templates.ListTemplates() {
+		if h, err := templates.GetHandler(name); err == nil {
+			handlers = append(handlers, h)
+		}
+	}
+
+	for _, h := range handlers {
+		var fsys fs.FS
+		var schema string
+		switch p := h.(type) {
+		case templates.FilesProvider:
+			fsys = p.TemplateFS()
+		case templates.SchemaProvider:
+			fsys, schema = p.SchemaFS(), p.SchemaPath()
+		case lang.SchemaProvider:
+			fsys, schema = p.SchemaFS(), p.SchemaPath()
+		default:
+			continue
+		}
+		// Unreadable handler files cannot be overridden either, so walk
+		// errors only shorten the list.
+		_ = fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() && p != schema {
+				files[p] = true
+				for dir := path.Dir(p); dir != "."; dir = path.Dir(dir) {
+					dirs[dir] = true
+				}
+			}
+			return nil
+		})
+	}
+	return files, dirs
+}
+
+// overridesKnown reports whether the file at rel (slash-separated, relative
+// to the override directory) is used by apply: it replaces a known template
+// file, adds to a known directory or is a partial.
+func overridesKnown(rel string, known, dirs map[string]bool) bool {
+	if known[rel] || tasks.IsPartial(rel) {
+		return true
+	}
+	for dir := path.Dir(rel); dir != "."; dir = path.Dir(dir) {
+		if dirs[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+// suggest returns the known template files rel was probably meant to be:
+// those with the same base name, with or without the .tpl suffix.
+func suggest(rel string, known map[string]bool) []string {
+	base := strings.TrimSuffix(path.Base(rel), ".tpl")
+	var matches []string
+	for k := range known {
+		if strings.TrimSuffix(path.Base(k), ".tpl") == base {
+			matches = append(matches, k)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// packageDirs returns the declarative packages in templateDir, which are
+// registered as handlers rather than used as overrides.
+func packageDirs(templateDir string) map[string]bool {
+	dirs := map[string]bool{}
+	for _, file := range []string{declarative.TemplateManifestFile, declarative.LanguageManifestFile} {
+		found, err := declarative.FindPackages(templateDir, file)
+		if err != nil {
+			continue
+		}
+		for _, d := range found {
+			dirs[d] = true
+		}
+	}
+	return dirs
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"scbake/internal/manifest"
+	"scbake/internal/preflight"
+	"scbake/internal/types"
+	"scbake/internal/util/fileutil"
+	"scbake/pkg/lang"
+	"scbake/pkg/templates"
+	"strings"
+)
+
+// checkManifest discovers the project root from opts.StartPath and checks
+// that its scbake.toml parses, was not written by a newer scbake and only
+// refers to known languages, templates and existing paths. It returns the
+// manifest, nil if it could not be loaded, and the project root.
+func checkManifest(r *Report, opts Options) (*types.Manifest, string) {
+	root, err := manifest.FindProjectRoot(opts.StartPath)
+	if err != nil {
+		r.add(CategoryManifest, fileutil.ManifestFileName, StatusError, err.Error(),
+			"run scbake doctor from inside the project")
+		return nil, ""
+	}
+
+	path := filepath.Join(root, fileutil.ManifestFileName)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		r.add(CategoryManifest, fileutil.ManifestFileName, StatusOK,
+			fmt.Sprintf("no %s in %s; scbake new or apply will create it", fileutil.ManifestFileName, root), "")
+		return nil, root
+	}
+
+	m, _, err := manifest.Load(root)
+	if err != nil {
+		r.add(CategoryManifest, path, StatusError, err.Error(),
+			"fix the TOML syntax, or restore the file from version control")
+		return nil, root
+	}
+	r.add(CategoryManifest, path, StatusOK,
+		fmt.Sprintf("parsed: %d project(s), %d root template(s)", len(m.Projects), len(m.Templates)), "")
+
+	checkManifestVersion(r, path, m.SbakeVersion, opts.Version)
+
+	for _, p := range m.Projects {
+		name := fmt.Sprintf("project %s", p.Name)
+		if _, err := lang.GetHandler(p.Language); err != nil {
+			r.add(CategoryManifest, name, StatusError,
+				fmt.Sprintf("unknown language %q", p.Language),
+				"install the language pack or plugin providing it, or remove the project from "+fileutil.ManifestFileName)
+		}
+		if _, err := os.Stat(filepath.Join(root, p.Path)); err != nil {
+			r.add(CategoryManifest, name, StatusWarn,
+				fmt.Sprintf("path %q does not exist", p.Path),
+				"restore the directory, or update or remove the project in "+fileutil.ManifestFileName)
+		}
+		for _, t := range p.Templates {
+			checkTemplateKnown(r, name, t)
+		}
+	}
+	for _, t := range m.Templates {
+		checkTemplateKnown(r, "root templates", t.Name)
+	}
+	return m, root
+}
+
+// checkManifestVersion warns if the manifest was written by a newer scbake,
+// which may record settings this binary does not understand. An empty
+// scbake_version is not checked.
+func checkManifestVersion(r *Report, path, manifestVersion, binaryVersion string) {
+	if manifestVersion == "" {
+		return
+	}
+	written, err := preflight.ParseVersion(manifestVersion)
+	if err != nil {
+		r.add(CategoryManifest, path, StatusWarn,
+			fmt.Sprintf("scbake_version %q is not a version", manifestVersion),
+			fmt.Sprintf("set scbake_version to the version that wrote the file, e.g. %q", binaryVersion))
+		return
+	}
+	running, err := preflight.ParseVersion(binaryVersion)
+	if err != nil {
+		// Development builds have no comparable version.
+		return
+	}
+	newer, err := preflight.Satisfies(written, ">"+formatVersion(running))
+	if err != nil || !newer {
+		return
+	}
+	r.add(CategoryManifest, path, StatusWarn,
+		fmt.Sprintf("written by scbake %s, newer than this scbake %s", manifestVersion, binaryVersion),
+		"upgrade scbake to "+manifestVersion+" or later")
+}
+
+func checkTemplateKnown(r *Report, name, tmpl string) {
+	if _, err := templates.GetHandler(tmpl); err != nil {
+		r.add(CategoryManifest, name, StatusWarn,
+			fmt.Sprintf("unknown template %q", tmpl),
+			"install the template package or plugin providing it; apply --with fails until then")
+	}
+}
+
+// checkPrerequisites checks the prerequisites of every registered language.
+// Projects are checked at their paths with their metadata, and
+// unmet requirements are errors because apply will fail. Languages no project
+// uses are checked as for a new project; unmet requirements are only
+// warnings because just scbake new needs them.
+func checkPrerequisites(ctx context.Context, r *Report, m *types.Manifest, root string) {
+	var metadata map[string]string
+	used := map[string][]types.Project{}
+	if m != nil {
+		metadata = m.Metadata
+		for _, p := range m.Projects {
+			used[p.Language] = append(used[p.Language], p)
+		}
+	}
+
+	// Unused languages are asked about a directory that does not exist, so
+	// they report what creating a project needs.
+	scratch, err := os.MkdirTemp("", "scbake-doctor-")
+	if err != nil {
+		r.add(CategoryPrerequisites, "languages", StatusError, err.Error(), "check that the temporary directory is writable")
+		return
+	}
+	defer func() { _ = os.RemoveAll(scratch) }()
+
+	for _, name := range lang.ListLangs() {
+		h, err := lang.GetHandler(name)
+		if err != nil {
+			continue
+		}
+		projects := used[name]
+		if len(projects) == 0 {
+			checkLang(ctx, r, h, name, filepath.Join(scratch, "new"), metadata, StatusWarn)
+			continue
+		}
+		for _, p := range projects {
+			label := name
+			if p.Path != "." && p.Path != "" {
+				label = fmt.Sprintf("%s at %s", name, p.Path)
+			}
+			checkLang(ctx, r, h, label, filepath.Join(root, p.Path), m.ProjectMetadata(&p), StatusError)
+		}
+	}
+}
+
+// checkLang reports the prerequisites of one language handler for
+// targetPath, with status for unmet requirements.
+func checkLang(ctx context.Context, r *Report, h lang.Handler, label, targetPath string, metadata map[string]string, status Status) {
+	reqs, err := lang.Requirements(h, targetPath, metadata)
+	if err != nil {
+		r.add(CategoryPrerequisites, label, status, fmt.Sprintf("cannot determine prerequisites: %v", err),
+			"fix the metadata in "+fileutil.ManifestFileName+" or the language pack")
+		return
+	}
+	if len(reqs) == 0 {
+		r.add(CategoryPrerequisites, label, StatusOK, "no prerequisites", "")
+		return
+	}
+
+	var found, problems, missing []string
+	for _, res := range preflight.Check(ctx, reqs...).Results {
+		if res.OK() {
+			desc := res.Binary
+			if res.Version != "" {
+				desc += " " + res.Version
+			}
+			found = append(found, desc)
+			continue
+		}
+		problem := res.String() + ": " + res.Problem
+		if res.Reason != "" {
+			problem = res.String() + " (" + res.Reason + "): " + res.Problem
+		}
+		problems = append(problems, problem)
+		missing = append(missing, res.String())
+	}
+
+	if len(problems) == 0 {
+		r.add(CategoryPrerequisites, label, StatusOK, "found "+strings.Join(found, ", "), "")
+		return
+	}
+	message := strings.Join(problems, "; ")
+	if status == StatusWarn {
+		message += " (only needed to create new projects)"
+	}
+	r.add(CategoryPrerequisites, label, status, message,
+		"install or upgrade "+strings.Join(missing, ", ")+" and make sure it is on $PATH")
+}
+
+// checkTransactions looks for transaction directories left in
+// .scbake/tmp by a run that was killed before it committed or rolled back,
+// and checks that the project root is writable.
+func checkTransactions(r *Report, root string) {
+	if root == "" {
+		return
+	}
+
+	if err := writable(root); err != nil {
+		r.add(CategoryTransactions, root, StatusError, fmt.Sprintf("project root is not writable: %v", err),
+			"fix the directory permissions; apply writes files and backups here")
+	}
+
+	tmp := filepath.Join(root, fileutil.InternalDir, fileutil.TmpDir)
+	leftovers, err := filepath.Glob(filepath.Join(tmp, "tx-*"))
+	if err != nil {
+		r.add(CategoryTransactions, tmp, StatusError, err.Error(), "")
+		return
+	}
+	if len(leftovers) == 0 {
+		r.add(CategoryTransactions, tmp, StatusOK, "no leftover transactions", "")
+		return
+	}
+	for _, dir := range leftovers {
+		r.add(CategoryTransactions, dir, StatusWarn,
+			"leftover transaction from an interrupted run; it holds backups of the files the run changed",
+			"check git status, restore any damaged files from the backups in this directory, then remove it")
+	}
+}
+
+// formatVersion joins version components with dots.
+func formatVersion(v []int) string {
+	parts := make([]string, len(v))
+	for i, n := range v {
+		parts[i] = fmt.Sprint(n)
+	}
+	return strings.Join(parts, ".")
+}
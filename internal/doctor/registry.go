@@ -0,0 +1,112 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"scbake/internal/templateregistry"
+	"time"
+)
+
+// reachTimeout bounds the reachability probe of a single registry.
+const reachTimeout = 5 * time.Second
+
+// checkRegistries checks that the registry config parses, that every
+// registry is valid and, unless opts.Offline, that its host answers. It
+// returns the configured registries.
+func checkRegistries(ctx context.Context, r *Report, opts Options) []templateregistry.Registry {
+	path := opts.RegistryConfigPath
+	if path == "" {
+		return nil
+	}
+
+	cfg, err := templateregistry.LoadConfig(path)
+	if err != nil {
+		r.add(CategoryRegistries, path, StatusError, err.Error(),
+			"fix the JSON by hand, or remove the file and add the registries again with 'scbake template registry add'")
+		return nil
+	}
+	if len(cfg.Registries) == 0 {
+		r.add(CategoryRegistries, path, StatusOK, "no registries configured", "")
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for _, reg := range cfg.Registries {
+		name := "registry " + reg.Name
+		if seen[reg.Name] {
+			r.add(CategoryRegistries, name, StatusError, "configured more than once in "+path,
+				"remove the duplicate entry from "+path)
+			continue
+		}
+		seen[reg.Name] = true
+
+		if err := reg.Validate(); err != nil {
+			r.add(CategoryRegistries, name, StatusError, err.Error(),
+				fmt.Sprintf("run 'scbake template registry remove %s' and add it again with a valid URL", reg.Name))
+			continue
+		}
+		if opts.Offline {
+			r.add(CategoryRegistries, name, StatusOK, "valid (reachability not checked)", "")
+			continue
+		}
+		if err := probe(ctx, reg.URL); err != nil {
+			r.add(CategoryRegistries, name, StatusWarn, fmt.Sprintf("unreachable: %v", err),
+				"check the URL, your network and proxy settings; cached templates still work")
+			continue
+		}
+		r.add(CategoryRegistries, name, StatusOK, "valid and reachable", "")
+	}
+	return cfg.Registries
+}
+
+// probe sends a HEAD request to url. Any HTTP response counts as reachable:
+// registries need not serve their base URL, and credentials are only sent
+// when pulling.
+func probe(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, reachTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// checkCache checks that the template cache is writable and consistent with
+// the configured registries.
+func checkCache(r *Report, cacheDir string, registries []templateregistry.Registry) {
+	if cacheDir == "" {
+		return
+	}
+
+	if err := writable(cacheDir); err != nil {
+		r.add(CategoryCache, cacheDir, StatusError, fmt.Sprintf("not writable: %v", err),
+			"fix the directory permissions, or set $XDG_CACHE_HOME to a writable directory")
+	}
+
+	issues, err := templateregistry.VerifyCache(cacheDir, registries)
+	if err != nil {
+		r.add(CategoryCache, cacheDir, StatusError, err.Error(), "fix the directory permissions")
+		return
+	}
+	for _, issue := range issues {
+		r.add(CategoryCache, issue.Path, StatusWarn, issue.Problem, issue.Fix)
+	}
+	if len(issues) == 0 {
+		message := "consistent"
+		if _, err := os.Stat(cacheDir); os.IsNotExist(err) {
+			message = "empty; created on the first pull"
+		}
+		r.add(CategoryCache, cacheDir, StatusOK, message, "")
+	}
+}
@@ -42,13 +42,15 @@ func (m *Manager) Rollback() error {
 	}
 
 	// 3. Cleanup temp dir and structural scaffolding
+	// The scaffolding is pruned even without backups: tasks may have created
+	// .scbake for their own state (e.g. merge bases).
 	if m.tempDir != "" {
 		if err := os.RemoveAll(m.tempDir); err != nil {
 			errs = append(errs, fmt.Errorf("failed to remove temp dir: %w", err))
 		}
-		m.cleanupStructure()
-		m.resetState()
 	}
+	m.cleanupStructure()
+	m.resetState()
 
 	if len(errs) > 0 {
 		return fmt.Errorf("rollback completed with errors: %v", errs)
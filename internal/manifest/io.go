@@ -7,9 +7,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"scbake/internal/types"
 	"scbake/internal/util/fileutil"
+	"sort"
 	"sync"
 	"time"
 
@@ -105,6 +107,9 @@ func Load(startPath string) (*types.Manifest, string, error) {
 	if _, err := toml.Decode(string(data), &m); err != nil {
 		return nil, "", fmt.Errorf("failed to decode manifest: %w", err)
 	}
+	if err := validateConflictStrategies(m.ConflictStrategies); err != nil {
+		return nil, "", err
+	}
 
 	// Record the modification time for conflict detection during Save()
 	if info, statErr := os.Stat(manifestPath); statErr == nil {
@@ -117,6 +122,26 @@ func Load(startPath string) (*types.Manifest, string, error) {
 }
 
 // writeAndCloseManifestFile encodes manifest to file, syncs, and closes.
+// validateConflictStrategies checks the [conflict_strategies] section, so a
+// typo fails the run instead of silently falling back to "fail".
+func validateConflictStrategies(strategies map[string]string) error {
+	patterns := make([]string, 0, len(strategies))
+	for p := range strategies {
+		patterns = append(patterns, p)
+	}
+	sort.Strings(patterns)
+
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid [conflict_strategies] pattern %q: %w", p, err)
+		}
+		if err := types.ValidateConflictStrategy(strategies[p]); err != nil {
+			return fmt.Errorf("[conflict_strategies] %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
 func writeAndCloseManifestFile(f *os.File, m *types.Manifest) error {
 	encoder := toml.NewEncoder(f)
 	if err := encoder.Encode(m); err != nil {
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"scbake/internal/types"
 	"scbake/internal/util/fileutil"
+	"strings"
 	"testing"
 )
 
@@ -190,3 +191,28 @@ func TestLoadAndSave(t *testing.T) {
 		t.Error("Persistence failed")
 	}
 }
+
+// TestLoad_ConflictStrategies checks that invalid [conflict_strategies]
+// entries fail the load with the same error as --conflict-strategy.
+func TestLoad_ConflictStrategies(t *testing.T) {
+	for content, want := range map[string]string{
+		"[conflict_strategies]\n\"README.md\" = \"keep-local\"\n\"docs/*.md\" = \"merge\"\n": "",
+		"[conflict_strategies]\n\"README.md\" = \"keep_local\"\n":                            `invalid conflict strategy "keep_local": must be one of fail, overwrite, artifact, keep-local, merge, prompt`,
+		"[conflict_strategies]\n\"docs/[*.md\" = \"merge\"\n":                                `invalid [conflict_strategies] pattern "docs/[*.md"`,
+	} {
+		tmpDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(tmpDir, fileutil.ManifestFileName), []byte(content), fileutil.PrivateFilePerms); err != nil {
+			t.Fatal(err)
+		}
+		_, _, err := Load(tmpDir)
+		if want == "" {
+			if err != nil {
+				t.Errorf("Load(%q) failed: %v", content, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Load(%q) = %v, want an error containing %q", content, err, want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package merge
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each hunk.
+const diffContext = 3
+
+// diffOp is a single line of an edit script.
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// Diff renders a unified diff from old to new, labelled with the given names.
+// It returns an empty string if both contents are identical.
+func Diff(oldName, newName string, oldContent, newContent []byte) string {
+	a := splitLines(oldContent)
+	b := splitLines(newContent)
+	ops := editScript(a, b)
+
+	var out strings.Builder
+	for start := 0; start < len(ops); {
+		// Find the next change.
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+
+		// Extend the hunk while changes are closer than twice the context.
+		end := start
+		for i := start; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				end = i + 1
+				continue
+			}
+			if i-end >= 2*diffContext {
+				break
+			}
+		}
+
+		from := max(start-diffContext, 0)
+		to := min(end+diffContext, len(ops))
+
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+		}
+		writeHunk(&out, ops, from, to)
+		start = to
+	}
+
+	return out.String()
+}
+
+// writeHunk emits ops[from:to] with a unified diff header.
+func writeHunk(out *strings.Builder, ops []diffOp, from, to int) {
+	oldStart, newStart := 1, 1
+	for _, op := range ops[:from] {
+		if op.kind != '+' {
+			oldStart++
+		}
+		if op.kind != '-' {
+			newStart++
+		}
+	}
+
+	oldLen, newLen := 0, 0
+	for _, op := range ops[from:to] {
+		if op.kind != '+' {
+			oldLen++
+		}
+		if op.kind != '-' {
+			newLen++
+		}
+	}
+
+	fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", oldStart, oldLen, newStart, newLen)
+	for _, op := range ops[from:to] {
+		out.WriteByte(op.kind)
+		out.WriteString(op.line)
+		if !strings.HasSuffix(op.line, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// editScript converts the line matching of a and b into a sequence of
+// keep, delete and insert operations.
+func editScript(a, b []string) []diffOp {
+	match := matchLines(a, b)
+	ops := make([]diffOp, 0, len(a)+len(b))
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && match[i] < 0:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		case i < len(a) && match[i] == j:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+
+	return ops
+}
@@ -0,0 +1,4 @@
+// Package merge implements a line-based three-way merge used to reconcile
+// user edits with updated template renderings, and a unified diff used to
+// preview such changes.
+package merge
@@ -0,0 +1,195 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package merge
+
+import (
+	"bytes"
+	"strings"
+)
+
+const (
+	// MarkerLocal opens a conflicting hunk; the lines that follow are the user's version.
+	MarkerLocal = "<<<<<<< local"
+
+	// MarkerSeparator divides the user's version from the new rendering.
+	MarkerSeparator = "======="
+
+	// MarkerNew closes a conflicting hunk; the lines above it are the new rendering.
+	MarkerNew = ">>>>>>> scbake"
+)
+
+// Result holds the outcome of a three-way merge.
+type Result struct {
+	// Content is the merged file, including conflict markers for overlapping hunks.
+	Content []byte
+
+	// Conflicts is the number of hunks that could not be merged automatically.
+	Conflicts int
+}
+
+// HasConflicts reports whether the merged content contains conflict markers.
+func (r Result) HasConflicts() bool {
+	return r.Conflicts > 0
+}
+
+// ThreeWay merges the changes made from base to local with the changes made
+// from base to remote. Non-overlapping hunks from both sides are combined;
+// hunks changed differently on both sides are wrapped in git-style markers.
+func ThreeWay(base, local, remote []byte) Result {
+	o := splitLines(base)
+	x := splitLines(local)
+	y := splitLines(remote)
+
+	matchX := matchLines(o, x)
+	matchY := matchLines(o, y)
+
+	var out bytes.Buffer
+	conflicts := 0
+	i, a, b := 0, 0, 0
+
+	for {
+		// Find the next base line that survives unchanged on both sides.
+		j := i
+		for j < len(o) && (matchX[j] < 0 || matchY[j] < 0) {
+			j++
+		}
+
+		endX, endY := len(x), len(y)
+		if j < len(o) {
+			endX, endY = matchX[j], matchY[j]
+		}
+
+		if resolveChunk(&out, o[i:j], x[a:endX], y[b:endY]) {
+			conflicts++
+		}
+
+		if j == len(o) {
+			break
+		}
+
+		// Stable line: identical in all three versions.
+		out.WriteString(x[endX])
+		i, a, b = j+1, endX+1, endY+1
+	}
+
+	return Result{Content: out.Bytes(), Conflicts: conflicts}
+}
+
+// resolveChunk writes the merged form of an unstable region and reports
+// whether it had to be emitted as a conflict.
+func resolveChunk(out *bytes.Buffer, base, local, remote []string) bool {
+	switch {
+	case equalLines(local, remote):
+		writeLines(out, local)
+	case equalLines(base, local):
+		writeLines(out, remote)
+	case equalLines(base, remote):
+		writeLines(out, local)
+	default:
+		writeMarker(out, MarkerLocal)
+		writeLines(out, local)
+		writeMarker(out, MarkerSeparator)
+		writeLines(out, remote)
+		writeMarker(out, MarkerNew)
+		return true
+	}
+	return false
+}
+
+// writeMarker emits a conflict marker on its own line, terminating the
+// previous line first if it had no trailing newline.
+func writeMarker(out *bytes.Buffer, marker string) {
+	if out.Len() > 0 && !bytes.HasSuffix(out.Bytes(), []byte("\n")) {
+		out.WriteByte('\n')
+	}
+	out.WriteString(marker)
+	out.WriteByte('\n')
+}
+
+func writeLines(out *bytes.Buffer, lines []string) {
+	for _, l := range lines {
+		out.WriteString(l)
+	}
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// splitLines splits content into lines, keeping the line terminators so that
+// joining the result reproduces the input byte-for-byte.
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// matchLines computes a longest common subsequence between a and b and
+// returns, for every line of a, the index of its partner in b (or -1).
+func matchLines(a, b []string) []int {
+	match := make([]int, len(a))
+	for i := range match {
+		match[i] = -1
+	}
+
+	// Trim the common prefix and suffix; templates usually change in the middle.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		match[prefix] = prefix
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		match[len(a)-1-suffix] = len(b) - 1 - suffix
+		suffix++
+	}
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+	if len(midA) == 0 || len(midB) == 0 {
+		return match
+	}
+
+	// Classic dynamic-programming LCS over the remaining window.
+	cols := len(midB) + 1
+	table := make([]int, (len(midA)+1)*cols)
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				table[i*cols+j] = table[(i+1)*cols+j+1] + 1
+			} else {
+				table[i*cols+j] = max(table[(i+1)*cols+j], table[i*cols+j+1])
+			}
+		}
+	}
+
+	for i, j := 0, 0; i < len(midA) && j < len(midB); {
+		switch {
+		case midA[i] == midB[j]:
+			match[prefix+i] = prefix + j
+			i++
+			j++
+		case table[(i+1)*cols+j] >= table[i*cols+j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return match
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package merge
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestThreeWay(t *testing.T) {
+	tests := []struct {
+		name          string
+		base          string
+		local         string
+		remote        string
+		want          string
+		wantConflicts int
+	}{
+		{
+			name:   "No changes",
+			base:   "a\nb\nc\n",
+			local:  "a\nb\nc\n",
+			remote: "a\nb\nc\n",
+			want:   "a\nb\nc\n",
+		},
+		{
+			name:   "Only local changed",
+			base:   "a\nb\nc\n",
+			local:  "a\nB\nc\n",
+			remote: "a\nb\nc\n",
+			want:   "a\nB\nc\n",
+		},
+		{
+			name:   "Only remote changed",
+			base:   "a\nb\nc\n",
+			local:  "a\nb\nc\n",
+			remote: "a\nb\nc\nd\n",
+			want:   "a\nb\nc\nd\n",
+		},
+		{
+			name:   "Non-overlapping edits on both sides",
+			base:   "all: build\n\nbuild:\n\tgo build\n\ntest:\n\tgo test\n",
+			local:  "all: build\n\nbuild:\n\tgo build -v\n\ntest:\n\tgo test\n",
+			remote: "all: build\n\nbuild:\n\tgo build\n\ntest:\n\tgo test\n\nlint:\n\tgolangci-lint run\n",
+			want:   "all: build\n\nbuild:\n\tgo build -v\n\ntest:\n\tgo test\n\nlint:\n\tgolangci-lint run\n",
+		},
+		{
+			name:   "Identical edits on both sides",
+			base:   "a\nb\nc\n",
+			local:  "a\nX\nc\n",
+			remote: "a\nX\nc\n",
+			want:   "a\nX\nc\n",
+		},
+		{
+			name:          "Overlapping edits conflict",
+			base:          "a\nb\nc\n",
+			local:         "a\nlocal\nc\n",
+			remote:        "a\nremote\nc\n",
+			want:          "a\n" + MarkerLocal + "\nlocal\n" + MarkerSeparator + "\nremote\n" + MarkerNew + "\nc\n",
+			wantConflicts: 1,
+		},
+		{
+			name:          "Missing trailing newline before marker",
+			base:          "a\nb",
+			local:         "a\nlocal",
+			remote:        "a\nremote",
+			want:          "a\n" + MarkerLocal + "\nlocal\n" + MarkerSeparator + "\nremote\n" + MarkerNew + "\n",
+			wantConflicts: 1,
+		},
+		{
+			name:   "Empty base with identical sides",
+			base:   "",
+			local:  "same\n",
+			remote: "same\n",
+			want:   "same\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ThreeWay([]byte(tt.base), []byte(tt.local), []byte(tt.remote))
+			if string(res.Content) != tt.want {
+				t.Errorf("merged content mismatch.\nWant:\n%s\nGot:\n%s", tt.want, res.Content)
+			}
+			if res.Conflicts != tt.wantConflicts {
+				t.Errorf("conflict count mismatch. Want %d, Got %d", tt.wantConflicts, res.Conflicts)
+			}
+			if res.HasConflicts() != (tt.wantConflicts > 0) {
+				t.Errorf("HasConflicts() = %v, want %v", res.HasConflicts(), tt.wantConflicts > 0)
+			}
+		})
+	}
+}
+
+func TestThreeWay_MultipleHunks(t *testing.T) {
+	base := "1\n2\n3\n4\n5\n6\n7\n"
+	local := "1\nL2\n3\n4\n5\nL6\n7\n"
+	remote := "1\nR2\n3\n4\n5\n6\n7\n8\n"
+
+	res := ThreeWay([]byte(base), []byte(local), []byte(remote))
+	if res.Conflicts != 1 {
+		t.Fatalf("expected exactly one conflicting hunk, got %d", res.Conflicts)
+	}
+
+	merged := string(res.Content)
+	for _, want := range []string{"L6\n", "8\n", "L2\n", "R2\n"} {
+		if !strings.Contains(merged, want) {
+			t.Errorf("merged output missing %q:\n%s", want, merged)
+		}
+	}
+}
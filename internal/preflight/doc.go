@@ -1,2 +1,6 @@
 // Package preflight contains essential checks run before executing a scaffolding plan.
+//
+// CheckBinaries only looks binaries up on $PATH. Check also queries their
+// versions against constraints, such as the go directive of a new go.mod,
+// and reports every unmet requirement at once.
 package preflight
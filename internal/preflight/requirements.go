@@ -0,0 +1,148 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// versionTimeout bounds a single version query.
+const versionTimeout = 10 * time.Second
+
+// Requirement is a binary that must be on $PATH, optionally in a version
+// that meets a constraint.
+type Requirement struct {
+	Binary      string   // Command looked up on $PATH, e.g. go.
+	VersionArgs []string // Arguments that print the version, e.g. ["version"]; default ["--version"].
+	Constraint  string   // Accepted versions, e.g. ">=1.22" (see Satisfies); "" accepts any.
+	Reason      string   // Why it is required, shown in the report, e.g. "go, go_version = 1.22".
+}
+
+// String returns the binary and its constraint, e.g. "go >=1.22".
+func (r Requirement) String() string {
+	if r.Constraint == "" {
+		return r.Binary
+	}
+	return r.Binary + " " + r.Constraint
+}
+
+// Result is the outcome of checking one Requirement.
+type Result struct {
+	Requirement
+	Path    string // Resolved path of the binary, "" if it is not on $PATH.
+	Version string // Detected version, "" if it was not queried or could not be parsed.
+	Problem string // Why the requirement is not met, "" if it is.
+}
+
+// OK reports whether the requirement is met.
+func (r Result) OK() bool { return r.Problem == "" }
+
+// Report holds the results of a Check in the order of its requirements.
+type Report struct {
+	Results []Result
+}
+
+// Err returns a single error listing every unmet requirement, or nil.
+func (r *Report) Err() error {
+	var b strings.Builder
+	for _, res := range r.Results {
+		if res.OK() {
+			continue
+		}
+		b.WriteString("\n  - " + res.String())
+		if res.Reason != "" {
+			b.WriteString(" (" + res.Reason + ")")
+		}
+		b.WriteString(": " + res.Problem)
+	}
+	if b.Len() == 0 {
+		return nil
+	}
+	return fmt.Errorf("preflight checks failed:%s\nInstall or upgrade the tools above to continue", b.String())
+}
+
+// Check looks up every requirement on $PATH and, if it has a constraint or
+// version arguments, queries its version. It checks all requirements rather
+// than stopping at the first failure, so the report lists every problem. A
+// binary's version is only queried once per set of arguments.
+func Check(ctx context.Context, reqs ...Requirement) *Report {
+	report := &Report{}
+	type query struct {
+		version string
+		err     error
+	}
+	queried := map[string]query{}
+
+	for _, req := range reqs {
+		res := Result{Requirement: req}
+		path, err := exec.LookPath(req.Binary)
+		if err != nil {
+			res.Problem = "not found in $PATH"
+			report.Results = append(report.Results, res)
+			continue
+		}
+		res.Path = path
+
+		if req.Constraint != "" || len(req.VersionArgs) > 0 {
+			args := req.VersionArgs
+			if len(args) == 0 {
+				args = []string{"--version"}
+			}
+			key := path + "\x00" + strings.Join(args, "\x00")
+			q, ok := queried[key]
+			if !ok {
+				q.version, q.err = queryVersion(ctx, path, args)
+				queried[key] = q
+			}
+			res.Version = q.version
+			res.Problem = checkVersion(req, q.version, q.err)
+		}
+		report.Results = append(report.Results, res)
+	}
+	return report
+}
+
+// checkVersion returns why a queried version does not meet req, or "".
+func checkVersion(req Requirement, version string, queryErr error) string {
+	if queryErr != nil {
+		if req.Constraint == "" {
+			// The version was only informational.
+			return ""
+		}
+		return "could not determine version: " + queryErr.Error()
+	}
+	v, err := splitVersion(version)
+	if err != nil {
+		return err.Error()
+	}
+	ok, err := Satisfies(v, req.Constraint)
+	if err != nil {
+		return err.Error()
+	}
+	if !ok {
+		return "found version " + version
+	}
+	return ""
+}
+
+// queryVersion runs the binary with args and parses the version from its
+// combined output; some tools, such as java -version, print to stderr.
+func queryVersion(ctx context.Context, path string, args []string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, versionTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, path, args...).CombinedOutput() //nolint:gosec // Binary and arguments are declared by language packs
+	if err != nil {
+		return "", fmt.Errorf("%s %s failed: %w", path, strings.Join(args, " "), err)
+	}
+	v, err := ParseVersion(string(out))
+	if err != nil {
+		return "", err
+	}
+	return formatVersion(v), nil
+}
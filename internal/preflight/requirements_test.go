@@ -0,0 +1,135 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package preflight
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"scbake/internal/util/fileutil"
+	"strings"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	for output, want := range map[string]string{
+		"go version go1.22.3 linux/amd64":     "1.22.3",
+		"v20.11.0\n":                          "20.11.0",
+		"Python 3.12.1":                       "3.12.1",
+		"cargo 1.75.0 (1d8b05cdd 2023-11-20)": "1.75.0",
+		"openjdk version \"21.0.2\" 2024-01-16\nOpenJDK Runtime": "21.0.2",
+		"java version \"1.8.0_392\"":                             "1.8.0",
+	} {
+		v, err := ParseVersion(output)
+		if err != nil {
+			t.Errorf("ParseVersion(%q) failed: %v", output, err)
+			continue
+		}
+		if got := formatVersion(v); got != want {
+			t.Errorf("ParseVersion(%q) = %s, want %s", output, got, want)
+		}
+	}
+
+	if _, err := ParseVersion("unknown"); err == nil {
+		t.Error("expected an error for output without a version")
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	for _, tc := range []struct {
+		version    string
+		constraint string
+		want       bool
+	}{
+		{"1.22.3", ">=1.22", true},
+		{"1.22", ">=1.22.0", true},
+		{"1.19.13", ">=1.22", false},
+		{"1.9", ">=1.22", false},
+		{"20.11.0", ">=18, <23", true},
+		{"23.0.0", ">=18, <23", false},
+		{"21.0.2", "17", true},
+		{"3.12.1", "=3.12.1", true},
+		{"3.12.1", "!=3.12.1", false},
+		{"1.0", "", true},
+	} {
+		v, err := splitVersion(tc.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Satisfies(v, tc.constraint)
+		if err != nil {
+			t.Errorf("Satisfies(%s, %q) failed: %v", tc.version, tc.constraint, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("Satisfies(%s, %q) = %v, want %v", tc.version, tc.constraint, got, tc.want)
+		}
+	}
+
+	if _, err := Satisfies([]int{1}, ">=latest"); err == nil {
+		t.Error("expected an error for an invalid constraint")
+	}
+}
+
+// fakeBinary writes a shell script printing output to dir/name.
+func fakeBinary(t *testing.T, dir, name, output string) {
+	t.Helper()
+	script := "#!/bin/sh\necho '" + output + "'\n"
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(script), fileutil.ExecFilePerms); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestCheck checks that every unmet requirement ends up in one report.
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	fakeBinary(t, dir, "go", "go version go1.19.13 linux/amd64")
+	fakeBinary(t, dir, "node", "v20.11.0")
+	fakeBinary(t, dir, "java", "openjdk version \"21.0.2\" 2024-01-16")
+	t.Setenv("PATH", dir)
+
+	report := Check(context.Background(),
+		Requirement{Binary: "go", VersionArgs: []string{"version"}, Constraint: ">=1.22", Reason: "go_version = 1.22"},
+		Requirement{Binary: "node", Constraint: ">=18"},
+		Requirement{Binary: "java", VersionArgs: []string{"-version"}},
+		Requirement{Binary: "npm"},
+	)
+
+	if len(report.Results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(report.Results))
+	}
+	goRes, node, java, npm := report.Results[0], report.Results[1], report.Results[2], report.Results[3]
+	if goRes.OK() || goRes.Version != "1.19.13" {
+		t.Errorf("go 1.19.13 should not satisfy >=1.22: %+v", goRes)
+	}
+	if !node.OK() || node.Version != "20.11.0" {
+		t.Errorf("node 20.11.0 should satisfy >=18: %+v", node)
+	}
+	if !java.OK() || java.Version != "21.0.2" {
+		t.Errorf("java should be found with its version: %+v", java)
+	}
+	if npm.OK() || npm.Path != "" {
+		t.Errorf("npm should be missing: %+v", npm)
+	}
+
+	err := report.Err()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{
+		"go >=1.22 (go_version = 1.22): found version 1.19.13",
+		"npm: not found in $PATH",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("report should contain %q, got:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "node") {
+		t.Errorf("report should only list unmet requirements, got:\n%v", err)
+	}
+
+	if err := Check(context.Background(), Requirement{Binary: "node", Constraint: ">=18"}).Err(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
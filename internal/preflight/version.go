@@ -0,0 +1,115 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package preflight
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// versionPattern matches the first dotted version number in a tool's output,
+// e.g. 1.22.3 in "go version go1.22.3 linux/amd64" or 20.11.0 in "v20.11.0".
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)*`)
+
+// operators are the comparison operators of a constraint, longest first.
+var operators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// ParseVersion extracts the first version number from the output of a
+// version command and returns it as its numeric components.
+func ParseVersion(output string) ([]int, error) {
+	match := versionPattern.FindString(output)
+	if match == "" {
+		return nil, fmt.Errorf("no version number in %q", strings.TrimSpace(output))
+	}
+	return splitVersion(match)
+}
+
+func splitVersion(v string) ([]int, error) {
+	parts := strings.Split(v, ".")
+	nums := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q", v)
+		}
+		nums[i] = n
+	}
+	return nums, nil
+}
+
+// compareVersions compares a and b component by component; missing
+// components count as 0, so 1.22 equals 1.22.0.
+func compareVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// Satisfies reports whether version meets constraint: comma-separated
+// comparisons such as ">=1.22" or ">=18, <23". A comparison without an
+// operator means ">=". An empty constraint accepts every version.
+func Satisfies(version []int, constraint string) (bool, error) {
+	for _, c := range strings.Split(constraint, ",") {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		op := ">="
+		for _, o := range operators {
+			if rest, ok := strings.CutPrefix(c, o); ok {
+				op, c = o, strings.TrimSpace(rest)
+				break
+			}
+		}
+		want, err := splitVersion(c)
+		if err != nil {
+			return false, fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+		}
+
+		cmp := compareVersions(version, want)
+		var ok bool
+		switch op {
+		case ">=":
+			ok = cmp >= 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case "<":
+			ok = cmp < 0
+		case "=":
+			ok = cmp == 0
+		case "!=":
+			ok = cmp != 0
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// formatVersion joins the components of a version with dots.
+func formatVersion(v []int) string {
+	parts := make([]string, len(v))
+	for i, n := range v {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ".")
+}
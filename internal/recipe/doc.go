@@ -0,0 +1,9 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+// Package recipe reads and writes recipes: named, versioned TOML files that
+// capture a project layout (language, templates, default metadata and
+// sub-projects) so `scbake new --recipe` can reproduce it. Recipes are looked
+// up as explicit files, in ~/.config/scbake/recipes/ and in the registry
+// cache under recipes/.
+package recipe
@@ -0,0 +1,212 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package recipe
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"scbake/internal/templateregistry"
+	"scbake/internal/types"
+	"scbake/internal/util/fileutil"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	// fileExt is the extension of recipe files.
+	fileExt = ".toml"
+	// cacheSubdir is where registries ship recipes, relative to a pulled archive.
+	cacheSubdir = "recipes"
+	// legacyRootTemplates is the placeholder older manifests recorded instead of template names.
+	legacyRootTemplates = "root-templates"
+)
+
+// Recipe is a named, versioned project layout.
+type Recipe struct {
+	Name        string            `toml:"name"`
+	Version     string            `toml:"version"`
+	Description string            `toml:"description,omitempty"`
+	Lang        string            `toml:"lang,omitempty"` // Language of the root project.
+	With        []string          `toml:"with,omitempty"` // Templates applied at the root.
+	Metadata    map[string]string `toml:"metadata,omitempty"`
+	// ProjectMetadata holds the root project's language variables, e.g. its
+	// module_path; unlike Metadata, sub-projects don't see them.
+	ProjectMetadata map[string]string `toml:"project_metadata,omitempty"`
+	Projects        []Project         `toml:"projects,omitempty"`
+}
+
+// Project is a sub-project created by a recipe.
+type Project struct {
+	Path string   `toml:"path"` // Relative to the new project's root.
+	Lang string   `toml:"lang"`
+	With []string `toml:"with,omitempty"`
+	// Metadata holds the project's language variables, e.g. its module_path.
+	Metadata map[string]string `toml:"metadata,omitempty"`
+}
+
+// Load strictly decodes and validates the recipe file at path.
+func Load(path string) (*Recipe, error) {
+	var r Recipe
+	md, err := toml.DecodeFile(path, &r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse recipe %s: %w", path, err)
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for i, k := range undecoded {
+			keys[i] = k.String()
+		}
+		return nil, fmt.Errorf("unknown keys in recipe %s: %s", path, strings.Join(keys, ", "))
+	}
+	if err := r.validate(); err != nil {
+		return nil, fmt.Errorf("invalid recipe %s: %w", path, err)
+	}
+	return &r, nil
+}
+
+func (r *Recipe) validate() error {
+	var errs []error
+	if r.Name == "" {
+		errs = append(errs, errors.New("name: must not be empty"))
+	}
+	if r.Version == "" {
+		errs = append(errs, errors.New("version: must not be empty"))
+	}
+	if r.Lang == "" && len(r.With) == 0 && len(r.Projects) == 0 {
+		errs = append(errs, errors.New("recipe selects no language, templates or projects"))
+	}
+	for i, p := range r.Projects {
+		if p.Path == "" || p.Lang == "" {
+			errs = append(errs, fmt.Errorf("projects[%d]: path and lang must not be empty", i))
+			continue
+		}
+		if !filepath.IsLocal(filepath.FromSlash(p.Path)) {
+			errs = append(errs, fmt.Errorf("projects[%d]: path %q must be relative and stay within the project", i, p.Path))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Find resolves a recipe reference: a path to a .toml file, or a name
+// (optionally "name@version") looked up in localDir and then in the
+// registry cache. With a version, the recipe's version must match.
+func Find(ref, localDir, cacheDir string) (*Recipe, string, error) {
+	if strings.HasSuffix(ref, fileExt) || strings.ContainsRune(ref, filepath.Separator) || strings.ContainsRune(ref, '/') {
+		r, err := Load(ref)
+		return r, ref, err
+	}
+
+	name, version, _ := strings.Cut(ref, "@")
+	var path string
+	if localDir != "" {
+		candidate := filepath.Join(localDir, name+fileExt)
+		if _, err := os.Stat(candidate); err == nil {
+			path = candidate
+		}
+	}
+	if path == "" {
+		path = templateregistry.ResolveCachePath(cacheDir, filepath.Join(cacheSubdir, name+fileExt))
+	}
+	if path == "" {
+		return nil, "", fmt.Errorf("recipe %q not found in %s or the registry cache (pull it with 'scbake template pull')", name, localDir)
+	}
+
+	r, err := Load(path)
+	if err != nil {
+		return nil, "", err
+	}
+	if version != "" && r.Version != version {
+		return nil, "", fmt.Errorf("recipe %q is version %s, not %s", name, r.Version, version)
+	}
+	return r, path, nil
+}
+
+// FromManifest captures the projects, templates and metadata recorded in m.
+// The metadata of the root project is kept apart from the recipe's metadata,
+// which applies to every project. Templates recorded at a path without a
+// project can't be expressed in a recipe and are reported as an error.
+func FromManifest(name, version string, m *types.Manifest) (*Recipe, error) {
+	r := &Recipe{Name: name, Version: version}
+	if len(m.Metadata) > 0 {
+		r.Metadata = make(map[string]string, len(m.Metadata))
+		for k, v := range m.Metadata {
+			r.Metadata[k] = v
+		}
+	}
+
+	templatesAt := make(map[string][]string)
+	for _, t := range m.Templates {
+		if t.Name == legacyRootTemplates {
+			continue
+		}
+		path := cleanPath(t.Path)
+		templatesAt[path] = appendUnique(templatesAt[path], t.Name)
+	}
+
+	for _, p := range m.Projects {
+		path := cleanPath(p.Path)
+		with := templatesAt[path]
+		for _, t := range p.Templates {
+			with = appendUnique(with, t)
+		}
+		delete(templatesAt, path)
+
+		if path == "." {
+			r.Lang = p.Language
+			r.With = with
+			r.ProjectMetadata = p.Metadata
+			continue
+		}
+		r.Projects = append(r.Projects, Project{Path: path, Lang: p.Language, With: with, Metadata: p.Metadata})
+	}
+	r.With = append(r.With, templatesAt["."]...)
+	delete(templatesAt, ".")
+
+	if len(templatesAt) > 0 {
+		rest := make([]string, 0, len(templatesAt))
+		for path, names := range templatesAt {
+			rest = append(rest, fmt.Sprintf("%s (%s)", path, strings.Join(names, ", ")))
+		}
+		sort.Strings(rest)
+		return nil, fmt.Errorf("templates at paths without a project cannot be saved in a recipe: %s", strings.Join(rest, "; "))
+	}
+	return r, nil
+}
+
+// Save writes the recipe to path, creating parent directories.
+func (r *Recipe) Save(path string) error {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(r); err != nil {
+		return fmt.Errorf("failed to encode recipe: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), fileutil.DirPerms); err != nil {
+		return fmt.Errorf("failed to create recipe directory: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), fileutil.FilePerms); err != nil {
+		return fmt.Errorf("failed to write recipe: %w", err)
+	}
+	return nil
+}
+
+// cleanPath normalizes manifest paths, treating "" as the root.
+func cleanPath(p string) string {
+	if p == "" {
+		return "."
+	}
+	return filepath.ToSlash(filepath.Clean(p))
+}
+
+func appendUnique(list []string, item string) []string {
+	for _, l := range list {
+		if l == item {
+			return list
+		}
+	}
+	return append(list, item)
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package recipe
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"scbake/internal/types"
+	"scbake/internal/util/fileutil"
+	"strings"
+	"testing"
+)
+
+func TestFromManifest(t *testing.T) {
+	m := &types.Manifest{
+		Projects: []types.Project{
+			{Name: "root", Path: ".", Language: "go", Metadata: map[string]string{"module_path": "example.com/root"}},
+			{Name: "web", Path: "./web", Language: "svelte", Metadata: map[string]string{"typescript": "true"}},
+		},
+		Templates: []types.Template{
+			{Name: "root-templates", Path: "."},
+			{Name: "makefile", Path: "."},
+			{Name: "svelte_linter", Path: "web"},
+		},
+		Metadata: map[string]string{"license": "MIT"},
+	}
+
+	got, err := FromManifest("acme", "1.2.0", m)
+	if err != nil {
+		t.Fatalf("FromManifest failed: %v", err)
+	}
+	want := &Recipe{
+		Name:     "acme",
+		Version:  "1.2.0",
+		Lang:     "go",
+		With:     []string{"makefile"},
+		Metadata: map[string]string{"license": "MIT"},
+		// The root's variables stay out of Metadata, which sub-projects see.
+		ProjectMetadata: map[string]string{"module_path": "example.com/root"},
+		Projects:        []Project{{Path: "web", Lang: "svelte", With: []string{"svelte_linter"}, Metadata: map[string]string{"typescript": "true"}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromManifest() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFromManifest_TemplatesWithoutProject(t *testing.T) {
+	m := &types.Manifest{
+		Projects:  []types.Project{{Name: "root", Path: ".", Language: "go"}},
+		Templates: []types.Template{{Name: "makefile", Path: "."}, {Name: "ci_github", Path: "docs"}},
+	}
+
+	// Applying ci_github at the root instead of docs/ would change the layout.
+	_, err := FromManifest("acme", "1.2.0", m)
+	if err == nil || !strings.Contains(err.Error(), "docs (ci_github)") {
+		t.Errorf("FromManifest() error = %v, want one naming docs (ci_github)", err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	local := t.TempDir()
+	cache := t.TempDir()
+
+	r := &Recipe{Name: "svc", Version: "2.0.0", Lang: "go"}
+	if err := r.Save(filepath.Join(local, "svc.toml")); err != nil {
+		t.Fatal(err)
+	}
+	shared := &Recipe{Name: "shared", Version: "1.0.0", With: []string{"makefile"}}
+	if err := shared.Save(filepath.Join(cache, "acme", "recipes", "shared.toml")); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, _, err := Find("svc@2.0.0", local, cache); err != nil || !reflect.DeepEqual(got, r) {
+		t.Errorf("Find(svc@2.0.0) = %+v, %v", got, err)
+	}
+	if _, _, err := Find("svc@1.0.0", local, cache); err == nil {
+		t.Error("expected a version mismatch error")
+	}
+	if got, _, err := Find("shared", local, cache); err != nil || got.Name != "shared" {
+		t.Errorf("Find(shared) from the registry cache = %+v, %v", got, err)
+	}
+	if _, _, err := Find("missing", local, cache); err == nil {
+		t.Error("expected an error for an unknown recipe")
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown key":   "name = \"x\"\nversion = \"1\"\nlang = \"go\"\nlanguage = \"go\"\n",
+		"no version":    "name = \"x\"\nlang = \"go\"\n",
+		"empty":         "name = \"x\"\nversion = \"1\"\n",
+		"escaping path": "name = \"x\"\nversion = \"1\"\n\n[[projects]]\npath = \"../x\"\nlang = \"go\"\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "r.toml")
+			if err := os.WriteFile(path, []byte(content), fileutil.PrivateFilePerms); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(path); err == nil || !strings.Contains(err.Error(), path) {
+				t.Errorf("Load() error = %v, want an error naming %s", err, path)
+			}
+		})
+	}
+}
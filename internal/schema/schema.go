@@ -6,11 +6,11 @@
 package schema
 
 import (
-	"embed"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 )
 
 // VariableType enumerates the supported input types.
@@ -41,9 +41,9 @@ type Schema struct {
 	Variables   map[string]VariableDef `json:"variables"`
 }
 
-// ReadSchema reads and parses a schema.json from an embedded filesystem.
-func ReadSchema(efs embed.FS, path string) (*Schema, error) {
-	data, err := efs.ReadFile(path)
+// ReadSchema reads and parses a schema.json from a filesystem (typically an embed.FS).
+func ReadSchema(fsys fs.FS, path string) (*Schema, error) {
+	data, err := fs.ReadFile(fsys, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read %s: %w", path, err)
 	}
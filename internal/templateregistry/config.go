@@ -43,20 +43,30 @@ type Manager struct {
 	config     Config
 }
 
-// NewManager creates or loads a registry manager from XDG paths.
-func NewManager() (*Manager, error) {
+// DefaultPaths returns the registry config file and the template cache
+// directory in the XDG (or platform) user directories.
+func DefaultPaths() (configPath, cacheDir string, err error) {
 	configDir, err := os.UserConfigDir()
 	if err != nil {
-		return nil, fmt.Errorf("cannot determine user config dir: %w", err)
+		return "", "", fmt.Errorf("cannot determine user config dir: %w", err)
 	}
-	cacheDir, err := os.UserCacheDir()
+	userCacheDir, err := os.UserCacheDir()
 	if err != nil {
-		return nil, fmt.Errorf("cannot determine user cache dir: %w", err)
+		return "", "", fmt.Errorf("cannot determine user cache dir: %w", err)
+	}
+	return filepath.Join(configDir, configDirName, configFileName), filepath.Join(userCacheDir, cacheDirName), nil
+}
+
+// NewManager creates or loads a registry manager from XDG paths.
+func NewManager() (*Manager, error) {
+	configPath, cacheDir, err := DefaultPaths()
+	if err != nil {
+		return nil, err
 	}
 
 	m := &Manager{
-		configPath: filepath.Join(configDir, configDirName, configFileName),
-		cacheDir:   filepath.Join(cacheDir, cacheDirName),
+		configPath: configPath,
+		cacheDir:   cacheDir,
 	}
 
 	if err := m.load(); err != nil {
@@ -74,18 +84,29 @@ func NewManagerWithPaths(configPath, cacheDir string) *Manager {
 }
 
 func (m *Manager) load() error {
-	data, err := os.ReadFile(m.configPath)
+	config, err := LoadConfig(m.configPath)
+	if err != nil {
+		return err
+	}
+	m.config = *config
+	return nil
+}
+
+// LoadConfig reads the registry config at path. A missing file is an empty
+// config.
+func LoadConfig(path string) (*Config, error) {
+	config := &Config{}
+	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			m.config = Config{}
-			return nil
+			return config, nil
 		}
-		return fmt.Errorf("reading registry config: %w", err)
+		return nil, fmt.Errorf("reading registry config: %w", err)
 	}
-	if err := json.Unmarshal(data, &m.config); err != nil {
-		return fmt.Errorf("parsing registry config: %w", err)
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parsing registry config: %w", err)
 	}
-	return nil
+	return config, nil
 }
 
 func (m *Manager) save() error {
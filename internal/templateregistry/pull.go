@@ -119,7 +119,7 @@ func doDownload(url, token, name string) (*http.Response, error) {
 }
 
 func extractToCache(body io.Reader, destDir, subdirectory string) error {
-	tmpDir := destDir + ".tmp"
+	tmpDir := destDir + tmpSuffix
 	if err := os.RemoveAll(tmpDir); err != nil {
 		return fmt.Errorf("cleaning temp dir: %w", err)
 	}
@@ -0,0 +1,115 @@
+package templateregistry
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// tmpSuffix marks the directory a pull extracts into before it is moved to
+// its place in the cache.
+const tmpSuffix = ".tmp"
+
+// Validate checks that the registry can be pulled from: its name is usable
+// as a cache directory, its URL is an absolute http(s) URL and its
+// subdirectory stays inside the archive.
+func (r Registry) Validate() error {
+	if r.Name == "" || r.Name == "." || r.Name == ".." || strings.ContainsAny(r.Name, `/\`) {
+		return fmt.Errorf("invalid name %q: must be a single path element", r.Name)
+	}
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", r.URL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid URL %q: expected http:// or https:// with a host", r.URL)
+	}
+	if r.Subdirectory != "" {
+		sub := filepath.Clean(r.Subdirectory)
+		if filepath.IsAbs(sub) || sub == ".." || strings.HasPrefix(sub, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("invalid subdirectory %q: must be relative to the archive root", r.Subdirectory)
+		}
+	}
+	return nil
+}
+
+// CacheIssue is a problem VerifyCache found in the template cache.
+type CacheIssue struct {
+	Path    string // Affected directory.
+	Problem string // What is wrong.
+	Fix     string // How to fix it.
+}
+
+// VerifyCache checks the template cache at <cacheDir>/<registry>/<template>/
+// for leftovers of interrupted pulls, templates without files, which pull
+// never replaces, and registries that are no longer configured. A missing
+// cache directory has no issues.
+func VerifyCache(cacheDir string, registries []Registry) ([]CacheIssue, error) {
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading template cache: %w", err)
+	}
+
+	configured := make(map[string]bool, len(registries))
+	for _, r := range registries {
+		configured[r.Name] = true
+	}
+
+	var issues []CacheIssue
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		registryDir := filepath.Join(cacheDir, e.Name())
+		if !configured[e.Name()] && !isPackageDir(registryDir) {
+			issues = append(issues, CacheIssue{
+				Path:    registryDir,
+				Problem: fmt.Sprintf("cache of registry %q, which is not configured", e.Name()),
+				Fix:     "remove the directory, or add the registry again with 'scbake template registry add'",
+			})
+		}
+
+		templates, err := os.ReadDir(registryDir)
+		if err != nil {
+			return nil, fmt.Errorf("reading template cache: %w", err)
+		}
+		for _, t := range templates {
+			if !t.IsDir() {
+				continue
+			}
+			dir := filepath.Join(registryDir, t.Name())
+			if name, ok := strings.CutSuffix(t.Name(), tmpSuffix); ok {
+				issues = append(issues, CacheIssue{
+					Path:    dir,
+					Problem: fmt.Sprintf("interrupted pull of %q", name),
+					Fix:     "remove the directory and pull the template again",
+				})
+				continue
+			}
+			if err := validateTemplateCache(dir); err != nil {
+				issues = append(issues, CacheIssue{
+					Path:    dir,
+					Problem: fmt.Sprintf("template %q has no files", t.Name()),
+					Fix:     "remove the directory and pull the template again; pull skips templates that are already cached",
+				})
+			}
+		}
+	}
+	return issues, nil
+}
+
+// isPackageDir reports whether dir holds a declarative template package or
+// language pack, which may be placed in the cache without a registry.
+func isPackageDir(dir string) bool {
+	for _, name := range []string{"template.toml", "lang.toml"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
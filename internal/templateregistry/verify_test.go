@@ -0,0 +1,90 @@
+package templateregistry
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	valid := []Registry{
+		{Name: "acme", URL: "https://templates.acme.com/v1"},
+		{Name: "local", URL: "http://localhost:8080", Subdirectory: "templates/go"},
+	}
+	for _, r := range valid {
+		if err := r.Validate(); err != nil {
+			t.Errorf("expected %+v to be valid, got %v", r, err)
+		}
+	}
+
+	invalid := []Registry{
+		{Name: "", URL: "https://a.com"},
+		{Name: "a/b", URL: "https://a.com"},
+		{Name: "..", URL: "https://a.com"},
+		{Name: "acme", URL: "ftp://a.com"},
+		{Name: "acme", URL: "templates.acme.com"},
+		{Name: "acme", URL: "https://a.com", Subdirectory: "../outside"},
+		{Name: "acme", URL: "https://a.com", Subdirectory: "/etc"},
+	}
+	for _, r := range invalid {
+		if err := r.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", r)
+		}
+	}
+}
+
+func TestVerifyCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	mkdir := func(rel string) string {
+		t.Helper()
+		dir := filepath.Join(cacheDir, rel)
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+	write := func(rel string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(mkdir(filepath.Dir(rel)), filepath.Base(rel)), []byte(testTplContent), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("acme/go-service/main.go.tpl")
+	write("acme/lint.tmp/.golangci.yml.tpl")
+	empty := mkdir("acme/empty")
+	write("stale/ci/main.yml.tpl")
+	write("pkg/template.toml")
+
+	issues, err := VerifyCache(cacheDir, []Registry{{Name: "acme", URL: "https://a.com"}})
+	if err != nil {
+		t.Fatalf("VerifyCache failed: %v", err)
+	}
+
+	got := map[string]string{}
+	for _, issue := range issues {
+		got[issue.Path] = issue.Problem
+		if issue.Fix == "" {
+			t.Errorf("issue %q has no fix", issue.Path)
+		}
+	}
+	want := map[string]string{
+		filepath.Join(cacheDir, "acme", "lint.tmp"): "interrupted pull",
+		empty:                            "has no files",
+		filepath.Join(cacheDir, "stale"): "not configured",
+	}
+	if len(got) != len(want) {
+		t.Errorf("expected %d issues, got %v", len(want), got)
+	}
+	for path, problem := range want {
+		if !strings.Contains(got[path], problem) {
+			t.Errorf("expected %q for %s, got %q", problem, path, got[path])
+		}
+	}
+
+	issues, err = VerifyCache(filepath.Join(cacheDir, "missing"), nil)
+	if err != nil || len(issues) != 0 {
+		t.Errorf("expected no issues for a missing cache, got %v, %v", issues, err)
+	}
+}
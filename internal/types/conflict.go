@@ -0,0 +1,70 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ConflictStrategies are the accepted values of --conflict-strategy and of
+// the [conflict_strategies] manifest section.
+var ConflictStrategies = []string{"fail", "overwrite", "artifact", "keep-local", "merge", "prompt"}
+
+// ValidateConflictStrategy returns an error if strategy is not one of
+// ConflictStrategies.
+func ValidateConflictStrategy(strategy string) error {
+	for _, s := range ConflictStrategies {
+		if s == strategy {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid conflict strategy %q: must be one of %s", strategy, strings.Join(ConflictStrategies, ", "))
+}
+
+// ConflictReport collects the files that a three-way merge left with
+// unresolved conflict markers. It is shared by all tasks of a run and is
+// safe for concurrent use. A nil report silently discards entries.
+type ConflictReport struct {
+	mu    sync.Mutex
+	files []string
+}
+
+// Add records a file (relative to the task's target path) as conflicted.
+func (r *ConflictReport) Add(path string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, f := range r.files {
+		if f == path {
+			return
+		}
+	}
+	r.files = append(r.files, path)
+}
+
+// Files returns a sorted copy of all conflicted files.
+func (r *ConflictReport) Files() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := append([]string(nil), r.files...)
+	sort.Strings(out)
+	return out
+}
+
+// ConflictResolver decides how a single drifted file is reconciled, typically
+// by asking the user. It is consulted when the effective strategy is "prompt".
+type ConflictResolver interface {
+	// ResolveConflict returns the strategy to apply to the file at path
+	// (overwrite, keep-local, artifact or merge), given its local content and
+	// the new rendering.
+	ResolveConflict(path string, local, rendered []byte) (string, error)
+}
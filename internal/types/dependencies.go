@@ -0,0 +1,30 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package types
+
+// Dependencies declares how a template relates to other templates and to the
+// languages and build tools of the projects it is applied to. Template names refer to the
+// names used with --with; languages to the names used with --lang.
+type Dependencies struct {
+	// Requires lists templates that must be applied together with (or
+	// before) this one.
+	Requires []string
+
+	// Conflicts lists templates that cannot be applied at the same path.
+	Conflicts []string
+
+	// Suggests lists templates that usually complement this one. They are
+	// only mentioned to the user.
+	Suggests []string
+
+	// RequiresLanguages, if set, requires a project using one of these languages.
+	RequiresLanguages []string
+
+	// ConflictsLanguages lists languages whose projects this template cannot be applied to.
+	ConflictsLanguages []string
+
+	// RequiresBuildTools, if set, requires a project using one of these
+	// build tools (the build_tool recorded for projects, e.g. "maven").
+	RequiresBuildTools []string
+}
@@ -3,6 +3,8 @@
 
 package types
 
+import "sync"
+
 // Manifest is the root structure of the scbake.toml file.
 // It's the "source of truth" for the project.
 type Manifest struct {
@@ -13,6 +15,12 @@ type Manifest struct {
 	// ManagedFiles tracks files created by scbake and their SHA-256 hashes
 	// The key is the relative path (e.g., "SECURITY.md"), the value is the hash.
 	ManagedFiles map[string]string `toml:"managed_files,omitempty"`
+	// ConflictStrategies overrides --conflict-strategy for specific files.
+	// The key is a relative path or glob (e.g., "README.md", "docs/*.md"), the value a strategy.
+	ConflictStrategies map[string]string `toml:"conflict_strategies,omitempty"`
+
+	// mu guards ManagedFiles while tasks run in parallel.
+	mu sync.Mutex
 }
 
 // Project represents a distinct code unit, like a Go backend or a React frontend.
@@ -20,7 +28,11 @@ type Project struct {
 	Name      string   `toml:"name"`
 	Path      string   `toml:"path"`
 	Language  string   `toml:"language"`
-	Templates []string `toml:"templates"` // List of template names applied
+	BuildTool string   `toml:"build_tool,omitempty"` // e.g. "maven" or "gradle"; empty if the language has one build tool
+	Templates []string `toml:"templates"`            // List of template names applied
+	// Metadata holds the values of the language pack's variables for this
+	// project (e.g. module_path), which must not leak into other projects.
+	Metadata map[string]string `toml:"metadata,omitempty"`
 }
 
 // Template represents a root-level tooling template applied to the repo.
@@ -29,6 +41,45 @@ type Template struct {
 	Path string `toml:"path"`
 }
 
+// ManagedHash returns the recorded hash of a managed file, or "" if there is none.
+// It is safe for concurrent use with SetManagedHash.
+func (m *Manifest) ManagedHash(path string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ManagedFiles[path]
+}
+
+// SetManagedHash records the hash of a managed file. It is safe for concurrent
+// use, so tasks scheduled in parallel can share one manifest.
+func (m *Manifest) SetManagedHash(path, hash string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ManagedFiles == nil {
+		m.ManagedFiles = make(map[string]string)
+	}
+	m.ManagedFiles[path] = hash
+}
+
+// ProjectMetadata returns the metadata tasks of project p see: m's metadata
+// overridden by p's. p may be nil for repository-level tasks.
+func (m *Manifest) ProjectMetadata(p *Project) map[string]string {
+	var base map[string]string
+	if m != nil {
+		base = m.Metadata
+	}
+	if p == nil || len(p.Metadata) == 0 {
+		return base
+	}
+	merged := make(map[string]string, len(base)+len(p.Metadata))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range p.Metadata {
+		merged[k] = v
+	}
+	return merged
+}
+
 // DeepCopy creates a complete copy of the manifest including all nested slices.
 // This ensures modifications to the copy don't affect the original.
 func (m *Manifest) DeepCopy() *Manifest {
@@ -54,15 +105,30 @@ func (m *Manifest) DeepCopy() *Manifest {
 		result.ManagedFiles[k] = v
 	}
 
+	// Deep copy conflict strategy overrides
+	if m.ConflictStrategies != nil {
+		result.ConflictStrategies = make(map[string]string, len(m.ConflictStrategies))
+		for k, v := range m.ConflictStrategies {
+			result.ConflictStrategies[k] = v
+		}
+	}
+
 	// Deep copy projects
 	for i, p := range m.Projects {
 		result.Projects[i] = Project{
 			Name:      p.Name,
 			Path:      p.Path,
 			Language:  p.Language,
+			BuildTool: p.BuildTool,
 			Templates: make([]string, len(p.Templates)),
 		}
 		result.Projects[i].Templates = append([]string{}, p.Templates...)
+		if p.Metadata != nil {
+			result.Projects[i].Metadata = make(map[string]string, len(p.Metadata))
+			for k, v := range p.Metadata {
+				result.Projects[i].Metadata[k] = v
+			}
+		}
 	}
 
 	// Deep copy templates
@@ -16,18 +16,35 @@ type TaskContext struct {
 	// TargetPath is the path the task should operate in (e.g., "./backend").
 	TargetPath string
 
+	// RootPath is the directory holding the manifest. Managed files and their
+	// merge bases are recorded relative to it; if empty, TargetPath is used.
+	RootPath string
+
 	// Manifest is the *current* state of the manifest, read-only.
 	Manifest *Manifest
 
+	// Project is the manifest project at TargetPath, or nil for repository-level runs.
+	Project *Project
+
+	// ScbakeVersion is the version of the running scbake binary.
+	ScbakeVersion string
+
 	// DryRun indicates if we are in dry-run mode.
 	DryRun bool
 
 	// Force indicates if we should overwrite existing files.
 	Force bool
 
-	// ConflictStrategy determines how to handle state-aware drift (fail, overwrite, artifact, keep-local)
+	// ConflictStrategy determines how to handle state-aware drift (fail, overwrite, artifact, keep-local, merge, prompt)
 	ConflictStrategy string
 
+	// ConflictResolver is consulted for drifted files when the strategy is "prompt".
+	ConflictResolver ConflictResolver
+
+	// Conflicts collects files left with merge conflict markers (merge strategy).
+	// If nil, conflicts are only reported on stdout.
+	Conflicts *ConflictReport
+
 	// TemplateDir is the path to an external directory containing template overrides.
 	TemplateDir string
 
@@ -39,6 +56,12 @@ type TaskContext struct {
 	Tx *transaction.Manager
 }
 
+// Metadata returns the metadata the task runs with: the manifest metadata
+// overridden by the metadata of Project, if any.
+func (tc TaskContext) Metadata() map[string]string {
+	return tc.Manifest.ProjectMetadata(tc.Project)
+}
+
 // Task is the interface for all atomic operations (e.g., create file, exec command).
 type Task interface {
 	// Description provides a human-readable summary for logging.
@@ -51,7 +74,60 @@ type Task interface {
 	Execute(tc TaskContext) error
 }
 
+// ConditionalTask is an optional interface for tasks that only run when a
+// `when` expression holds. The expression is a Go template pipeline evaluated
+// against the manifest (e.g. `has_language "go"` or `eq .Metadata.ci "github"`);
+// an empty expression always runs.
+type ConditionalTask interface {
+	Condition() string
+}
+
+// SkippedPart is a part of a task that is not written because its `when`
+// condition is false, such as a single entry of a template tree.
+type SkippedPart struct {
+	Description string
+	Reason      string
+}
+
+// SkippingTask is an optional interface for tasks that skip parts of their
+// work based on `when` conditions. The executor reports the skipped parts
+// through Reporter.TaskSkipped before the task runs, in dry runs too.
+type SkippingTask interface {
+	SkippedParts(tc TaskContext) ([]SkippedPart, error)
+}
+
+// Schedule declares how a task relates to the other tasks of a plan. The
+// executor orders tasks by priority, but tasks whose resources do not overlap
+// may run concurrently (see --jobs), and After overrides the priority order.
+type Schedule struct {
+	// ID names the task so others can list it in After. IDs are unique within a plan.
+	ID string
+
+	// After lists the IDs of tasks that must finish before this one starts.
+	After []string
+
+	// Resources lists what the task touches: paths, usually below the target
+	// path handlers receive in GetTasks, or plain names such as "npm-cache".
+	// Resources overlap when they are equal or one is a parent directory of
+	// the other. A task without resources is exclusive: it never runs
+	// alongside another task.
+	Resources []string
+}
+
+// ScheduledTask is an optional interface for tasks that declare a Schedule.
+type ScheduledTask interface {
+	Scheduling() Schedule
+}
+
 // Plan is a sorted list of tasks to be executed.
 type Plan struct {
 	Tasks []Task
 }
+
+// MetadataTaskProvider is an optional interface for language and template
+// handlers whose tasks depend on the metadata of the run, such as subprocess
+// plugins. scbake calls GetTasksWithMetadata instead of GetTasks with the
+// manifest's [metadata] updated by --license, --copyright-holder and --set.
+type MetadataTaskProvider interface {
+	GetTasksWithMetadata(targetPath, templateDir, registryCacheDir string, metadata map[string]string) ([]Task, error)
+}
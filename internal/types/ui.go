@@ -12,8 +12,14 @@ type Reporter interface {
 	SetTotalSteps(total int)
 
 	// TaskStart initiates a progress indicator for a specific sub-task.
+	// current numbers the task; in parallel runs several tasks are active
+	// at once.
 	TaskStart(description string, current, total int)
 
-	// TaskEnd finalizes the progress indicator for the current sub-task.
-	TaskEnd(err error)
+	// TaskEnd finalizes the progress indicator of the sub-task started
+	// with the given number.
+	TaskEnd(current int, err error)
+
+	// TaskSkipped records a sub-task that did not run because its condition was false.
+	TaskSkipped(description, reason string, current, total int)
 }
@@ -1,3 +1,3 @@
 // Package ui provides terminal user interface components for scbake,
-// including progress reporting and interactive spinners.
+// including progress reporting, interactive spinners and conflict prompts.
 package ui
@@ -18,16 +18,22 @@ func NewReporter(totalSteps int, dryRun bool) types.Reporter {
 	}
 
 	// Automated detection for non-interactive environments (CI, pipes, redirects).
-	if !isTerminal() {
+	if !isTerminal(os.Stdout) {
 		return NewPlainReporter(totalSteps, false)
 	}
 
 	return NewSpinnerReporter(totalSteps)
 }
 
-// isTerminal checks if os.Stdout is connected to an interactive terminal.
-func isTerminal() bool {
-	fileInfo, err := os.Stdout.Stat()
+// IsInteractive reports whether both stdin and stdout are attached to a terminal,
+// i.e. whether the user can be prompted.
+func IsInteractive() bool {
+	return isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+// isTerminal checks if f is connected to an interactive terminal.
+func isTerminal(f *os.File) bool {
+	fileInfo, err := f.Stat()
 	if err != nil {
 		return false
 	}
@@ -43,4 +43,15 @@ func (r *PlainReporter) TaskStart(desc string, _, _ int) {
 }
 
 // TaskEnd is a no-op for the plain reporter to satisfy the Reporter interface.
-func (r *PlainReporter) TaskEnd(_ error) {}
+func (r *PlainReporter) TaskEnd(_ int, _ error) {}
+
+// TaskSkipped logs a task whose `when` condition was false, in both dry-run and normal mode.
+func (r *PlainReporter) TaskSkipped(desc, reason string, _, _ int) {
+	outputMux.Lock()
+	defer outputMux.Unlock()
+	if r.isDryRun {
+		fmt.Printf("  [DRY RUN] [SKIP] %s (%s)\n", desc, reason)
+		return
+	}
+	fmt.Printf("  ⏭️  Skipped: %s (%s)\n", desc, reason)
+}
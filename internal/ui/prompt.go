@@ -0,0 +1,85 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"scbake/internal/merge"
+	"strings"
+)
+
+// conflictChoices maps prompt answers to conflict strategies.
+// The uppercase variant of each key applies the choice to all remaining files.
+var conflictChoices = map[string]string{
+	"o": "overwrite",
+	"k": "keep-local",
+	"a": "artifact",
+	"m": "merge",
+}
+
+// ConflictPrompter resolves drifted files by asking the user, one file at a time.
+// It implements types.ConflictResolver.
+type ConflictPrompter struct {
+	in  *bufio.Reader
+	out io.Writer
+
+	// applyAll holds the strategy chosen with "apply to all", if any.
+	applyAll string
+}
+
+// NewConflictPrompter creates a prompter reading answers from in and writing to out.
+func NewConflictPrompter(in io.Reader, out io.Writer) *ConflictPrompter {
+	return &ConflictPrompter{in: bufio.NewReader(in), out: out}
+}
+
+// ResolveConflict shows the diff between the local file and the new rendering
+// and returns the strategy picked by the user.
+func (p *ConflictPrompter) ResolveConflict(path string, local, rendered []byte) (string, error) {
+	// Hold the output lock so the spinner does not draw over the prompt.
+	outputMux.Lock()
+	defer outputMux.Unlock()
+
+	if p.applyAll != "" {
+		fmt.Fprintf(p.out, "\n⚠️  Conflict in %s: applying %q (chosen for all files).\n", path, p.applyAll)
+		return p.applyAll, nil
+	}
+
+	fmt.Fprintf(p.out, "\n⚠️  Conflict in %s (user modifications detected).\n", path)
+	fmt.Fprint(p.out, merge.Diff(path+" (local)", path+" (new)", local, rendered))
+
+	for {
+		fmt.Fprint(p.out, "[o]verwrite, [k]eep local, [a]rtifact, [m]erge, [v]iew full (O/K/A/M = apply to all): ")
+
+		line, err := p.in.ReadString('\n')
+		answer := strings.TrimSpace(line)
+		if err != nil && answer == "" {
+			if errors.Is(err, io.EOF) {
+				return "", fmt.Errorf("no resolution given for %s: input closed", path)
+			}
+			return "", fmt.Errorf("failed to read resolution for %s: %w", path, err)
+		}
+
+		if answer == "v" || answer == "V" {
+			fmt.Fprintf(p.out, "----- %s (new) -----\n%s", path, rendered)
+			if len(rendered) > 0 && rendered[len(rendered)-1] != '\n' {
+				fmt.Fprintln(p.out)
+			}
+			fmt.Fprintln(p.out, "-----")
+			continue
+		}
+
+		if strategy, ok := conflictChoices[answer]; ok {
+			return strategy, nil
+		}
+		if strategy, ok := conflictChoices[strings.ToLower(answer)]; ok {
+			p.applyAll = strategy
+			return strategy, nil
+		}
+
+		fmt.Fprintf(p.out, "Unknown choice %q.\n", answer)
+	}
+}
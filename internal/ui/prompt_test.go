@@ -0,0 +1,69 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConflictPrompter(t *testing.T) {
+	t.Run("Single choice with diff", func(t *testing.T) {
+		var out bytes.Buffer
+		p := NewConflictPrompter(strings.NewReader("k\n"), &out)
+
+		got, err := p.ResolveConflict("README.md", []byte("local\n"), []byte("new\n"))
+		if err != nil {
+			t.Fatalf("ResolveConflict() error = %v", err)
+		}
+		if got != "keep-local" {
+			t.Errorf("got %q, want keep-local", got)
+		}
+		if !strings.Contains(out.String(), "-local\n+new\n") {
+			t.Errorf("prompt should show a diff, got:\n%s", out.String())
+		}
+	})
+
+	t.Run("View full then invalid then choice", func(t *testing.T) {
+		var out bytes.Buffer
+		p := NewConflictPrompter(strings.NewReader("v\nx\nm\n"), &out)
+
+		got, err := p.ResolveConflict("Makefile", []byte("a\n"), []byte("FULL RENDERING\n"))
+		if err != nil {
+			t.Fatalf("ResolveConflict() error = %v", err)
+		}
+		if got != "merge" {
+			t.Errorf("got %q, want merge", got)
+		}
+		if !strings.Contains(out.String(), "----- Makefile (new) -----\nFULL RENDERING\n") {
+			t.Errorf("view-full should print the new rendering, got:\n%s", out.String())
+		}
+		if !strings.Contains(out.String(), `Unknown choice "x"`) {
+			t.Errorf("invalid choice should be reported, got:\n%s", out.String())
+		}
+	})
+
+	t.Run("Apply to all", func(t *testing.T) {
+		var out bytes.Buffer
+		p := NewConflictPrompter(strings.NewReader("A\n"), &out)
+
+		for _, file := range []string{"a.txt", "b.txt"} {
+			got, err := p.ResolveConflict(file, []byte("1\n"), []byte("2\n"))
+			if err != nil {
+				t.Fatalf("ResolveConflict(%s) error = %v", file, err)
+			}
+			if got != "artifact" {
+				t.Errorf("ResolveConflict(%s) = %q, want artifact", file, got)
+			}
+		}
+	})
+
+	t.Run("Closed input", func(t *testing.T) {
+		p := NewConflictPrompter(strings.NewReader(""), &bytes.Buffer{})
+		if _, err := p.ResolveConflict("a.txt", nil, []byte("x\n")); err == nil {
+			t.Error("expected error when input is closed")
+		}
+	})
+}
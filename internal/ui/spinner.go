@@ -22,18 +22,23 @@ const (
 )
 
 // SpinnerReporter provides an interactive terminal UI with an animated spinner.
+// In parallel runs several tasks are active; the spinner shows the oldest one
+// and how many others are running.
 type SpinnerReporter struct {
 	mu            sync.Mutex
 	currentStep   int
 	totalSteps    int
-	activeDesc    string
-	activeIndex   int
-	activeTotal   int
-	done          chan struct{}
-	spinnerCtx    context.Context
+	active        []activeTask // In start order.
 	spinnerCancel context.CancelFunc
 }
 
+// activeTask is a started task that has not ended yet.
+type activeTask struct {
+	desc  string
+	index int
+	total int
+}
+
 // NewSpinnerReporter initializes a reporter with a specified number of high-level steps.
 func NewSpinnerReporter(totalSteps int) *SpinnerReporter {
 	return &SpinnerReporter{totalSteps: totalSteps}
@@ -58,73 +63,87 @@ func (r *SpinnerReporter) SetTotalSteps(total int) {
 	r.totalSteps = total
 }
 
-// TaskStart initiates the spinner animation for a specific sub-task in a separate goroutine.
+// TaskStart adds a sub-task to the spinner, starting the animation in a
+// separate goroutine if no other task is active.
 func (r *SpinnerReporter) TaskStart(desc string, curr, total int) {
-	// Cancel previous spinner if still running (cleanup)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.active = append(r.active, activeTask{desc: desc, index: curr, total: total})
 	if r.spinnerCancel != nil {
-		r.spinnerCancel()
+		return
 	}
 
-	// Create context with timeout to prevent goroutine leaks if TaskEnd() is never called
-	r.spinnerCtx, r.spinnerCancel = context.WithTimeout(context.Background(), spinnerTimeout)
-
-	r.mu.Lock()
-	r.activeDesc = desc
-	r.activeIndex = curr
-	r.activeTotal = total
-	r.done = make(chan struct{})
-	r.mu.Unlock()
-
-	go r.animate()
+	// The timeout prevents goroutine leaks if TaskEnd() is never called.
+	ctx, cancel := context.WithTimeout(context.Background(), spinnerTimeout)
+	r.spinnerCancel = cancel
+	go r.animate(ctx)
 }
 
 // animate handles the ticker logic and ANSI escape sequences for the spinner animation.
-func (r *SpinnerReporter) animate() {
+func (r *SpinnerReporter) animate(ctx context.Context) {
 	ticker := time.NewTicker(spinnerDelay)
 	defer ticker.Stop()
 	i := 0
 	for {
 		select {
-		case <-r.done:
-			return
-		case <-r.spinnerCtx.Done():
+		case <-ctx.Done():
 			return
 		case <-ticker.C:
 			r.mu.Lock()
-			desc := r.activeDesc
-			index := r.activeIndex
-			total := r.activeTotal
+			if len(r.active) == 0 {
+				r.mu.Unlock()
+				continue
+			}
+			first := r.active[0]
+			others := len(r.active) - 1
 			r.mu.Unlock()
+
+			line := fmt.Sprintf("[%d/%d] %s %s", first.index, first.total, spinnerChars[i%len(spinnerChars)], first.desc)
+			if others > 0 {
+				line += fmt.Sprintf(" (+%d running)", others)
+			}
 			outputMux.Lock()
-			prefix := fmt.Sprintf("[%d/%d]", index, total)
-			fmt.Printf("\r%s %s %s", prefix, spinnerChars[i%len(spinnerChars)], desc)
+			// Don't redraw over the final line of a task that just ended.
+			if ctx.Err() == nil {
+				fmt.Printf("\r\033[K%s", line)
+			}
 			outputMux.Unlock()
 			i++
 		}
 	}
 }
 
-// TaskEnd stops the active spinner goroutine and prints a final success or failure indicator.
-func (r *SpinnerReporter) TaskEnd(err error) {
-	// Signal goroutine to stop (primary method)
-	close(r.done)
-
-	// Cancel context to ensure cleanup if done channel was already consumed
-	if r.spinnerCancel != nil {
+// TaskEnd removes the sub-task numbered curr and prints a final success or
+// failure indicator for it. The animation stops when no task is left.
+func (r *SpinnerReporter) TaskEnd(curr int, err error) {
+	r.mu.Lock()
+	task := activeTask{index: curr}
+	for i, t := range r.active {
+		if t.index == curr {
+			task = t
+			r.active = append(r.active[:i], r.active[i+1:]...)
+			break
+		}
+	}
+	if len(r.active) == 0 && r.spinnerCancel != nil {
 		r.spinnerCancel()
+		r.spinnerCancel = nil
 	}
-
-	r.mu.Lock()
-	desc := r.activeDesc
-	index := r.activeIndex
-	total := r.activeTotal
 	r.mu.Unlock()
+
 	outputMux.Lock()
 	defer outputMux.Unlock()
-	prefix := fmt.Sprintf("[%d/%d]", index, total)
+	prefix := fmt.Sprintf("[%d/%d]", task.index, task.total)
 	if err != nil {
-		fmt.Printf("\r%s ❌ %s\n", prefix, desc)
+		fmt.Printf("\r\033[K%s ❌ %s\n", prefix, task.desc)
 		return
 	}
-	fmt.Printf("\r%s ✅ %s\n", prefix, desc)
+	fmt.Printf("\r\033[K%s ✅ %s\n", prefix, task.desc)
+}
+
+// TaskSkipped prints a skip indicator for a task whose condition was false. No spinner is started.
+func (r *SpinnerReporter) TaskSkipped(desc, reason string, curr, total int) {
+	outputMux.Lock()
+	defer outputMux.Unlock()
+	fmt.Printf("[%d/%d] ⏭️  %s (%s)\n", curr, total, desc, reason)
 }
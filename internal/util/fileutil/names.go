@@ -13,6 +13,10 @@ const (
 	// TmpDir is the subdirectory for transactional backups.
 	TmpDir = "tmp"
 
+	// BaseDir is the subdirectory holding the last rendered content of managed files,
+	// used as the common ancestor for three-way merges.
+	BaseDir = "base"
+
 	// GitDir is the standard Git repository directory marker.
 	GitDir = ".git"
 
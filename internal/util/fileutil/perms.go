@@ -12,6 +12,9 @@ const (
 	// FilePerms (0644) is the standard setting for public project files (rw-r--r--).
 	FilePerms os.FileMode = 0o644
 
+	// ExecFilePerms (0755) is for executable project files such as scripts and wrappers (rwxr-xr-x).
+	ExecFilePerms os.FileMode = 0o755
+
 	// PrivateFilePerms (0600) is for sensitive files like the manifest (rw-------).
 	PrivateFilePerms os.FileMode = 0o600
 )
@@ -0,0 +1,53 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+// Package declarative loads template packages (template.toml) and language
+// packs (lang.toml) described by a file instead of Go code, so new templates
+// and languages can be shipped through a registry or a local directory
+// without rebuilding scbake.
+//
+// A package is a directory containing template.toml and the files it refers to:
+//
+//	acme_observability/
+//	├── template.toml
+//	├── schema.json
+//	└── otel-collector.yaml.tpl
+//
+// template.toml declares the package:
+//
+//	name = "acme_observability"          # defaults to the directory name
+//	description = "OpenTelemetry collector setup"
+//	band = "devenv"                      # config, ci, linter, build, devenv or vcs
+//	schema = "schema.json"               # optional input schema
+//	requires = ["git"]                   # templates applied with this one
+//	suggests = ["ci_github"]             # mentioned to the user only
+//	requires_languages = ["go"]          # also: conflicts, conflicts_languages,
+//	                                     # requires_build_tools
+//
+//	[[directories]]
+//	path = "deploy"
+//
+//	[[files]]
+//	template = "otel-collector.yaml.tpl" # relative to the package directory
+//	output = "deploy/otel-collector.yaml"
+//	when = 'has_language "go"'
+//
+//	[[trees]]
+//	root = "dashboards"
+//	output = "deploy/dashboards"
+//
+//	[[commands]]
+//	cmd = "make"
+//	args = ["fmt"]
+//	predicted_created = ["bin"]
+//
+// Tasks are created in the order directories, files, trees, commands, each
+// section in declaration order, with consecutive priorities from the band.
+// Templates are rendered exactly like built-in ones (partials, functions,
+// TemplateContext) and can be overridden file by file through --template-dir
+// under "<package directory name>/". Commands run in the target directory.
+//
+// A language pack uses the same sections in lang.toml, plus `binaries`, the
+// executables required on PATH. It has no band: directories are created in
+// the directory band and all other tasks run in the language setup band.
+package declarative
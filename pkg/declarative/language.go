@@ -0,0 +1,90 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package declarative
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"scbake/internal/types"
+)
+
+// LanguageManifestFile marks a directory as a language pack.
+const LanguageManifestFile = "lang.toml"
+
+// LanguagePack is a language handler loaded from lang.toml. It uses the same
+// sections as a template package; directories are created in the directory
+// band and everything else runs in the language setup band.
+// It implements lang.Handler, lang.SchemaProvider and lang.PrerequisitesProvider.
+type LanguagePack struct {
+	Name        string `toml:"name"`
+	Description string `toml:"description"`
+	Schema      string `toml:"schema"`
+
+	// Binaries must be on PATH before the pack runs (e.g. ["cargo"]).
+	Binaries []string `toml:"binaries"`
+
+	Contents
+
+	// Dir is the pack directory.
+	Dir string `toml:"-"`
+}
+
+// LoadLanguagePack reads and validates dir/lang.toml.
+func LoadLanguagePack(dir string) (*LanguagePack, error) {
+	p := &LanguagePack{}
+	if err := decodeFile(filepath.Join(dir, LanguageManifestFile), p); err != nil {
+		return nil, err
+	}
+	p.Dir = dir
+
+	if p.Name == "" {
+		p.Name = filepath.Base(dir)
+	}
+
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s in %s: %w", LanguageManifestFile, dir, err)
+	}
+	return p, nil
+}
+
+func (p *LanguagePack) validate() error {
+	if !namePattern.MatchString(p.Name) {
+		return fmt.Errorf("name %q must match %s", p.Name, namePattern)
+	}
+	if p.Schema != "" {
+		if err := checkSource(p.Dir, p.Schema); err != nil {
+			return fmt.Errorf("schema: %w", err)
+		}
+	}
+	for i, b := range p.Binaries {
+		if b == "" {
+			return fmt.Errorf("binaries[%d]: must not be empty", i)
+		}
+	}
+	return p.Contents.validate(p.Dir)
+}
+
+// GetTasks returns the pack's plan for the project at targetPath.
+func (p *LanguagePack) GetTasks(targetPath string, _ string, _ string) ([]types.Task, error) {
+	dirSeq, err := types.NewPrioritySequence(types.PrioDirCreate, types.MaxDirCreate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create directory priority sequence: %w", err)
+	}
+	seq, err := types.NewPrioritySequence(types.PrioLangSetup, types.MaxLangSetup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create priority sequence: %w", err)
+	}
+	return p.tasks(dirSeq, seq, targetPath, filepath.Base(p.Dir), os.DirFS(filepath.Dir(p.Dir)))
+}
+
+// SchemaFS returns the pack directory.
+func (p *LanguagePack) SchemaFS() fs.FS { return os.DirFS(p.Dir) }
+
+// SchemaPath returns the schema file declared in lang.toml, if any.
+func (p *LanguagePack) SchemaPath() string { return filepath.ToSlash(p.Schema) }
+
+// RequiredBinaries returns the binaries declared in lang.toml.
+func (p *LanguagePack) RequiredBinaries() []string { return p.Binaries }
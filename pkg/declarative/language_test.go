@@ -0,0 +1,89 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package declarative
+
+import (
+	"os"
+	"path/filepath"
+	"scbake/internal/types"
+	"scbake/pkg/tasks"
+	"testing"
+)
+
+const rustDir = "testdata/langs/rust"
+
+func TestLoadLanguagePack(t *testing.T) {
+	p, err := LoadLanguagePack(rustDir)
+	if err != nil {
+		t.Fatalf("LoadLanguagePack failed: %v", err)
+	}
+	if p.Name != "rust" {
+		t.Errorf("Name = %q, want directory name", p.Name)
+	}
+	if got := p.RequiredBinaries(); len(got) != 1 || got[0] != "cargo" {
+		t.Errorf("RequiredBinaries() = %v, want [cargo]", got)
+	}
+	if p.SchemaPath() != "schema.json" {
+		t.Errorf("SchemaPath() = %q", p.SchemaPath())
+	}
+
+	plan, err := p.GetTasks("/repo/cli", "", "")
+	if err != nil {
+		t.Fatalf("GetTasks failed: %v", err)
+	}
+	if len(plan) != 4 {
+		t.Fatalf("expected 4 tasks, got %d", len(plan))
+	}
+
+	// Directories go into the directory band, everything else into language setup.
+	wantPrio := []int{int(types.PrioDirCreate), int(types.PrioLangSetup), int(types.PrioLangSetup) + 1, int(types.PrioLangSetup) + 2}
+	for i, task := range plan {
+		if task.Priority() != wantPrio[i] {
+			t.Errorf("task %d priority = %d, want %d", i, task.Priority(), wantPrio[i])
+		}
+	}
+	if dir := plan[0].(*tasks.CreateDirTask); dir.Path != filepath.Join("/repo/cli", "src") {
+		t.Errorf("directory path = %q, want it under the target", dir.Path)
+	}
+	if cmd := plan[3].(*tasks.ExecCommandTask); len(cmd.PredictedCreated) != 2 {
+		t.Errorf("PredictedCreated = %v, want Cargo.lock and target", cmd.PredictedCreated)
+	}
+}
+
+func TestLanguagePack_Render(t *testing.T) {
+	p, err := LoadLanguagePack(rustDir)
+	if err != nil {
+		t.Fatalf("LoadLanguagePack failed: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	plan, err := p.GetTasks(tmpDir, "", "")
+	if err != nil {
+		t.Fatalf("GetTasks failed: %v", err)
+	}
+
+	m := &types.Manifest{
+		Projects: []types.Project{{Name: "cli", Path: ".", Language: "rust"}},
+		Metadata: map[string]string{"rust_edition": "2021"},
+	}
+	tc := types.TaskContext{TargetPath: tmpDir, Manifest: m, Project: &m.Projects[0]}
+	for _, task := range plan[:3] {
+		if err := task.Execute(tc); err != nil {
+			t.Fatalf("%s failed: %v", task.Description(), err)
+		}
+	}
+
+	//nolint:gosec // Test temp directory
+	content, err := os.ReadFile(filepath.Join(tmpDir, "Cargo.toml"))
+	if err != nil {
+		t.Fatalf("Cargo.toml not written: %v", err)
+	}
+	want := "[package]\nname = \"cli\"\nversion = \"0.1.0\"\nedition = \"2021\"\n"
+	if string(content) != want {
+		t.Errorf("Cargo.toml = %q, want %q", content, want)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "src", "main.rs")); err != nil {
+		t.Errorf("src/main.rs not written: %v", err)
+	}
+}
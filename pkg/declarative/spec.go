@@ -0,0 +1,257 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package declarative
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"scbake/internal/types"
+	"scbake/pkg/tasks"
+	"sort"
+	"strings"
+)
+
+// namePattern restricts package names to what --with and --lang accept unquoted.
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// band is an inclusive priority range.
+type band struct {
+	base, max types.Priority
+}
+
+// bands maps the band names accepted in template.toml to priority ranges.
+var bands = map[string]band{
+	"config": {types.PrioConfigUniversal, types.MaxConfigUniversal},
+	"ci":     {types.PrioCI, types.MaxCI},
+	"linter": {types.PrioLinter, types.MaxLinter},
+	"build":  {types.PrioBuildSystem, types.MaxBuildSystem},
+	"devenv": {types.PrioDevEnv, types.MaxDevEnv},
+	"vcs":    {types.PrioVersionControl, types.MaxVersionControl},
+}
+
+// DirectorySpec declares a directory to create.
+type DirectorySpec struct {
+	Path string `toml:"path"`
+}
+
+// FileSpec declares a template rendered to a single file.
+type FileSpec struct {
+	Template    string `toml:"template"`
+	Output      string `toml:"output"`
+	Description string `toml:"description"`
+	When        string `toml:"when"`
+}
+
+// TreeSpec declares a template directory rendered with tasks.CreateTreeTask.
+type TreeSpec struct {
+	Root        string            `toml:"root"`
+	Output      string            `toml:"output"`
+	Description string            `toml:"description"`
+	Ignore      []string          `toml:"ignore"`
+	Executable  []string          `toml:"executable"`
+	Conditions  map[string]string `toml:"conditions"`
+	When        string            `toml:"when"`
+}
+
+// CommandSpec declares an external command run in the target directory.
+type CommandSpec struct {
+	Cmd              string   `toml:"cmd"`
+	Args             []string `toml:"args"`
+	Description      string   `toml:"description"`
+	PredictedCreated []string `toml:"predicted_created"`
+	When             string   `toml:"when"`
+}
+
+// Contents is the task-producing part shared by all declarative packages.
+type Contents struct {
+	Directories []DirectorySpec `toml:"directories"`
+	Files       []FileSpec      `toml:"files"`
+	Trees       []TreeSpec      `toml:"trees"`
+	Commands    []CommandSpec   `toml:"commands"`
+}
+
+// validate checks that every entry is complete and stays within the package
+// and the target directory.
+func (c *Contents) validate(dir string) error {
+	var errs []error
+
+	if len(c.Directories)+len(c.Files)+len(c.Trees)+len(c.Commands) == 0 {
+		return errors.New("no directories, files, trees or commands declared")
+	}
+
+	for i, d := range c.Directories {
+		if err := checkRelative(d.Path); err != nil {
+			errs = append(errs, fmt.Errorf("directories[%d].path: %w", i, err))
+		}
+	}
+	for i, f := range c.Files {
+		if err := checkSource(dir, f.Template); err != nil {
+			errs = append(errs, fmt.Errorf("files[%d].template: %w", i, err))
+		}
+		if err := checkRelative(f.Output); err != nil {
+			errs = append(errs, fmt.Errorf("files[%d].output: %w", i, err))
+		}
+	}
+	for i, t := range c.Trees {
+		if err := checkSource(dir, t.Root); err != nil {
+			errs = append(errs, fmt.Errorf("trees[%d].root: %w", i, err))
+		}
+		if t.Output != "" {
+			if err := checkRelative(t.Output); err != nil {
+				errs = append(errs, fmt.Errorf("trees[%d].output: %w", i, err))
+			}
+		}
+	}
+	for i, cmd := range c.Commands {
+		if cmd.Cmd == "" {
+			errs = append(errs, fmt.Errorf("commands[%d].cmd: must not be empty", i))
+		}
+		for _, p := range cmd.PredictedCreated {
+			if err := checkRelative(p); err != nil {
+				errs = append(errs, fmt.Errorf("commands[%d].predicted_created: %w", i, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// tasks builds the plan for targetPath. Directories take priorities from
+// dirSeq, everything else from seq. Each task claims the paths it writes as
+// its scheduling resources; commands claim the whole target directory. fsys is the directory holding the
+// package, whose templates are addressed as "<namespace>/<path>" so that
+// --template-dir and the registry cache can override them per package.
+func (c *Contents) tasks(dirSeq, seq *types.PrioritySequence, targetPath, namespace string, fsys fs.FS) ([]types.Task, error) {
+	var plan []types.Task
+
+	next := func() (int, error) {
+		p, err := seq.Next()
+		return int(p), err
+	}
+
+	for _, d := range c.Directories {
+		p, err := dirSeq.Next()
+		if err != nil {
+			return nil, err
+		}
+		dir := filepath.Join(targetPath, filepath.FromSlash(d.Path))
+		plan = append(plan, &tasks.CreateDirTask{
+			Path:     dir,
+			Desc:     "Create directory " + d.Path,
+			TaskPrio: int(p),
+			Schedule: types.Schedule{Resources: []string{dir}},
+		})
+	}
+
+	for _, f := range c.Files {
+		p, err := next()
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, &tasks.CreateTemplateTask{
+			TemplateFS:   fsys,
+			TemplatePath: path.Join(namespace, f.Template),
+			OutputPath:   filepath.FromSlash(f.Output),
+			Desc:         orDefault(f.Description, "Create "+f.Output),
+			TaskPrio:     p,
+			When:         f.When,
+			Schedule:     types.Schedule{Resources: []string{filepath.Join(targetPath, filepath.FromSlash(f.Output))}},
+		})
+	}
+
+	for _, t := range c.Trees {
+		p, err := next()
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, &tasks.CreateTreeTask{
+			TemplateFS: fsys,
+			Root:       path.Join(namespace, t.Root),
+			OutputDir:  filepath.FromSlash(t.Output),
+			Ignore:     t.Ignore,
+			Executable: t.Executable,
+			Conditions: t.Conditions,
+			Desc:       orDefault(t.Description, "Render "+t.Root),
+			TaskPrio:   p,
+			When:       t.When,
+			Schedule:   types.Schedule{Resources: []string{filepath.Join(targetPath, filepath.FromSlash(t.Output))}},
+		})
+	}
+
+	for _, cmd := range c.Commands {
+		p, err := next()
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, &tasks.ExecCommandTask{
+			Cmd:              cmd.Cmd,
+			Args:             cmd.Args,
+			Desc:             orDefault(cmd.Description, "Run "+strings.Join(append([]string{cmd.Cmd}, cmd.Args...), " ")),
+			TaskPrio:         p,
+			RunInTarget:      true,
+			PredictedCreated: cmd.PredictedCreated,
+			When:             cmd.When,
+			Schedule:         types.Schedule{Resources: []string{targetPath}},
+		})
+	}
+
+	return plan, nil
+}
+
+// BandRange returns the inclusive priority range of a band name accepted in
+// template.toml. Subprocess plugins use the same names.
+func BandRange(name string) (types.Priority, types.Priority, error) {
+	b, ok := bands[name]
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown band %q (expected one of: %s)", name, strings.Join(bandNames(), ", "))
+	}
+	return b.base, b.max, nil
+}
+
+// checkRelative rejects empty, absolute and escaping output paths.
+func checkRelative(p string) error {
+	if p == "" {
+		return errors.New("must not be empty")
+	}
+	if path.IsAbs(p) || filepath.IsAbs(p) {
+		return fmt.Errorf("%q must be relative", p)
+	}
+	if clean := path.Clean(filepath.ToSlash(p)); clean == ".." || strings.HasPrefix(clean, "../") {
+		return fmt.Errorf("%q escapes the target directory", p)
+	}
+	return nil
+}
+
+// checkSource verifies that p names an existing file or directory inside dir.
+func checkSource(dir, p string) error {
+	if err := checkRelative(p); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(p))); err != nil {
+		return fmt.Errorf("%q not found in package", p)
+	}
+	return nil
+}
+
+// bandNames returns the accepted band names, sorted.
+func bandNames() []string {
+	names := make([]string, 0, len(bands))
+	for n := range bands {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func orDefault(s, def string) string {
+	if s != "" {
+		return s
+	}
+	return def
+}
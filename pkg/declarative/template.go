@@ -0,0 +1,156 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package declarative
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"scbake/internal/types"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// TemplateManifestFile marks a directory as a template package.
+const TemplateManifestFile = "template.toml"
+
+// defaultBand is used when template.toml does not name a band.
+const defaultBand = "config"
+
+// TemplatePackage is a tooling template loaded from template.toml.
+// It implements templates.Handler, templates.SchemaProvider and
+// templates.DependencyProvider.
+type TemplatePackage struct {
+	Name        string `toml:"name"`
+	Description string `toml:"description"`
+	Band        string `toml:"band"`
+	Schema      string `toml:"schema"`
+
+	// Relations to other templates and to project languages and build tools
+	// (see types.Dependencies).
+	Requires           []string `toml:"requires"`
+	Conflicts          []string `toml:"conflicts"`
+	Suggests           []string `toml:"suggests"`
+	RequiresLanguages  []string `toml:"requires_languages"`
+	ConflictsLanguages []string `toml:"conflicts_languages"`
+	RequiresBuildTools []string `toml:"requires_build_tools"`
+
+	Contents
+
+	// Dir is the package directory.
+	Dir string `toml:"-"`
+}
+
+// LoadTemplatePackage reads and validates dir/template.toml.
+func LoadTemplatePackage(dir string) (*TemplatePackage, error) {
+	p := &TemplatePackage{}
+	if err := decodeFile(filepath.Join(dir, TemplateManifestFile), p); err != nil {
+		return nil, err
+	}
+	p.Dir = dir
+
+	if p.Name == "" {
+		p.Name = filepath.Base(dir)
+	}
+	if p.Band == "" {
+		p.Band = defaultBand
+	}
+
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s in %s: %w", TemplateManifestFile, dir, err)
+	}
+	return p, nil
+}
+
+func (p *TemplatePackage) validate() error {
+	if !namePattern.MatchString(p.Name) {
+		return fmt.Errorf("name %q must match %s", p.Name, namePattern)
+	}
+	if _, _, err := BandRange(p.Band); err != nil {
+		return err
+	}
+	if p.Schema != "" {
+		if err := checkSource(p.Dir, p.Schema); err != nil {
+			return fmt.Errorf("schema: %w", err)
+		}
+	}
+	return p.Contents.validate(p.Dir)
+}
+
+// GetTasks returns the package's plan within its priority band.
+func (p *TemplatePackage) GetTasks(targetPath string, _ string, _ string) ([]types.Task, error) {
+	base, limit, err := BandRange(p.Band)
+	if err != nil {
+		return nil, err
+	}
+	seq, err := types.NewPrioritySequence(base, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create priority sequence: %w", err)
+	}
+	return p.tasks(seq, seq, targetPath, filepath.Base(p.Dir), os.DirFS(filepath.Dir(p.Dir)))
+}
+
+// SchemaFS returns the package directory.
+func (p *TemplatePackage) SchemaFS() fs.FS { return os.DirFS(p.Dir) }
+
+// SchemaPath returns the schema file declared in template.toml, if any.
+func (p *TemplatePackage) SchemaPath() string { return filepath.ToSlash(p.Schema) }
+
+// Dependencies returns the relations declared in template.toml.
+func (p *TemplatePackage) Dependencies() types.Dependencies {
+	return types.Dependencies{
+		Requires:           p.Requires,
+		Conflicts:          p.Conflicts,
+		Suggests:           p.Suggests,
+		RequiresLanguages:  p.RequiresLanguages,
+		ConflictsLanguages: p.ConflictsLanguages,
+		RequiresBuildTools: p.RequiresBuildTools,
+	}
+}
+
+// FindPackages returns the directories below root that contain file, either
+// directly (root/<pkg>/file) or one level deeper, which is how the registry
+// cache stores pulled templates (root/<registry>/<pkg>/file). A missing root
+// yields no results.
+func FindPackages(root, file string) ([]string, error) {
+	if root == "" {
+		return nil, nil
+	}
+
+	var dirs []string
+	for _, pattern := range []string{
+		filepath.Join(root, "*", file),
+		filepath.Join(root, "*", "*", file),
+	} {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search %s: %w", root, err)
+		}
+		for _, m := range matches {
+			dirs = append(dirs, filepath.Dir(m))
+		}
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// decodeFile strictly decodes a TOML file into v, rejecting unknown keys so
+// typos in package files surface as errors instead of being ignored.
+func decodeFile(path string, v interface{}) error {
+	md, err := toml.DecodeFile(path, v)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for i, k := range undecoded {
+			keys[i] = k.String()
+		}
+		return fmt.Errorf("unknown keys in %s: %s", path, strings.Join(keys, ", "))
+	}
+	return nil
+}
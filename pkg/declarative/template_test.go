@@ -0,0 +1,135 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package declarative
+
+import (
+	"os"
+	"path/filepath"
+	"scbake/internal/schema"
+	"scbake/internal/types"
+	"scbake/pkg/tasks"
+	"strings"
+	"testing"
+)
+
+const acmeDir = "testdata/packages/acme_observability"
+
+func TestLoadTemplatePackage(t *testing.T) {
+	p, err := LoadTemplatePackage(acmeDir)
+	if err != nil {
+		t.Fatalf("LoadTemplatePackage failed: %v", err)
+	}
+	if p.Name != "acme_observability" {
+		t.Errorf("Name = %q, want directory name", p.Name)
+	}
+
+	plan, err := p.GetTasks("/repo", "", "")
+	if err != nil {
+		t.Fatalf("GetTasks failed: %v", err)
+	}
+	if len(plan) != 5 {
+		t.Fatalf("expected 5 tasks, got %d", len(plan))
+	}
+
+	// Directories, files, trees, commands; consecutive priorities in the devenv band.
+	if _, ok := plan[0].(*tasks.CreateDirTask); !ok {
+		t.Errorf("task 0 should be CreateDirTask, got %T", plan[0])
+	}
+	file, ok := plan[1].(*tasks.CreateTemplateTask)
+	if !ok || file.TemplatePath != "acme_observability/otel-collector.yaml.tpl" {
+		t.Errorf("task 1 should render the namespaced collector template, got %#v", plan[1])
+	}
+	if cond, ok := plan[2].(*tasks.CreateTemplateTask); !ok || cond.When != `has_language "go"` {
+		t.Errorf("task 2 should carry the when condition, got %#v", plan[2])
+	}
+	if _, ok := plan[3].(*tasks.CreateTreeTask); !ok {
+		t.Errorf("task 3 should be CreateTreeTask, got %T", plan[3])
+	}
+	if cmd, ok := plan[4].(*tasks.ExecCommandTask); !ok || !cmd.RunInTarget {
+		t.Errorf("task 4 should be an ExecCommandTask in the target, got %#v", plan[4])
+	}
+	for i, task := range plan {
+		if want := int(types.PrioDevEnv) + i; task.Priority() != want {
+			t.Errorf("task %d priority = %d, want %d", i, task.Priority(), want)
+		}
+	}
+}
+
+func TestTemplatePackage_Render(t *testing.T) {
+	p, err := LoadTemplatePackage(acmeDir)
+	if err != nil {
+		t.Fatalf("LoadTemplatePackage failed: %v", err)
+	}
+
+	s, err := schema.ReadSchema(p.SchemaFS(), p.SchemaPath())
+	if err != nil {
+		t.Fatalf("ReadSchema failed: %v", err)
+	}
+	metadata := map[string]string{}
+	if res := schema.Validate(s, metadata); res.HasErrors() {
+		t.Fatalf("unexpected validation errors: %s", res.Error())
+	}
+
+	tmpDir := t.TempDir()
+	plan, err := p.GetTasks(tmpDir, "", "")
+	if err != nil {
+		t.Fatalf("GetTasks failed: %v", err)
+	}
+	tc := types.TaskContext{TargetPath: tmpDir, Manifest: &types.Manifest{Metadata: metadata}}
+	// Task 2 is conditional on Go projects and would be skipped by the executor.
+	for _, i := range []int{1, 3} {
+		if err := plan[i].Execute(tc); err != nil {
+			t.Fatalf("%s failed: %v", plan[i].Description(), err)
+		}
+	}
+
+	for rel, want := range map[string]string{
+		"deploy/otel-collector.yaml":      `endpoint: "localhost:4317"`,
+		"deploy/dashboards/overview.json": `"acme_observability/dashboards/overview.json.tpl"`,
+	} {
+		//nolint:gosec // Test temp directory
+		content, err := os.ReadFile(filepath.Join(tmpDir, rel))
+		if err != nil {
+			t.Fatalf("%s not written: %v", rel, err)
+		}
+		if !strings.Contains(string(content), want) {
+			t.Errorf("%s = %q, want it to contain %q", rel, content, want)
+		}
+	}
+}
+
+func TestLoadTemplatePackage_Invalid(t *testing.T) {
+	for dir, want := range map[string]string{
+		"bad_band":    `unknown band "nope"`,
+		"unknown_key": "unknown keys",
+		"escape":      "escapes the target directory",
+	} {
+		_, err := LoadTemplatePackage(filepath.Join("testdata/invalid", dir))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: error = %v, want it to contain %q", dir, err, want)
+		}
+	}
+
+	if _, err := LoadTemplatePackage(filepath.Join("testdata/invalid", "escape")); err == nil ||
+		!strings.Contains(err.Error(), `"missing.tpl" not found`) {
+		t.Errorf("missing template should be reported alongside other errors, got %v", err)
+	}
+}
+
+func TestFindPackages(t *testing.T) {
+	dirs, err := FindPackages("testdata", TemplateManifestFile)
+	if err != nil {
+		t.Fatalf("FindPackages failed: %v", err)
+	}
+	if len(dirs) != 4 {
+		t.Fatalf("expected 4 package dirs, got %v", dirs)
+	}
+	if dirs[len(dirs)-1] != filepath.FromSlash(acmeDir) {
+		t.Errorf("expected %s to be found one level deep, got %v", acmeDir, dirs)
+	}
+
+	if dirs, err := FindPackages(filepath.Join(t.TempDir(), "missing"), TemplateManifestFile); err != nil || len(dirs) != 0 {
+		t.Errorf("missing root should yield nothing, got %v (err: %v)", dirs, err)
+	}
+}
@@ -0,0 +1,4 @@
+[package]
+name = "{{ .Project.Name }}"
+version = "0.1.0"
+edition = "{{ .Metadata.rust_edition }}"
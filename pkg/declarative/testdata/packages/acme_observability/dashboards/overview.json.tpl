@@ -0,0 +1 @@
+{"title": "{{ .Template }}"}
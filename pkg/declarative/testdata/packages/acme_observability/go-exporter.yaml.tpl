@@ -0,0 +1 @@
+exporter: go
@@ -0,0 +1,3 @@
+exporters:
+  otlp:
+    endpoint: {{ .Metadata.otel_endpoint | quote }}
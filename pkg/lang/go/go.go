@@ -6,27 +6,50 @@ package golang
 import (
 	"embed"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"scbake/internal/preflight"
 	"scbake/internal/types"
-	"scbake/internal/util"
 	"scbake/internal/util/fileutil"
 	"scbake/pkg/tasks"
 )
 
-//go:embed main.go.tpl gitignore.tpl schema.json
+//go:embed gitignore.tpl schema.json layouts
 var templates embed.FS
 
 // Handler implements the lang.Handler interface for Go projects.
 type Handler struct{}
 
 // SchemaFS returns the embedded filesystem containing schema.json.
-func (h *Handler) SchemaFS() embed.FS { return templates }
+func (h *Handler) SchemaFS() fs.FS { return templates }
 
 // SchemaPath returns the path to the embedded schema definition.
 func (h *Handler) SchemaPath() string { return "schema.json" }
 
-// GetTasks uses the sanitize utility to sanitize the module name and returns the execution plan.
+// Requirements returns the go command in at least the Go version of the
+// project: the go directive of an existing go.mod, otherwise go_version.
+// Older toolchains cannot build a module with a newer go directive.
+func (h *Handler) Requirements(targetPath string, metadata map[string]string) ([]preflight.Requirement, error) {
+	goVersion, err := existingGoVersion(targetPath)
+	if err != nil {
+		return nil, err
+	}
+	reason := "go directive in go.mod"
+	if goVersion == "" {
+		if goVersion, err = setting(metadata, "go_version"); err != nil {
+			return nil, err
+		}
+		reason = "go_version = " + goVersion
+	}
+	return []preflight.Requirement{
+		{Binary: "go", VersionArgs: []string{"version"}, Constraint: ">=" + goVersion, Reason: reason},
+	}, nil
+}
+
+// GetTasks returns the execution plan. The module path, Go version and
+// layout are read from the metadata (module_path, go_version, layout) when
+// the tasks execute.
 func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task, error) {
 	var plan []types.Task
 
@@ -47,20 +70,15 @@ func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task,
 		OutputPath:   fileutil.GitIgnore,
 		Desc:         "Create " + fileutil.GitIgnore,
 		TaskPrio:     int(p),
+		Schedule:     types.Schedule{Resources: []string{targetPath}},
 	})
 
-	// Task 2: Create main.go
+	// Task 2: Create the project skeleton of the selected layout
 	p, err = langSeq.Next()
 	if err != nil {
 		return nil, err
 	}
-	plan = append(plan, &tasks.CreateTemplateTask{
-		TemplateFS:   templates,
-		TemplatePath: "main.go.tpl",
-		OutputPath:   "main.go",
-		Desc:         "Create main.go",
-		TaskPrio:     int(p),
-	})
+	plan = append(plan, &layoutTask{prio: int(p), targetPath: targetPath})
 
 	// Idempotency Check
 	goModPath := filepath.Join(targetPath, "go.mod")
@@ -68,23 +86,12 @@ func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task,
 
 	if os.IsNotExist(checkErr) {
 		// --- Path 1: go.mod does NOT exist (Initialization) ---
-		moduleName, err := util.SanitizeModuleName(targetPath)
-		if err != nil {
-			return nil, fmt.Errorf("could not determine module name: %w", err)
-		}
-
-		// Task 3: Run 'go mod init'
+		// Task 3: Run 'go mod init' with module_path and go_version
 		p, err = langSeq.Next()
 		if err != nil {
 			return nil, err
 		}
-		plan = append(plan, &tasks.ExecCommandTask{
-			Cmd:         "go",
-			Args:        []string{"mod", "init", moduleName}, // Use sanitized name
-			Desc:        "Run go mod init " + moduleName,
-			TaskPrio:    int(p),
-			RunInTarget: true,
-		})
+		plan = append(plan, &modInitTask{prio: int(p), targetPath: targetPath})
 
 		// Task 4: Run 'go mod tidy'
 		p, err = langSeq.Next()
@@ -97,6 +104,7 @@ func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task,
 			Desc:        "Run go mod tidy",
 			TaskPrio:    int(p),
 			RunInTarget: true,
+			Schedule:    types.Schedule{Resources: []string{targetPath}},
 		})
 	} else if checkErr == nil {
 		// --- Path 2: go.mod *does* exist (Maintenance) ---
@@ -111,6 +119,7 @@ func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task,
 			Desc:        "Run go mod tidy (project exists)",
 			TaskPrio:    int(p),
 			RunInTarget: true,
+			Schedule:    types.Schedule{Resources: []string{targetPath}},
 		})
 	} else {
 		// --- Path 3: Some other error ---
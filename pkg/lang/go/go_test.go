@@ -4,8 +4,11 @@
 package golang
 
 import (
+	"context"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"scbake/internal/types"
@@ -20,7 +23,9 @@ func TestGetTasks_NewProject(t *testing.T) {
 	plan := getPlanOrFail(t, handler, tempDir)
 
 	assertPlanLength(t, plan, 4)
-	assertGoModInitTask(t, plan[2], filepath.Base(tempDir))
+	if _, ok := plan[2].(*modInitTask); !ok {
+		t.Fatalf("Task 3 should be modInitTask, got %T", plan[2])
+	}
 }
 
 func getPlanOrFail(t *testing.T, h *Handler, path string) []types.Task {
@@ -39,24 +44,6 @@ func assertPlanLength(t *testing.T, plan []types.Task, expected int) {
 	}
 }
 
-func assertGoModInitTask(t *testing.T, task types.Task, expectedModule string) {
-	t.Helper()
-
-	initTask, ok := task.(*tasks.ExecCommandTask)
-	if !ok {
-		t.Fatalf("Task should be ExecCommandTask")
-	}
-
-	if len(initTask.Args) < 3 || initTask.Args[1] != "init" {
-		t.Fatalf("Task should be 'go mod init', got: %v", initTask.Args)
-	}
-
-	if !contains(initTask.Args, expectedModule) {
-		t.Fatalf("Expected module name '%s' not found in args %v",
-			expectedModule, initTask.Args)
-	}
-}
-
 // TestGetTasks_ExistingProject validates the maintenance path when go.mod exists.
 func TestGetTasks_ExistingProject(t *testing.T) {
 	tempDir := t.TempDir()
@@ -81,6 +68,9 @@ func assertNoGoModInit(t *testing.T, plan []types.Task) {
 	t.Helper()
 
 	for _, task := range plan {
+		if _, ok := task.(*modInitTask); ok {
+			t.Fatal("Plan should not contain 'go mod init' for an existing project")
+		}
 		if exec, ok := task.(*tasks.ExecCommandTask); ok {
 			if contains(exec.Args, "init") {
 				t.Fatal("Plan should not contain 'go mod init' for an existing project")
@@ -115,3 +105,130 @@ func contains(slice []string, val string) bool {
 	}
 	return false
 }
+
+// TestModuleSettings checks the precedence of go.mod, metadata and defaults.
+func TestModuleSettings(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Billing Service")
+	if err := os.Mkdir(dir, 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	module, goVersion, err := moduleSettings(nil, dir)
+	if err != nil || module != "billing-service" || goVersion != "1.22" {
+		t.Errorf("defaults = %q, %q, %v; want billing-service, 1.22", module, goVersion, err)
+	}
+
+	metadata := map[string]string{"module_path": "github.com/acme/billing", "go_version": "1.23"}
+	module, goVersion, _ = moduleSettings(metadata, dir)
+	if module != "github.com/acme/billing" || goVersion != "1.23" {
+		t.Errorf("from metadata = %q, %q", module, goVersion)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/legacy\n\ngo 1.21\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if module, _, _ = moduleSettings(metadata, dir); module != "example.com/legacy" {
+		t.Errorf("an existing go.mod should win, got %q", module)
+	}
+}
+
+// TestGetTasks_Layouts renders every layout for a new module and checks
+// go.mod, the generated files and their imports. Outside -short mode, layouts
+// without external dependencies must also pass go vet and go test.
+func TestGetTasks_Layouts(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go binary not available")
+	}
+
+	layouts := map[string][]string{
+		"cli":          {"cmd/billing-api/main.go", "internal/cli/cli.go", "internal/cli/cli_test.go"},
+		"http-service": {"cmd/billing-api/main.go", "internal/config/config.go", "internal/server/server_test.go", "pkg/health/health.go"},
+		"grpc-service": {"cmd/billing-api/main.go", "internal/server/server.go", "api/billingapi/v1/billingapi.proto"},
+		"library":      {"doc.go", "billingapi.go", "billingapi_test.go", "example_test.go"},
+	}
+	for layout, files := range layouts {
+		t.Run(layout, func(t *testing.T) {
+			dir := t.TempDir()
+			plan := getPlanOrFail(t, &Handler{}, dir)
+			tc := types.TaskContext{
+				Ctx:        context.Background(),
+				TargetPath: dir,
+				Manifest: &types.Manifest{Metadata: map[string]string{
+					"module_path": "github.com/acme/billing-api/v2",
+					"go_version":  "1.22",
+					"layout":      layout,
+				}},
+			}
+			// Everything but 'go mod tidy', which may need the network.
+			for _, task := range plan[:3] {
+				if err := task.Execute(tc); err != nil {
+					t.Fatalf("%s failed: %v", task.Description(), err)
+				}
+			}
+
+			//nolint:gosec // Test temp directory
+			goMod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{"module github.com/acme/billing-api/v2", "go 1.22", "toolchain go1.22.0"} {
+				if !strings.Contains(string(goMod), want) {
+					t.Errorf("go.mod missing %q:\n%s", want, goMod)
+				}
+			}
+			for _, f := range files {
+				if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+					t.Errorf("%s not generated: %v", f, err)
+				}
+			}
+
+			if layout == "grpc-service" || testing.Short() {
+				return
+			}
+			for _, args := range [][]string{{"vet", "./..."}, {"test", "./..."}} {
+				cmd := exec.Command("go", args...)
+				cmd.Dir = dir
+				cmd.Env = append(os.Environ(), "GOTOOLCHAIN=local", "GOFLAGS=-mod=mod")
+				if out, err := cmd.CombinedOutput(); err != nil {
+					t.Errorf("go %s failed: %v\n%s", strings.Join(args, " "), err, out)
+				}
+			}
+		})
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	tests := map[string]string{
+		"github.com/acme/billing-api":    "billingapi",
+		"github.com/acme/billing-api/v2": "billingapi",
+		"Acme_Tools":                     "acmetools",
+		"example.com/3d":                 "lib3d",
+	}
+	for module, want := range tests {
+		if got := packageName(moduleName(module)); got != want {
+			t.Errorf("packageName(moduleName(%q)) = %q, want %q", module, got, want)
+		}
+	}
+}
+
+// TestRequirements checks that the go directive of an existing go.mod wins
+// over go_version as the minimum Go version.
+func TestRequirements(t *testing.T) {
+	dir := t.TempDir()
+	h := &Handler{}
+
+	reqs, err := h.Requirements(dir, map[string]string{"go_version": "1.23"})
+	if err != nil || len(reqs) != 1 || reqs[0].Binary != "go" || reqs[0].Constraint != ">=1.23" {
+		t.Fatalf("Unexpected requirements for a new module: %+v, %v", reqs, err)
+	}
+	if reqs, _ = h.Requirements(dir, nil); reqs[0].Constraint != ">=1.22" {
+		t.Errorf("Expected the schema default, got %q", reqs[0].Constraint)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/legacy\n\ngo 1.21\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if reqs, _ = h.Requirements(dir, map[string]string{"go_version": "1.23"}); reqs[0].Constraint != ">=1.21" {
+		t.Errorf("The go directive of go.mod should win, got %q", reqs[0].Constraint)
+	}
+}
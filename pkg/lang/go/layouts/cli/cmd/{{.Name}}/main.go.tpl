@@ -0,0 +1,12 @@
+// Command {{ .Name }} is the entry point of the {{ .Module }} command line tool.
+package main
+
+import (
+	"os"
+
+	"{{ .Module }}/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+}
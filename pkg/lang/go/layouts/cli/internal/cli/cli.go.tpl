@@ -0,0 +1,30 @@
+// Package cli implements the {{ .Name }} command line.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+)
+
+// Version is printed by -version. Set it at build time with
+// -ldflags "-X {{ .Module }}/internal/cli.Version=v1.0.0".
+var Version = "dev"
+
+// Run parses args, executes the command and returns the process exit code.
+func Run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("{{ .Name }}", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	name := flags.String("name", "world", "who to greet")
+	version := flags.Bool("version", false, "print the version and exit")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *version {
+		fmt.Fprintln(stdout, Version)
+		return 0
+	}
+	fmt.Fprintf(stdout, "Hello, %s!\n", *name)
+	return 0
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+		want string
+	}{
+		{args: nil, code: 0, want: "Hello, world!\n"},
+		{args: []string{"-name", "gopher"}, code: 0, want: "Hello, gopher!\n"},
+		{args: []string{"-version"}, code: 0, want: Version + "\n"},
+		{args: []string{"-unknown"}, code: 2, want: ""},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		if code := Run(tt.args, &stdout, &stderr); code != tt.code || stdout.String() != tt.want {
+			t.Errorf("Run(%q) = %d, %q; want %d, %q", tt.args, code, stdout.String(), tt.code, tt.want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+// API definition of {{ .Name }}. Generate the Go code with protoc (or buf):
+//
+//   protoc --go_out=. --go_opt=module={{ .Module }} \
+//          --go-grpc_out=. --go-grpc_opt=module={{ .Module }} \
+//          api/{{ .Package }}/v1/{{ .Package }}.proto
+//
+// and register the service in internal/server.New.
+syntax = "proto3";
+
+package {{ .Package }}.v1;
+
+option go_package = "{{ .Module }}/api/{{ .Package }}/v1;{{ .Package }}v1";
+
+service {{ pascalCase .Name }}Service {
+  rpc Ping(PingRequest) returns (PingResponse);
+}
+
+message PingRequest {}
+
+message PingResponse {
+  string message = 1;
+}
@@ -0,0 +1,36 @@
+// Package config loads the service configuration from the environment.
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Config is the service configuration.
+type Config struct {
+	// Addr is the listen address (ADDR, default ":50051").
+	Addr string
+	// ShutdownTimeout bounds the graceful shutdown (SHUTDOWN_TIMEOUT, default 10s).
+	ShutdownTimeout time.Duration
+}
+
+// Load reads the configuration from environment variables.
+func Load() (Config, error) {
+	return load(os.Getenv)
+}
+
+func load(getenv func(string) string) (Config, error) {
+	cfg := Config{Addr: ":50051", ShutdownTimeout: 10 * time.Second}
+	if v := getenv("ADDR"); v != "" {
+		cfg.Addr = v
+	}
+	if v := getenv("SHUTDOWN_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("SHUTDOWN_TIMEOUT: %w", err)
+		}
+		cfg.ShutdownTimeout = d
+	}
+	return cfg, nil
+}
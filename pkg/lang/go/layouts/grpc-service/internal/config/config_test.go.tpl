@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	env := map[string]string{}
+	getenv := func(key string) string { return env[key] }
+
+	cfg, err := load(getenv)
+	if err != nil || cfg.Addr != ":50051" || cfg.ShutdownTimeout != 10*time.Second {
+		t.Fatalf("defaults = %+v, %v", cfg, err)
+	}
+
+	env["ADDR"] = ":9090"
+	env["SHUTDOWN_TIMEOUT"] = "3s"
+	cfg, err = load(getenv)
+	if err != nil || cfg.Addr != ":9090" || cfg.ShutdownTimeout != 3*time.Second {
+		t.Fatalf("from the environment = %+v, %v", cfg, err)
+	}
+
+	env["SHUTDOWN_TIMEOUT"] = "soon"
+	if _, err := load(getenv); err == nil {
+		t.Error("expected an error for an invalid SHUTDOWN_TIMEOUT")
+	}
+}
@@ -0,0 +1,69 @@
+// Package server sets up the gRPC server and runs it.
+package server
+
+import (
+	"context"
+	"log"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"{{ .Module }}/internal/config"
+)
+
+// New returns a gRPC server with the standard health and reflection
+// services registered. Register the services generated from api/ here.
+func New() *grpc.Server {
+	srv := grpc.NewServer()
+
+	hs := health.NewServer()
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, hs)
+	reflection.Register(srv)
+
+	return srv
+}
+
+// Run listens on cfg.Addr and serves until ctx is cancelled.
+func Run(ctx context.Context, cfg config.Config, logger *log.Logger) error {
+	ln, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		return err
+	}
+	return Serve(ctx, ln, cfg, logger)
+}
+
+// Serve serves on ln until ctx is cancelled, then stops gracefully, waiting
+// at most cfg.ShutdownTimeout for open RPCs before closing them.
+func Serve(ctx context.Context, ln net.Listener, cfg config.Config, logger *log.Logger) error {
+	srv := New()
+
+	errc := make(chan error, 1)
+	go func() {
+		logger.Printf("listening on %s", ln.Addr())
+		errc <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Print("shutting down")
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(cfg.ShutdownTimeout):
+		srv.Stop()
+	}
+	return <-errc
+}
@@ -0,0 +1,33 @@
+// Command {{ .Name }} runs the {{ .Module }} HTTP service.
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"{{ .Module }}/internal/config"
+	"{{ .Module }}/internal/server"
+)
+
+func main() {
+	logger := log.New(os.Stderr, "{{ .Name }}: ", log.LstdFlags)
+	if err := run(logger); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+// run loads the configuration and serves until SIGINT or SIGTERM.
+func run(logger *log.Logger) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return server.Run(ctx, cfg, logger)
+}
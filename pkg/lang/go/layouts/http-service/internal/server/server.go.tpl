@@ -0,0 +1,59 @@
+// Package server wires the HTTP routes and runs the server.
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"{{ .Module }}/internal/config"
+	"{{ .Module }}/pkg/health"
+)
+
+// NewHandler returns the service's routes.
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", health.Handler())
+	return mux
+}
+
+// Run listens on cfg.Addr and serves until ctx is cancelled.
+func Run(ctx context.Context, cfg config.Config, logger *log.Logger) error {
+	ln, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		return err
+	}
+	return Serve(ctx, ln, cfg, logger)
+}
+
+// Serve serves on ln until ctx is cancelled, then shuts down gracefully,
+// waiting at most cfg.ShutdownTimeout for open requests.
+func Serve(ctx context.Context, ln net.Listener, cfg config.Config, logger *log.Logger) error {
+	srv := &http.Server{Handler: NewHandler(), ReadHeaderTimeout: 5 * time.Second}
+
+	errc := make(chan error, 1)
+	go func() {
+		logger.Printf("listening on %s", ln.Addr())
+		errc <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Print("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
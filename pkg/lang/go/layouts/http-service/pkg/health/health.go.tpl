@@ -0,0 +1,15 @@
+// Package health provides an HTTP health check endpoint.
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Handler reports that the service is up.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	})
+}
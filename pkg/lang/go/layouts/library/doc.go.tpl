@@ -0,0 +1,6 @@
+// Package {{ .Package }} is the {{ .Module }} library.
+//
+// Import it with:
+//
+//	import "{{ .Module }}"
+package {{ .Package }}
@@ -0,0 +1,12 @@
+package {{ .Package }}_test
+
+import (
+	"fmt"
+
+	"{{ .Module }}"
+)
+
+func ExampleGreet() {
+	fmt.Println({{ .Package }}.Greet("gopher"))
+	// Output: Hello, gopher!
+}
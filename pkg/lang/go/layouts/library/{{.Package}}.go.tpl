@@ -0,0 +1,11 @@
+package {{ .Package }}
+
+import "fmt"
+
+// Greet returns a greeting for name.
+func Greet(name string) string {
+	if name == "" {
+		name = "world"
+	}
+	return fmt.Sprintf("Hello, %s!", name)
+}
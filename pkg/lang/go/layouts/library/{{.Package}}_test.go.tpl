@@ -0,0 +1,15 @@
+package {{ .Package }}
+
+import "testing"
+
+func TestGreet(t *testing.T) {
+	tests := map[string]string{
+		"":       "Hello, world!",
+		"gopher": "Hello, gopher!",
+	}
+	for name, want := range tests {
+		if got := Greet(name); got != want {
+			t.Errorf("Greet(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -1,8 +0,0 @@
-// Package main is the entry point for the application.
-package main
-
-import "fmt"
-
-func main() {
-	fmt.Println("Hello from scbake!")
-}
\ No newline at end of file
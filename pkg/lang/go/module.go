@@ -0,0 +1,225 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package golang
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"scbake/internal/schema"
+	"scbake/internal/types"
+	"scbake/internal/util"
+	"scbake/pkg/tasks"
+	"strconv"
+	"strings"
+)
+
+// toolchainSince is the first Go minor version that understands the
+// toolchain directive.
+const toolchainSince = 21
+
+// majorVersion matches the major version suffix of a module path.
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// templateData is what the Go pack's templates are rendered with: the
+// standard template context plus the resolved module settings.
+type templateData struct {
+	*tasks.TemplateContext
+	Module    string // Module path, e.g. github.com/acme/billing-api.
+	GoVersion string // Go version, e.g. 1.22.
+	Name      string // Last module path element without a major version, e.g. billing-api.
+	Package   string // Name as a Go package name, e.g. billingapi.
+}
+
+// newTemplateData extends ctx with the module settings (see moduleSettings).
+func newTemplateData(ctx *tasks.TemplateContext) (interface{}, error) {
+	module, goVersion, err := moduleSettings(ctx.Metadata, ctx.TargetPath)
+	if err != nil {
+		return nil, err
+	}
+	name := moduleName(module)
+	return &templateData{
+		TemplateContext: ctx,
+		Module:          module,
+		GoVersion:       goVersion,
+		Name:            name,
+		Package:         packageName(name),
+	}, nil
+}
+
+// moduleName returns the last element of a module path, skipping a major
+// version suffix such as /v2.
+func moduleName(module string) string {
+	elems := strings.Split(module, "/")
+	name := elems[len(elems)-1]
+	if majorVersion.MatchString(name) && len(elems) > 1 {
+		name = elems[len(elems)-2]
+	}
+	return name
+}
+
+// packageName turns name into a valid Go package name by keeping only
+// lowercase letters and digits.
+func packageName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	pkg := b.String()
+	if pkg == "" || (pkg[0] >= '0' && pkg[0] <= '9') {
+		pkg = "lib" + pkg
+	}
+	return pkg
+}
+
+// moduleSettings resolves the module path and Go version. The module path
+// of an existing go.mod wins, so generated imports match it; otherwise the
+// validated metadata is used, falling back to the directory name and the
+// schema default.
+func moduleSettings(metadata map[string]string, targetPath string) (string, string, error) {
+	module, err := existingModule(targetPath)
+	if err != nil {
+		return "", "", err
+	}
+	if module == "" {
+		module = metadata["module_path"]
+	}
+	if module == "" {
+		name, err := util.SanitizeModuleName(targetPath)
+		if err != nil {
+			return "", "", fmt.Errorf("could not determine module name: %w", err)
+		}
+		module = name
+	}
+
+	goVersion, err := setting(metadata, "go_version")
+	if err != nil {
+		return "", "", err
+	}
+	return module, goVersion, nil
+}
+
+// setting returns a metadata value, falling back to its schema default.
+func setting(metadata map[string]string, name string) (string, error) {
+	if v := metadata[name]; v != "" {
+		return v, nil
+	}
+	return schemaDefault(name)
+}
+
+// existingModule returns the module path declared in targetPath/go.mod, or
+// "" if there is no go.mod.
+func existingModule(targetPath string) (string, error) {
+	return goModDirective(targetPath, "module")
+}
+
+// existingGoVersion returns the go directive of targetPath/go.mod, or "" if
+// there is no go.mod or it has no go directive.
+func existingGoVersion(targetPath string) (string, error) {
+	return goModDirective(targetPath, "go")
+}
+
+// goModDirective returns the argument of the first name directive in
+// targetPath/go.mod, or "" if there is none.
+func goModDirective(targetPath, name string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(targetPath, "go.mod"))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not read go.mod: %w", err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), name+" "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`), nil
+		}
+	}
+	return "", nil
+}
+
+// schemaDefault reads a variable's default from the embedded schema, which
+// stays the single source of truth.
+func schemaDefault(name string) (string, error) {
+	s, err := schema.ReadSchema(templates, "schema.json")
+	if err != nil {
+		return "", err
+	}
+	def, ok := s.Variables[name]
+	if !ok || def.Default == nil {
+		return "", fmt.Errorf("schema.json declares no %s default", name)
+	}
+	return *def.Default, nil
+}
+
+// modInitTask runs `go mod init` and pins the go and toolchain directives.
+// The module path and Go version come from the validated metadata, which is
+// only known when the plan executes.
+type modInitTask struct {
+	prio       int
+	targetPath string
+}
+
+func (t *modInitTask) Description() string { return "Run go mod init" }
+func (t *modInitTask) Priority() int       { return t.prio }
+func (t *modInitTask) Scheduling() types.Schedule {
+	return types.Schedule{Resources: []string{t.targetPath}}
+}
+
+func (t *modInitTask) Execute(tc types.TaskContext) error {
+	module, goVersion, err := moduleSettings(tc.Metadata(), tc.TargetPath)
+	if err != nil {
+		return err
+	}
+
+	edit := []string{"mod", "edit", "-go=" + goVersion}
+	if minor, err := strconv.Atoi(strings.TrimPrefix(goVersion, "1.")); err == nil && minor >= toolchainSince {
+		edit = append(edit, "-toolchain=go"+goVersion+".0")
+	}
+
+	steps := []*tasks.ExecCommandTask{
+		{Cmd: "go", Args: []string{"mod", "init", module}, RunInTarget: true, PredictedCreated: []string{"go.mod"}},
+		{Cmd: "go", Args: edit, RunInTarget: true},
+	}
+	for _, step := range steps {
+		if err := step.Execute(tc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// layoutTask renders the project skeleton of the layout selected by the
+// layout variable from layouts/<layout>. Every file resolves through
+// --template-dir and the registry cache (layouts/<layout>/<path>) before the
+// embedded default, so single files can be overridden.
+type layoutTask struct {
+	prio       int
+	targetPath string
+}
+
+func (t *layoutTask) Description() string { return "Create Go project layout" }
+func (t *layoutTask) Priority() int       { return t.prio }
+func (t *layoutTask) Scheduling() types.Schedule {
+	return types.Schedule{Resources: []string{t.targetPath}}
+}
+
+func (t *layoutTask) Execute(tc types.TaskContext) error {
+	layout, err := setting(tc.Metadata(), "layout")
+	if err != nil {
+		return err
+	}
+
+	tree := &tasks.CreateTreeTask{
+		TemplateFS:  templates,
+		Root:        path.Join("layouts", layout),
+		Desc:        "Create " + layout + " layout",
+		TaskPrio:    t.prio,
+		ContextData: newTemplateData,
+	}
+	return tree.Execute(tc)
+}
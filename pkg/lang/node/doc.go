@@ -0,0 +1,2 @@
+// Package node provides the task handler for initializing TypeScript Node.js projects.
+package node
@@ -0,0 +1,134 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package node
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"scbake/internal/preflight"
+	"scbake/internal/types"
+	"scbake/pkg/tasks"
+)
+
+//go:embed schema.json all:project
+var templates embed.FS
+
+// Handler implements the lang.Handler interface for TypeScript Node.js projects.
+type Handler struct{}
+
+// SchemaFS returns the embedded filesystem containing schema.json.
+func (h *Handler) SchemaFS() fs.FS { return templates }
+
+// SchemaPath returns the path to the embedded schema definition.
+func (h *Handler) SchemaPath() string { return "schema.json" }
+
+// BuildTool returns the package manager of the project at targetPath: the
+// one whose lockfile exists, otherwise the package_manager variable.
+func (h *Handler) BuildTool(targetPath string, metadata map[string]string) (string, error) {
+	for _, pm := range []string{pmPnpm, pmYarn, pmNpm} {
+		_, err := os.Stat(filepath.Join(targetPath, lockfiles[pm]))
+		if err == nil {
+			return pm, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", fmt.Errorf("could not check for %s: %w", lockfiles[pm], err)
+		}
+	}
+	return setting(metadata, "package_manager")
+}
+
+// Requirements returns Node.js in at least node_version and the project's
+// package manager when they run: for node_install on a new project. The project
+// is rendered without them.
+func (h *Handler) Requirements(targetPath string, metadata map[string]string) ([]preflight.Requirement, error) {
+	_, err := os.Stat(filepath.Join(targetPath, "package.json"))
+	if err == nil {
+		return nil, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("could not check for package.json: %w", err)
+	}
+
+	install, err := setting(metadata, "node_install")
+	if err != nil || install != "true" {
+		return nil, err
+	}
+	nodeVersion, err := setting(metadata, "node_version")
+	if err != nil {
+		return nil, err
+	}
+	pm, err := h.BuildTool(targetPath, metadata)
+	if err != nil {
+		return nil, err
+	}
+	return []preflight.Requirement{
+		{Binary: "node", Constraint: ">=" + nodeVersion, Reason: "node_install = true, node_version = " + nodeVersion},
+		{Binary: pm, Reason: "node_install = true"},
+	}, nil
+}
+
+// GetTasks returns the execution plan. The package name, package manager,
+// module type and Node.js version and the node_install switch are read from the
+// metadata when the tasks execute.
+func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task, error) {
+	var plan []types.Task
+
+	// Initialize sequences
+	dirSeq, err := types.NewPrioritySequence(types.PrioDirCreate, types.MaxDirCreate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create priority sequence: %w", err)
+	}
+	langSeq, err := types.NewPrioritySequence(types.PrioLangSetup, types.MaxLangSetup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create priority sequence: %w", err)
+	}
+
+	// Task 0: Ensure target directory exists (always needed)
+	p, err := dirSeq.Next()
+	if err != nil {
+		return nil, err
+	}
+	plan = append(plan, &tasks.CreateDirTask{
+		Path:     targetPath,
+		Desc:     fmt.Sprintf("Create project directory '%s'", targetPath),
+		TaskPrio: int(p), // Now 50
+		Schedule: types.Schedule{Resources: []string{targetPath}},
+	})
+
+	// Idempotency Check: Check for existence of package.json
+	_, checkErr := os.Stat(filepath.Join(targetPath, "package.json"))
+
+	if os.IsNotExist(checkErr) {
+		// --- Path 1: package.json does NOT exist (Initialization) ---
+		// Task 1: Create package.json, tsconfig.json, src, test and .gitignore
+		p, err := langSeq.Next()
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, &tasks.CreateTreeTask{
+			TemplateFS:  templates,
+			Root:        "project",
+			Desc:        "Create TypeScript project layout",
+			TaskPrio:    int(p), // Now 100
+			ContextData: newTemplateData,
+			Schedule:    types.Schedule{Resources: []string{targetPath}},
+		})
+
+		// Task 2: Optionally install the dependencies
+		p, err = langSeq.Next()
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, &installTask{prio: int(p), targetPath: targetPath}) // Now 101
+	} else if checkErr != nil {
+		// --- Path 2 (package.json exists) leaves the plan with the CreateDirTask only.
+		// --- Path 3: Some other error ---
+		return nil, fmt.Errorf("could not check for package.json: %w", checkErr)
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"scbake/internal/types"
+	"scbake/pkg/tasks"
+	"strings"
+	"testing"
+)
+
+// TestGetTasks_NewNodeProject validates the initialization sequence.
+func TestGetTasks_NewNodeProject(t *testing.T) {
+	targetPath := filepath.Join(t.TempDir(), "billing-api")
+
+	plan, err := (&Handler{}).GetTasks(targetPath, "", "")
+	if err != nil {
+		t.Fatalf("Failed to get tasks: %v", err)
+	}
+	if len(plan) != 3 {
+		t.Fatalf("Expected 3 tasks, got %d", len(plan))
+	}
+	if dirTask, ok := plan[0].(*tasks.CreateDirTask); !ok || dirTask.Path != targetPath {
+		t.Fatal("First task should be directory creation for the target path")
+	}
+	if tree, ok := plan[1].(*tasks.CreateTreeTask); !ok || tree.Root != "project" {
+		t.Fatalf("Second task should render the project tree, got %T", plan[1])
+	}
+	install, ok := plan[2].(*installTask)
+	if !ok {
+		t.Fatalf("Third task should install the dependencies, got %T", plan[2])
+	}
+
+	// Installing needs the network, so it is opt-in.
+	for value, want := range map[string]bool{"": false, "false": false, "true": true} {
+		m := &types.Manifest{Metadata: map[string]string{"node_install": value}}
+		got, err := tasks.EvalCondition(install.Condition(), m)
+		if err != nil {
+			t.Fatalf("EvalCondition failed: %v", err)
+		}
+		if got != want {
+			t.Errorf("node_install=%q: got %v, want %v", value, got, want)
+		}
+	}
+
+	if plan[1].Priority() != int(types.PrioLangSetup) || plan[2].Priority() != int(types.PrioLangSetup)+1 {
+		t.Errorf("Unexpected priorities: %d, %d", plan[1].Priority(), plan[2].Priority())
+	}
+}
+
+// TestGetTasks_ExistingNodeProject validates idempotency.
+func TestGetTasks_ExistingNodeProject(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "package.json"), []byte("{}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := (&Handler{}).GetTasks(tempDir, "", "")
+	if err != nil {
+		t.Fatalf("Failed to get tasks: %v", err)
+	}
+	if len(plan) != 1 {
+		t.Errorf("Expected only 1 task for existing project, got %d", len(plan))
+	}
+}
+
+// TestProjectLayout renders the project and checks package.json and the sources.
+func TestProjectLayout(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Billing API")
+	plan, err := (&Handler{}).GetTasks(dir, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc := types.TaskContext{
+		Ctx:        context.Background(),
+		TargetPath: dir,
+		Manifest: &types.Manifest{Metadata: map[string]string{
+			"module_type":         "commonjs",
+			"node_version":        "22",
+			"project_description": `Billing "core"`,
+		}},
+		Project: &types.Project{Name: "billing", Language: "node", BuildTool: "pnpm"},
+	}
+	if err := plan[1].Execute(tc); err != nil {
+		t.Fatal(err)
+	}
+
+	var pkg struct {
+		Name            string            `json:"name"`
+		Description     string            `json:"description"`
+		Type            string            `json:"type"`
+		Engines         map[string]string `json:"engines"`
+		Scripts         map[string]string `json:"scripts"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal([]byte(readFile(t, filepath.Join(dir, "package.json"))), &pkg); err != nil {
+		t.Fatalf("package.json is not valid JSON: %v", err)
+	}
+	if pkg.Name != "billing-api" || pkg.Description != `Billing "core"` || pkg.Type != "commonjs" ||
+		pkg.Engines["node"] != ">=22" || pkg.DevDependencies["@types/node"] != "^22.0.0" {
+		t.Errorf("Unexpected package.json: %+v", pkg)
+	}
+	for _, script := range []string{"build", "start", "test", "lint"} {
+		if pkg.Scripts[script] == "" {
+			t.Errorf("package.json has no %s script", script)
+		}
+	}
+
+	var tsconfig map[string]interface{}
+	if err := json.Unmarshal([]byte(readFile(t, filepath.Join(dir, "tsconfig.json"))), &tsconfig); err != nil {
+		t.Fatalf("tsconfig.json is not valid JSON: %v", err)
+	}
+	if index := readFile(t, filepath.Join(dir, "src", "index.ts")); !strings.Contains(index, `greet("billing-api")`) {
+		t.Errorf("Unexpected src/index.ts:\n%s", index)
+	}
+	readFile(t, filepath.Join(dir, "src", "greet.ts"))
+	readFile(t, filepath.Join(dir, "test", "greet.test.ts"))
+	// The package manager recorded for the project wins over the metadata.
+	if gitignore := readFile(t, filepath.Join(dir, ".gitignore")); !strings.Contains(gitignore, "pnpm-debug.log*") {
+		t.Errorf(".gitignore should contain the pnpm entries:\n%s", gitignore)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path) //nolint:gosec // Test reads files it generated
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// TestBuildTool checks that an existing lockfile wins over the package_manager variable.
+func TestBuildTool(t *testing.T) {
+	dir := t.TempDir()
+	h := &Handler{}
+
+	for metadata, want := range map[string]string{"": pmNpm, pmYarn: pmYarn} {
+		got, err := h.BuildTool(dir, map[string]string{"package_manager": metadata})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("package_manager=%q: got %q, want %q", metadata, got, want)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, lockfiles[pmPnpm]), []byte("lockfileVersion: '9.0'\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := h.BuildTool(dir, map[string]string{"package_manager": pmYarn}); err != nil || got != pmPnpm {
+		t.Errorf("Existing pnpm-lock.yaml: got %q, %v", got, err)
+	}
+}
+
+// TestPackageName checks the derivation of package names from directory names.
+func TestPackageName(t *testing.T) {
+	for dir, want := range map[string]string{
+		"billing":     "billing",
+		"billing-api": "billing-api",
+		"my tool":     "my-tool",
+		"_private":    "private",
+		"...":         "app",
+	} {
+		if got := packageName(dir); got != want {
+			t.Errorf("packageName(%q) = %q, want %q", dir, got, want)
+		}
+	}
+}
+
+// TestRequirements checks that Node.js and the package manager are only
+// required for node_install.
+func TestRequirements(t *testing.T) {
+	dir := t.TempDir()
+	h := &Handler{}
+
+	if reqs, err := h.Requirements(dir, nil); err != nil || len(reqs) != 0 {
+		t.Errorf("Expected no requirements without node_install, got %+v, %v", reqs, err)
+	}
+
+	reqs, err := h.Requirements(dir, map[string]string{"node_install": "true", "node_version": "22", "package_manager": pmPnpm})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 2 || reqs[0].Binary != "node" || reqs[0].Constraint != ">=22" || reqs[1].Binary != pmPnpm {
+		t.Errorf("Unexpected requirements: %+v", reqs)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package node
+
+import (
+	"fmt"
+	"scbake/internal/schema"
+	"scbake/internal/types"
+	"scbake/internal/util"
+	"scbake/pkg/tasks"
+	"strings"
+)
+
+// Package managers (the package_manager variable), recorded per project in
+// scbake.toml as its build tool.
+const (
+	pmNpm  = "npm"
+	pmPnpm = "pnpm"
+	pmYarn = "yarn"
+)
+
+// lockfiles are the files that mark an existing project of each package manager.
+var lockfiles = map[string]string{
+	pmNpm:  "package-lock.json",
+	pmPnpm: "pnpm-lock.yaml",
+	pmYarn: "yarn.lock",
+}
+
+// templateData is what the Node.js pack's templates are rendered with: the
+// standard template context plus the resolved project settings.
+type templateData struct {
+	*tasks.TemplateContext
+	Name           string // Package name, e.g. billing-api.
+	Description    string // One-line description.
+	PackageManager string // npm, pnpm or yarn.
+	ModuleType     string // module or commonjs.
+	NodeVersion    string // Minimum Node.js major version, e.g. 20.
+}
+
+// newTemplateData extends ctx with the settings from the validated metadata,
+// falling back to the directory name and the schema defaults.
+func newTemplateData(ctx *tasks.TemplateContext) (interface{}, error) {
+	metadata := ctx.Metadata
+
+	name := metadata["npm_package_name"]
+	if name == "" {
+		dir, err := util.SanitizeModuleName(ctx.TargetPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not determine package name: %w", err)
+		}
+		name = packageName(dir)
+	}
+
+	d := &templateData{TemplateContext: ctx, Name: name, Description: metadata["project_description"]}
+	for name, dst := range map[string]*string{
+		"package_manager": &d.PackageManager,
+		"module_type":     &d.ModuleType,
+		"node_version":    &d.NodeVersion,
+	} {
+		v, err := setting(metadata, name)
+		if err != nil {
+			return nil, err
+		}
+		*dst = v
+	}
+	// The package manager recorded for the project at plan time (see Handler.BuildTool) wins.
+	if ctx.Project != nil && ctx.Project.BuildTool != "" {
+		d.PackageManager = ctx.Project.BuildTool
+	}
+	return d, nil
+}
+
+// packageName turns a directory name into a valid npm package name: every
+// other character than lowercase letters, digits, "-", "." and "_" becomes
+// a hyphen, and a leading "." or "_" is dropped.
+func packageName(dir string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(dir) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '.' || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+	name := strings.TrimLeft(b.String(), "._")
+	if name == "" {
+		name = "app"
+	}
+	return name
+}
+
+// setting returns a metadata value, falling back to its schema default.
+func setting(metadata map[string]string, name string) (string, error) {
+	if v := metadata[name]; v != "" {
+		return v, nil
+	}
+	s, err := schema.ReadSchema(templates, "schema.json")
+	if err != nil {
+		return "", err
+	}
+	def, ok := s.Variables[name]
+	if !ok || def.Default == nil {
+		return "", fmt.Errorf("schema.json declares no %s default", name)
+	}
+	return *def.Default, nil
+}
+
+// installTask installs the dependencies with the project's package manager
+// when node_install is set. The package manager is only required then (see
+// Handler.Requirements).
+type installTask struct {
+	prio       int
+	targetPath string
+}
+
+func (t *installTask) Description() string { return "Install dependencies" }
+func (t *installTask) Priority() int       { return t.prio }
+func (t *installTask) Condition() string   { return `eq .Metadata.node_install "true"` }
+func (t *installTask) Scheduling() types.Schedule {
+	return types.Schedule{Resources: []string{t.targetPath}}
+}
+
+func (t *installTask) Execute(tc types.TaskContext) error {
+	if tc.DryRun {
+		return nil
+	}
+	pm, err := setting(tc.Metadata(), "package_manager")
+	if err != nil {
+		return err
+	}
+	if tc.Project != nil && tc.Project.BuildTool != "" {
+		pm = tc.Project.BuildTool
+	}
+	if _, ok := lockfiles[pm]; !ok {
+		return fmt.Errorf("unknown package manager %q (expected %s, %s or %s)", pm, pmNpm, pmPnpm, pmYarn)
+	}
+
+	cmd := &tasks.ExecCommandTask{
+		Cmd:              pm,
+		Args:             []string{"install"},
+		RunInTarget:      true,
+		PredictedCreated: []string{"node_modules", lockfiles[pm]},
+	}
+	return cmd.Execute(tc)
+}
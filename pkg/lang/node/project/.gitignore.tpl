@@ -0,0 +1,30 @@
+# Dependencies
+node_modules/
+{{- if eq .PackageManager "yarn"}}
+.pnp.*
+.yarn/*
+!.yarn/releases
+{{- end}}
+
+# Build output
+dist/
+*.tsbuildinfo
+
+# Logs
+npm-debug.log*
+{{- if eq .PackageManager "pnpm"}}
+pnpm-debug.log*
+{{- else if eq .PackageManager "yarn"}}
+yarn-error.log
+{{- end}}
+
+# Environment
+.env
+.env.*
+
+# IDEs
+.idea/
+.vscode/
+
+# OS
+.DS_Store
@@ -0,0 +1,24 @@
+{
+  "name": {{toJson .Name}},
+  "version": "0.1.0",
+{{- with .Description}}
+  "description": {{toJson .}},
+{{- end}}
+  "private": true,
+  "type": "{{.ModuleType}}",
+  "main": "dist/index.js",
+  "engines": {
+    "node": ">={{.NodeVersion}}"
+  },
+  "scripts": {
+    "build": "tsc",
+    "start": "node dist/index.js",
+    "test": "vitest run",
+    "lint": "tsc --noEmit"
+  },
+  "devDependencies": {
+    "@types/node": "^{{.NodeVersion}}.0.0",
+    "typescript": "^5.6.0",
+    "vitest": "^2.1.0"
+  }
+}
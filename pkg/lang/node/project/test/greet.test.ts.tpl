@@ -0,0 +1,9 @@
+import { describe, expect, it } from "vitest";
+
+import { greet } from "../src/greet.js";
+
+describe("greet", () => {
+  it("greets by name", () => {
+    expect(greet("scbake")).toBe("Hello, scbake!");
+  });
+});
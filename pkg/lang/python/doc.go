@@ -0,0 +1,2 @@
+// Package python provides the task handler for initializing Python projects.
+package python
@@ -0,0 +1,93 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package python
+
+import (
+	"fmt"
+	"scbake/internal/schema"
+	"scbake/internal/util"
+	"scbake/pkg/tasks"
+	"strings"
+)
+
+// templateData is what the Python pack's templates are rendered with: the
+// standard template context plus the resolved project settings.
+type templateData struct {
+	*tasks.TemplateContext
+	Name          string // Distribution name, e.g. billing-api.
+	Package       string // Import package, e.g. billing_api.
+	PythonVersion string // Minimum Python version, e.g. 3.12.
+	PythonTag     string // Python version as a tag, e.g. py312.
+	Description   string // One-line description.
+}
+
+// newTemplateData extends ctx with the settings from the validated metadata,
+// falling back to the directory name and the schema defaults.
+func newTemplateData(ctx *tasks.TemplateContext) (interface{}, error) {
+	metadata := ctx.Metadata
+
+	name := metadata["project_name"]
+	if name == "" {
+		dir, err := util.SanitizeModuleName(ctx.TargetPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not determine project name: %w", err)
+		}
+		name = dir
+	}
+
+	pkg := metadata["package_name"]
+	if pkg == "" {
+		pkg = packageName(name)
+	}
+
+	version, err := setting(metadata, "python_version")
+	if err != nil {
+		return nil, err
+	}
+
+	return &templateData{
+		TemplateContext: ctx,
+		Name:            name,
+		Package:         pkg,
+		PythonVersion:   version,
+		PythonTag:       "py" + strings.ReplaceAll(version, ".", ""),
+		Description:     metadata["project_description"],
+	}, nil
+}
+
+// packageName turns a distribution name into an import package name:
+// lowercase, with every other character than letters and digits replaced by
+// an underscore, e.g. billing_api for Billing-API. Names starting with a
+// digit get a pkg_ prefix.
+func packageName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	pkg := b.String()
+	if pkg == "" || (pkg[0] >= '0' && pkg[0] <= '9') {
+		pkg = "pkg_" + pkg
+	}
+	return pkg
+}
+
+// setting returns a metadata value, falling back to its schema default.
+func setting(metadata map[string]string, name string) (string, error) {
+	if v := metadata[name]; v != "" {
+		return v, nil
+	}
+	s, err := schema.ReadSchema(templates, "schema.json")
+	if err != nil {
+		return "", err
+	}
+	def, ok := s.Variables[name]
+	if !ok || def.Default == nil {
+		return "", fmt.Errorf("schema.json declares no %s default", name)
+	}
+	return *def.Default, nil
+}
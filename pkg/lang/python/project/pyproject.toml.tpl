@@ -0,0 +1,27 @@
+[build-system]
+requires = ["hatchling"]
+build-backend = "hatchling.build"
+
+[project]
+name = "{{.Name}}"
+version = "0.1.0"
+description = {{quote .Description}}
+requires-python = ">={{.PythonVersion}}"
+dependencies = []
+
+[project.optional-dependencies]
+dev = [
+    "build",
+    "pytest>=8",
+    "ruff",
+]
+
+[tool.hatch.build.targets.wheel]
+packages = ["src/{{.Package}}"]
+
+[tool.pytest.ini_options]
+testpaths = ["tests"]
+pythonpath = ["src"]
+
+[tool.ruff]
+target-version = "{{.PythonTag}}"
@@ -0,0 +1,123 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package python
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"scbake/internal/preflight"
+	"scbake/internal/types"
+	"scbake/pkg/tasks"
+)
+
+//go:embed schema.json all:project
+var templates embed.FS
+
+// Handler implements the lang.Handler interface for Python projects.
+type Handler struct{}
+
+// SchemaFS returns the embedded filesystem containing schema.json.
+func (h *Handler) SchemaFS() fs.FS { return templates }
+
+// SchemaPath returns the path to the embedded schema definition.
+func (h *Handler) SchemaPath() string { return "schema.json" }
+
+// Requirements returns python3 in at least python_version when it runs:
+// for the virtual environment of a new project. The project is rendered
+// without it.
+func (h *Handler) Requirements(targetPath string, metadata map[string]string) ([]preflight.Requirement, error) {
+	_, err := os.Stat(filepath.Join(targetPath, "pyproject.toml"))
+	if err == nil {
+		return nil, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("could not check for pyproject.toml: %w", err)
+	}
+
+	venv, err := setting(metadata, "venv")
+	if err != nil || venv != "true" {
+		return nil, err
+	}
+	pythonVersion, err := setting(metadata, "python_version")
+	if err != nil {
+		return nil, err
+	}
+	return []preflight.Requirement{
+		{Binary: "python3", Constraint: ">=" + pythonVersion, Reason: "venv = true, python_version = " + pythonVersion},
+	}, nil
+}
+
+// GetTasks returns the execution plan. The project and package names, the
+// Python version and the venv switch are read from the metadata when the
+// tasks execute.
+func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task, error) {
+	var plan []types.Task
+
+	// Initialize sequences
+	dirSeq, err := types.NewPrioritySequence(types.PrioDirCreate, types.MaxDirCreate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create priority sequence: %w", err)
+	}
+	langSeq, err := types.NewPrioritySequence(types.PrioLangSetup, types.MaxLangSetup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create priority sequence: %w", err)
+	}
+
+	// Task 0: Ensure target directory exists, so the venv command can run in it.
+	p, err := dirSeq.Next()
+	if err != nil {
+		return nil, err
+	}
+	plan = append(plan, &tasks.CreateDirTask{
+		Path:     targetPath,
+		Desc:     fmt.Sprintf("Create project directory '%s'", targetPath),
+		TaskPrio: int(p), // Now 50
+		Schedule: types.Schedule{Resources: []string{targetPath}},
+	})
+
+	// Idempotency Check: Check for existence of pyproject.toml
+	_, checkErr := os.Stat(filepath.Join(targetPath, "pyproject.toml"))
+
+	if os.IsNotExist(checkErr) {
+		// --- Path 1: pyproject.toml does NOT exist (Initialization) ---
+		// Task 1: Create pyproject.toml, src/<package>, tests and .gitignore
+		p, err := langSeq.Next()
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, &tasks.CreateTreeTask{
+			TemplateFS:  templates,
+			Root:        "project",
+			Desc:        "Create Python project layout",
+			TaskPrio:    int(p), // Now 100
+			ContextData: newTemplateData,
+			Schedule:    types.Schedule{Resources: []string{targetPath}},
+		})
+
+		// Task 2: Optionally create a virtual environment
+		p, err = langSeq.Next()
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, &tasks.ExecCommandTask{
+			Cmd:              "python3",
+			Args:             []string{"-m", "venv", ".venv"},
+			Desc:             "Create virtual environment in .venv",
+			TaskPrio:         int(p), // Now 101
+			RunInTarget:      true,
+			PredictedCreated: []string{".venv"},
+			When:             `eq .Metadata.venv "true"`,
+			Schedule:         types.Schedule{Resources: []string{targetPath}},
+		})
+	} else if checkErr != nil {
+		// --- Path 2 (pyproject.toml exists) leaves the plan with the CreateDirTask only.
+		// --- Path 3: Some other error ---
+		return nil, fmt.Errorf("could not check for pyproject.toml: %w", checkErr)
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package python
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"scbake/internal/types"
+	"scbake/pkg/tasks"
+	"strings"
+	"testing"
+)
+
+// TestGetTasks_NewPythonProject validates the initialization sequence.
+func TestGetTasks_NewPythonProject(t *testing.T) {
+	handler := &Handler{}
+	targetPath := filepath.Join(t.TempDir(), "billing-api")
+
+	plan, err := handler.GetTasks(targetPath, "", "")
+	if err != nil {
+		t.Fatalf("Failed to get tasks: %v", err)
+	}
+	if len(plan) != 3 {
+		t.Fatalf("Expected 3 tasks, got %d", len(plan))
+	}
+
+	if dirTask, ok := plan[0].(*tasks.CreateDirTask); !ok || dirTask.Path != targetPath {
+		t.Fatal("First task should be directory creation for the target path")
+	}
+	if tree, ok := plan[1].(*tasks.CreateTreeTask); !ok || tree.Root != "project" {
+		t.Fatalf("Second task should render the project tree, got %T", plan[1])
+	}
+	venv, ok := plan[2].(*tasks.ExecCommandTask)
+	if !ok || venv.Cmd != "python3" || strings.Join(venv.Args, " ") != "-m venv .venv" {
+		t.Fatalf("Third task should create the virtual environment, got %+v", plan[2])
+	}
+
+	// The virtual environment is opt-in.
+	for value, want := range map[string]bool{"": false, "false": false, "true": true} {
+		m := &types.Manifest{Metadata: map[string]string{"venv": value}}
+		got, err := tasks.EvalCondition(venv.When, m)
+		if err != nil {
+			t.Fatalf("EvalCondition failed: %v", err)
+		}
+		if got != want {
+			t.Errorf("venv=%q: got %v, want %v", value, got, want)
+		}
+	}
+
+	if plan[0].Priority() != int(types.PrioDirCreate) ||
+		plan[1].Priority() != int(types.PrioLangSetup) ||
+		plan[2].Priority() != int(types.PrioLangSetup)+1 {
+		t.Errorf("Unexpected priorities: %d, %d, %d", plan[0].Priority(), plan[1].Priority(), plan[2].Priority())
+	}
+}
+
+// TestGetTasks_ExistingPythonProject validates idempotency.
+func TestGetTasks_ExistingPythonProject(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "pyproject.toml"), []byte("[project]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := (&Handler{}).GetTasks(tempDir, "", "")
+	if err != nil {
+		t.Fatalf("Failed to get tasks: %v", err)
+	}
+	if len(plan) != 1 {
+		t.Errorf("Expected only 1 task for existing project, got %d", len(plan))
+	}
+}
+
+// TestProjectLayout renders the project and checks pyproject.toml and the package layout.
+func TestProjectLayout(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Billing-API")
+	plan, err := (&Handler{}).GetTasks(dir, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc := types.TaskContext{
+		Ctx:        context.Background(),
+		TargetPath: dir,
+		Manifest: &types.Manifest{Metadata: map[string]string{
+			"python_version":      "3.11",
+			"project_description": `A "billing" service`,
+		}},
+	}
+	for _, task := range plan[:2] {
+		if err := task.Execute(tc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pyproject := readFile(t, filepath.Join(dir, "pyproject.toml"))
+	for _, want := range []string{
+		`name = "billing-api"`,
+		`description = "A \"billing\" service"`,
+		`requires-python = ">=3.11"`,
+		`packages = ["src/billing_api"]`,
+		`target-version = "py311"`,
+	} {
+		if !strings.Contains(pyproject, want) {
+			t.Errorf("pyproject.toml missing %q:\n%s", want, pyproject)
+		}
+	}
+
+	if init := readFile(t, filepath.Join(dir, "src", "billing_api", "__init__.py")); !strings.HasPrefix(init, `"""billing-api: `) {
+		t.Errorf("__init__.py should be rendered:\n%s", init)
+	}
+	if test := readFile(t, filepath.Join(dir, "tests", "test_billing_api.py")); !strings.Contains(test, "from billing_api import") {
+		t.Errorf("Test module should import the package:\n%s", test)
+	}
+	readFile(t, filepath.Join(dir, ".gitignore"))
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path) //nolint:gosec // Test reads files it generated
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// TestPackageName checks the derivation of import names from project names.
+func TestPackageName(t *testing.T) {
+	for name, want := range map[string]string{
+		"billing":     "billing",
+		"Billing-API": "billing_api",
+		"my.tool":     "my_tool",
+		"2fa":         "pkg_2fa",
+	} {
+		if got := packageName(name); got != want {
+			t.Errorf("packageName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+// TestRequirements checks that python3 is only required for the virtual environment.
+func TestRequirements(t *testing.T) {
+	dir := t.TempDir()
+	h := &Handler{}
+
+	if reqs, err := h.Requirements(dir, nil); err != nil || len(reqs) != 0 {
+		t.Errorf("Expected no requirements without venv, got %+v, %v", reqs, err)
+	}
+
+	reqs, err := h.Requirements(dir, map[string]string{"venv": "true", "python_version": "3.11"})
+	if err != nil || len(reqs) != 1 || reqs[0].Binary != "python3" || reqs[0].Constraint != ">=3.11" {
+		t.Errorf("Unexpected requirements: %+v, %v", reqs, err)
+	}
+}
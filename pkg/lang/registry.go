@@ -4,13 +4,17 @@
 package lang
 
 import (
-	"embed"
 	"fmt"
+	"io/fs"
 	"sort"
 	"sync"
 
+	"scbake/internal/preflight"
 	"scbake/internal/types"
 	golang "scbake/pkg/lang/go"
+	"scbake/pkg/lang/node"
+	"scbake/pkg/lang/python"
+	"scbake/pkg/lang/rust"
 	"scbake/pkg/lang/spring"
 	"scbake/pkg/lang/svelte"
 )
@@ -20,10 +24,59 @@ import (
 // metadata against the schema before executing any tasks.
 type SchemaProvider interface {
 	Handler
-	SchemaFS() embed.FS
+	SchemaFS() fs.FS
 	SchemaPath() string
 }
 
+// PrerequisitesProvider is an optional interface a language Handler can
+// implement to declare the binaries that must be on PATH before it runs.
+type PrerequisitesProvider interface {
+	Handler
+	RequiredBinaries() []string
+}
+
+// PreflightProvider is an optional interface a language Handler can implement
+// to declare the binaries it runs together with the versions it accepts, e.g.
+// go >=1.22 for a go directive of 1.22. scbake checks the requirements of
+// every language of a run with preflight.Check before planning and reports
+// all unmet ones at once.
+type PreflightProvider interface {
+	Handler
+	// Requirements returns what the project at targetPath needs with the
+	// given metadata; switches such as install can drop requirements.
+	Requirements(targetPath string, metadata map[string]string) ([]preflight.Requirement, error)
+}
+
+// Requirements returns the binaries h needs for the project at targetPath:
+// those it declares through PreflightProvider, then those it lists through
+// PrerequisitesProvider. Handlers implementing neither need none.
+func Requirements(h Handler, targetPath string, metadata map[string]string) ([]preflight.Requirement, error) {
+	var reqs []preflight.Requirement
+	if pp, ok := h.(PreflightProvider); ok {
+		var err error
+		if reqs, err = pp.Requirements(targetPath, metadata); err != nil {
+			return nil, err
+		}
+	}
+	if pp, ok := h.(PrerequisitesProvider); ok {
+		for _, bin := range pp.RequiredBinaries() {
+			reqs = append(reqs, preflight.Requirement{Binary: bin})
+		}
+	}
+	return reqs, nil
+}
+
+// BuildToolProvider is an optional interface a language Handler can implement
+// when projects of its language can use more than one build tool. The result
+// is recorded as the project's build_tool in scbake.toml, so templates such
+// as makefile and ci_github can pick the matching commands.
+type BuildToolProvider interface {
+	Handler
+	// BuildTool returns the build tool of the project at targetPath: the one
+	// an existing project uses, otherwise the one selected by metadata.
+	BuildTool(targetPath string, metadata map[string]string) (string, error)
+}
+
 // Handler is the interface all language handlers must implement.
 type Handler interface {
 	// GetTasks takes a targetPath to be context-aware, and optional directories for overrides/cache
@@ -36,6 +89,9 @@ var (
 		"go":     &golang.Handler{},
 		"svelte": &svelte.Handler{},
 		"spring": &spring.Handler{},
+		"python": &python.Handler{},
+		"rust":   &rust.Handler{},
+		"node":   &node.Handler{},
 	}
 )
 
@@ -58,9 +114,9 @@ func GetHandler(langName string) (Handler, error) {
 }
 
 // GetSchema looks up the handler by name and, if it implements SchemaProvider,
-// reads and parses its embedded schema.json. Returns nil, nil when the handler
-// does not provide a schema (validation is skipped).
-func GetSchema(langName string) (*embed.FS, string, error) {
+// returns the filesystem and path of its schema.json. Returns nil when the
+// handler does not provide a schema (validation is skipped).
+func GetSchema(langName string) (fs.FS, string, error) {
 	handlersLock.RLock()
 	defer handlersLock.RUnlock()
 
@@ -74,9 +130,11 @@ func GetSchema(langName string) (*embed.FS, string, error) {
 		return nil, "", nil
 	}
 
-	fs := sp.SchemaFS()
 	path := sp.SchemaPath()
-	return &fs, path, nil
+	if path == "" {
+		return nil, "", nil
+	}
+	return sp.SchemaFS(), path, nil
 }
 
 // ListLangs returns the sorted names of all supported languages.
@@ -1,16 +1,12 @@
-# Rust
+# Build output
 /target/
-Cargo.lock
 **/*.rs.bk
 *.pdb
 
-# IDE
-.vscode/
+# IDEs
 .idea/
+.vscode/
 *.swp
-*.swo
-*~
 
 # OS
 .DS_Store
-Thumbs.db
@@ -0,0 +1,9 @@
+[package]
+name = "{{.Name}}"
+version = "0.1.0"
+edition = "{{.Edition}}"
+{{- with .Description}}
+description = {{quote .}}
+{{- end}}
+
+[dependencies]
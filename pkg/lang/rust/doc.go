@@ -0,0 +1,2 @@
+// Package rust provides the task handler for initializing Rust (Cargo) projects.
+package rust
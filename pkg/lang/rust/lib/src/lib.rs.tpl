@@ -0,0 +1,20 @@
+//! {{with .Description}}{{.}}{{else}}The {{.Name}} crate.{{end}}
+
+/// Returns a greeting for `name`.
+///
+/// ```
+/// assert_eq!({{.Crate}}::greet("scbake"), "Hello, scbake!");
+/// ```
+pub fn greet(name: &str) -> String {
+    format!("Hello, {name}!")
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn greets_by_name() {
+        assert_eq!(greet("scbake"), "Hello, scbake!");
+    }
+}
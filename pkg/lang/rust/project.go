@@ -0,0 +1,121 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package rust
+
+import (
+	"fmt"
+	"scbake/internal/schema"
+	"scbake/internal/types"
+	"scbake/internal/util"
+	"scbake/pkg/tasks"
+	"strings"
+)
+
+// editionSince is the first Rust release of each edition (the edition
+// variable), which older cargo releases reject in Cargo.toml.
+var editionSince = map[string]string{
+	"2018": "1.31",
+	"2021": "1.56",
+	"2024": "1.85",
+}
+
+// templateData is what the Rust pack's templates are rendered with: the
+// standard template context plus the resolved crate settings.
+type templateData struct {
+	*tasks.TemplateContext
+	Name        string // Package name, e.g. billing-api.
+	Crate       string // Crate name as used in paths, e.g. billing_api.
+	Edition     string // Rust edition, e.g. 2021.
+	Description string // One-line description.
+}
+
+// newTemplateData extends ctx with the settings from the validated metadata,
+// falling back to the directory name and the schema defaults.
+func newTemplateData(ctx *tasks.TemplateContext) (interface{}, error) {
+	metadata := ctx.Metadata
+
+	name := metadata["crate_name"]
+	if name == "" {
+		dir, err := util.SanitizeModuleName(ctx.TargetPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not determine crate name: %w", err)
+		}
+		name = crateName(dir)
+	}
+
+	edition, err := setting(metadata, "edition")
+	if err != nil {
+		return nil, err
+	}
+
+	return &templateData{
+		TemplateContext: ctx,
+		Name:            name,
+		Crate:           strings.ReplaceAll(name, "-", "_"),
+		Edition:         edition,
+		Description:     metadata["project_description"],
+	}, nil
+}
+
+// crateName turns a directory name into a valid Cargo package name: every
+// other character than letters, digits, "-" and "_" becomes a hyphen, e.g.
+// my-app-v2 for my.app.v2. Names starting with a digit get an app- prefix.
+func crateName(dir string) string {
+	var b strings.Builder
+	for _, r := range dir {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+	name := b.String()
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "app-" + name
+	}
+	return name
+}
+
+// setting returns a metadata value, falling back to its schema default.
+func setting(metadata map[string]string, name string) (string, error) {
+	if v := metadata[name]; v != "" {
+		return v, nil
+	}
+	s, err := schema.ReadSchema(templates, "schema.json")
+	if err != nil {
+		return "", err
+	}
+	def, ok := s.Variables[name]
+	if !ok || def.Default == nil {
+		return "", fmt.Errorf("schema.json declares no %s default", name)
+	}
+	return *def.Default, nil
+}
+
+// checkTask runs cargo check on the new crate when cargo_check is set. cargo
+// is only required then (see Handler.Requirements).
+type checkTask struct {
+	prio       int
+	targetPath string
+}
+
+func (t *checkTask) Description() string { return "Check crate with cargo" }
+func (t *checkTask) Priority() int       { return t.prio }
+func (t *checkTask) Condition() string   { return `eq .Metadata.cargo_check "true"` }
+func (t *checkTask) Scheduling() types.Schedule {
+	return types.Schedule{Resources: []string{t.targetPath}}
+}
+
+func (t *checkTask) Execute(tc types.TaskContext) error {
+	if tc.DryRun {
+		return nil
+	}
+	cmd := &tasks.ExecCommandTask{
+		Cmd:              "cargo",
+		Args:             []string{"check", "--quiet"},
+		RunInTarget:      true,
+		PredictedCreated: []string{"target", "Cargo.lock"},
+	}
+	return cmd.Execute(tc)
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package rust
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"scbake/internal/preflight"
+	"scbake/internal/types"
+	"scbake/pkg/tasks"
+)
+
+//go:embed schema.json all:crate bin lib
+var templates embed.FS
+
+// Handler implements the lang.Handler interface for Rust projects.
+type Handler struct{}
+
+// SchemaFS returns the embedded filesystem containing schema.json.
+func (h *Handler) SchemaFS() fs.FS { return templates }
+
+// SchemaPath returns the path to the embedded schema definition.
+func (h *Handler) SchemaPath() string { return "schema.json" }
+
+// Requirements returns cargo in at least the first release of the crate's
+// edition when it runs: for cargo_check on a new crate. The crate is
+// rendered without it.
+func (h *Handler) Requirements(targetPath string, metadata map[string]string) ([]preflight.Requirement, error) {
+	_, err := os.Stat(filepath.Join(targetPath, "Cargo.toml"))
+	if err == nil {
+		return nil, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("could not check for Cargo.toml: %w", err)
+	}
+
+	check, err := setting(metadata, "cargo_check")
+	if err != nil || check != "true" {
+		return nil, err
+	}
+	edition, err := setting(metadata, "edition")
+	if err != nil {
+		return nil, err
+	}
+	cargo := preflight.Requirement{Binary: "cargo", Reason: "cargo_check = true, edition = " + edition}
+	if since, ok := editionSince[edition]; ok {
+		cargo.Constraint = ">=" + since
+	}
+	return []preflight.Requirement{cargo}, nil
+}
+
+// GetTasks returns the execution plan. The crate name, type and edition and
+// the cargo_check switch are read from the metadata when the tasks execute.
+func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task, error) {
+	var plan []types.Task
+
+	// Initialize sequences
+	dirSeq, err := types.NewPrioritySequence(types.PrioDirCreate, types.MaxDirCreate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create priority sequence: %w", err)
+	}
+	langSeq, err := types.NewPrioritySequence(types.PrioLangSetup, types.MaxLangSetup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create priority sequence: %w", err)
+	}
+
+	// Task 0: Ensure target directory exists (always needed)
+	p, err := dirSeq.Next()
+	if err != nil {
+		return nil, err
+	}
+	plan = append(plan, &tasks.CreateDirTask{
+		Path:     targetPath,
+		Desc:     fmt.Sprintf("Create project directory '%s'", targetPath),
+		TaskPrio: int(p), // Now 50
+		Schedule: types.Schedule{Resources: []string{targetPath}},
+	})
+
+	// Idempotency Check: Check for existence of Cargo.toml
+	_, checkErr := os.Stat(filepath.Join(targetPath, "Cargo.toml"))
+
+	if os.IsNotExist(checkErr) {
+		// --- Path 1: Cargo.toml does NOT exist (Initialization) ---
+		// Task 1: Create Cargo.toml and .gitignore
+		p, err := langSeq.Next()
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, &tasks.CreateTreeTask{
+			TemplateFS:  templates,
+			Root:        "crate",
+			Desc:        "Create Cargo.toml and .gitignore",
+			TaskPrio:    int(p), // Now 100
+			ContextData: newTemplateData,
+			Schedule:    types.Schedule{Resources: []string{targetPath}},
+		})
+
+		// Task 2: Create src/main.rs or src/lib.rs, depending on crate_type
+		for _, crate := range []struct{ root, desc, when string }{
+			{"bin", "Create src/main.rs", `ne .Metadata.crate_type "lib"`},
+			{"lib", "Create src/lib.rs", `eq .Metadata.crate_type "lib"`},
+		} {
+			p, err = langSeq.Next()
+			if err != nil {
+				return nil, err
+			}
+			plan = append(plan, &tasks.CreateTreeTask{
+				TemplateFS:  templates,
+				Root:        crate.root,
+				Desc:        crate.desc,
+				TaskPrio:    int(p), // Now 101 and 102
+				ContextData: newTemplateData,
+				When:        crate.when,
+				Schedule:    types.Schedule{Resources: []string{targetPath}},
+			})
+		}
+
+		// Task 3: Optionally check that the crate builds
+		p, err = langSeq.Next()
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, &checkTask{prio: int(p), targetPath: targetPath}) // Now 103
+	} else if checkErr != nil {
+		// --- Path 2 (Cargo.toml exists) leaves the plan with the CreateDirTask only.
+		// --- Path 3: Some other error ---
+		return nil, fmt.Errorf("could not check for Cargo.toml: %w", checkErr)
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package rust
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"scbake/internal/types"
+	"scbake/pkg/tasks"
+	"strings"
+	"testing"
+)
+
+// TestGetTasks_NewRustProject validates the initialization sequence.
+func TestGetTasks_NewRustProject(t *testing.T) {
+	targetPath := filepath.Join(t.TempDir(), "billing-api")
+
+	plan, err := (&Handler{}).GetTasks(targetPath, "", "")
+	if err != nil {
+		t.Fatalf("Failed to get tasks: %v", err)
+	}
+	if len(plan) != 5 {
+		t.Fatalf("Expected 5 tasks, got %d", len(plan))
+	}
+	if dirTask, ok := plan[0].(*tasks.CreateDirTask); !ok || dirTask.Path != targetPath {
+		t.Fatal("First task should be directory creation for the target path")
+	}
+	for i, root := range []string{"crate", "bin", "lib"} {
+		if tree, ok := plan[i+1].(*tasks.CreateTreeTask); !ok || tree.Root != root {
+			t.Fatalf("Task %d should render the %s tree, got %T", i+1, root, plan[i+1])
+		}
+	}
+	if _, ok := plan[4].(*checkTask); !ok {
+		t.Fatalf("Last task should run cargo check, got %T", plan[4])
+	}
+
+	for i := 1; i < len(plan); i++ {
+		if plan[i].Priority() != int(types.PrioLangSetup)+i-1 {
+			t.Errorf("Task %d has priority %d, want %d", i, plan[i].Priority(), int(types.PrioLangSetup)+i-1)
+		}
+	}
+}
+
+// TestGetTasks_Conditions checks that exactly one of src/main.rs and
+// src/lib.rs is written and that cargo check is opt-in.
+func TestGetTasks_Conditions(t *testing.T) {
+	plan, err := (&Handler{}).GetTasks(t.TempDir(), "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin := plan[2].(types.ConditionalTask).Condition()
+	lib := plan[3].(types.ConditionalTask).Condition()
+	check := plan[4].(types.ConditionalTask).Condition()
+
+	for _, tc := range []struct {
+		metadata             map[string]string
+		wantBin, wantLib, ok bool
+	}{
+		{map[string]string{}, true, false, false},
+		{map[string]string{"crate_type": "bin"}, true, false, false},
+		{map[string]string{"crate_type": "lib", "cargo_check": "true"}, false, true, true},
+	} {
+		m := &types.Manifest{Metadata: tc.metadata}
+		for when, want := range map[string]bool{bin: tc.wantBin, lib: tc.wantLib, check: tc.ok} {
+			got, err := tasks.EvalCondition(when, m)
+			if err != nil {
+				t.Fatalf("EvalCondition(%q) failed: %v", when, err)
+			}
+			if got != want {
+				t.Errorf("%q with %v: got %v, want %v", when, tc.metadata, got, want)
+			}
+		}
+	}
+}
+
+// TestGetTasks_ExistingRustProject validates idempotency.
+func TestGetTasks_ExistingRustProject(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "Cargo.toml"), []byte("[package]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := (&Handler{}).GetTasks(tempDir, "", "")
+	if err != nil {
+		t.Fatalf("Failed to get tasks: %v", err)
+	}
+	if len(plan) != 1 {
+		t.Errorf("Expected only 1 task for existing project, got %d", len(plan))
+	}
+}
+
+// TestCrate renders a binary and a library crate and builds them with cargo
+// when it is available.
+func TestCrate(t *testing.T) {
+	for _, crateType := range []string{"bin", "lib"} {
+		t.Run(crateType, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "Billing.API")
+			m := &types.Manifest{Metadata: map[string]string{
+				"crate_type":          crateType,
+				"edition":             "2021",
+				"project_description": `Billing "core"`,
+			}}
+			render(t, dir, m)
+
+			cargo := readFile(t, filepath.Join(dir, "Cargo.toml"))
+			for _, want := range []string{
+				`name = "billing-api"`,
+				`edition = "2021"`,
+				`description = "Billing \"core\""`,
+			} {
+				if !strings.Contains(cargo, want) {
+					t.Errorf("Cargo.toml missing %q:\n%s", want, cargo)
+				}
+			}
+			readFile(t, filepath.Join(dir, ".gitignore"))
+
+			src, other := "main.rs", "lib.rs"
+			if crateType == "lib" {
+				src, other = other, src
+			}
+			readFile(t, filepath.Join(dir, "src", src))
+			if _, err := os.Stat(filepath.Join(dir, "src", other)); !os.IsNotExist(err) {
+				t.Errorf("src/%s should not exist for a %s crate", other, crateType)
+			}
+
+			if testing.Short() {
+				t.Skip("skipping cargo test in short mode")
+			}
+			if _, err := exec.LookPath("cargo"); err != nil {
+				t.Skip("cargo not installed")
+			}
+			cmd := exec.Command("cargo", "test", "--quiet", "--offline")
+			cmd.Dir = dir
+			cmd.Env = append(os.Environ(), "CARGO_TARGET_DIR="+t.TempDir())
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("cargo test failed: %v\n%s", err, out)
+			}
+		})
+	}
+}
+
+// render executes the plan's tasks whose conditions hold for m.
+func render(t *testing.T, dir string, m *types.Manifest) {
+	t.Helper()
+	plan, err := (&Handler{}).GetTasks(dir, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc := types.TaskContext{Ctx: context.Background(), TargetPath: dir, Manifest: m}
+	for _, task := range plan {
+		if ct, ok := task.(types.ConditionalTask); ok {
+			if run, err := tasks.EvalCondition(ct.Condition(), m); err != nil || !run {
+				continue
+			}
+		}
+		if err := task.Execute(tc); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path) //nolint:gosec // Test reads files it generated
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// TestCrateName checks the derivation of package names from directory names.
+func TestCrateName(t *testing.T) {
+	for dir, want := range map[string]string{
+		"billing":     "billing",
+		"billing-api": "billing-api",
+		"my.tool":     "my-tool",
+		"2fa":         "app-2fa",
+	} {
+		if got := crateName(dir); got != want {
+			t.Errorf("crateName(%q) = %q, want %q", dir, got, want)
+		}
+	}
+}
+
+// TestRequirements checks that cargo is only required for cargo_check, in a
+// release that knows the edition.
+func TestRequirements(t *testing.T) {
+	dir := t.TempDir()
+	h := &Handler{}
+
+	if reqs, err := h.Requirements(dir, nil); err != nil || len(reqs) != 0 {
+		t.Errorf("Expected no requirements without cargo_check, got %+v, %v", reqs, err)
+	}
+
+	reqs, err := h.Requirements(dir, map[string]string{"cargo_check": "true", "edition": "2024"})
+	if err != nil || len(reqs) != 1 || reqs[0].Binary != "cargo" || reqs[0].Constraint != ">=1.85" {
+		t.Errorf("Unexpected requirements: %+v, %v", reqs, err)
+	}
+}
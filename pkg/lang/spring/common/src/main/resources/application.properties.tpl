@@ -0,0 +1,4 @@
+spring.application.name={{.ArtifactID}}
+{{- if .Uses "actuator"}}
+management.endpoints.web.exposure.include=health,info
+{{- end}}
@@ -0,0 +1,43 @@
+plugins {
+	java
+	id("org.springframework.boot") version "{{.SpringBootVersion}}"
+	id("io.spring.dependency-management") version "1.1.6"
+}
+
+group = "{{.GroupID}}"
+version = "0.0.1-SNAPSHOT"
+
+java {
+	toolchain {
+		languageVersion = JavaLanguageVersion.of({{.JavaVersion}})
+	}
+}
+{{- if .Uses "lombok"}}
+
+configurations {
+	compileOnly {
+		extendsFrom(configurations.annotationProcessor.get())
+	}
+}
+{{- end}}
+
+repositories {
+	mavenCentral()
+}
+
+dependencies {
+{{- range $d := .Dependencies}}
+{{- range $d.GradleConfigurations}}
+	{{.}}("{{$d.GroupID}}:{{$d.ArtifactID}}")
+{{- end}}
+{{- end}}
+	testImplementation("org.springframework.boot:spring-boot-starter-test")
+{{- if .Uses "security"}}
+	testImplementation("org.springframework.security:spring-security-test")
+{{- end}}
+	testRuntimeOnly("org.junit.platform:junit-platform-launcher")
+}
+
+tasks.withType<Test> {
+	useJUnitPlatform()
+}
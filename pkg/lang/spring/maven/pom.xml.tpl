@@ -0,0 +1,77 @@
+<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+	xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 https://maven.apache.org/xsd/maven-4.0.0.xsd">
+	<modelVersion>4.0.0</modelVersion>
+	<parent>
+		<groupId>org.springframework.boot</groupId>
+		<artifactId>spring-boot-starter-parent</artifactId>
+		<version>{{.SpringBootVersion}}</version>
+		<relativePath/>
+	</parent>
+	<groupId>{{.GroupID}}</groupId>
+	<artifactId>{{.ArtifactID}}</artifactId>
+	<version>0.0.1-SNAPSHOT</version>
+	<name>{{.ArtifactID}}</name>
+	<properties>
+		<java.version>{{.JavaVersion}}</java.version>
+	</properties>
+	<dependencies>
+{{- range .Dependencies}}
+		<dependency>
+			<groupId>{{.GroupID}}</groupId>
+			<artifactId>{{.ArtifactID}}</artifactId>
+{{- if .Scope}}
+			<scope>{{.Scope}}</scope>
+{{- end}}
+{{- if .Optional}}
+			<optional>true</optional>
+{{- end}}
+		</dependency>
+{{- end}}
+		<dependency>
+			<groupId>org.springframework.boot</groupId>
+			<artifactId>spring-boot-starter-test</artifactId>
+			<scope>test</scope>
+		</dependency>
+{{- if .Uses "security"}}
+		<dependency>
+			<groupId>org.springframework.security</groupId>
+			<artifactId>spring-security-test</artifactId>
+			<scope>test</scope>
+		</dependency>
+{{- end}}
+	</dependencies>
+	<build>
+		<plugins>
+{{- if .Uses "lombok"}}
+			<plugin>
+				<groupId>org.apache.maven.plugins</groupId>
+				<artifactId>maven-compiler-plugin</artifactId>
+				<configuration>
+					<annotationProcessorPaths>
+						<path>
+							<groupId>org.projectlombok</groupId>
+							<artifactId>lombok</artifactId>
+							<version>${lombok.version}</version>
+						</path>
+					</annotationProcessorPaths>
+				</configuration>
+			</plugin>
+{{- end}}
+			<plugin>
+				<groupId>org.springframework.boot</groupId>
+				<artifactId>spring-boot-maven-plugin</artifactId>
+{{- if .Uses "lombok"}}
+				<configuration>
+					<excludes>
+						<exclude>
+							<groupId>org.projectlombok</groupId>
+							<artifactId>lombok</artifactId>
+						</exclude>
+					</excludes>
+				</configuration>
+{{- end}}
+			</plugin>
+		</plugins>
+	</build>
+</project>
@@ -0,0 +1,342 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package spring
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"scbake/internal/schema"
+	"scbake/internal/types"
+	"scbake/internal/util"
+	"scbake/pkg/tasks"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Generation modes (the spring_mode variable).
+const (
+	modeEmbedded   = "embedded"
+	modeInitializr = "initializr"
+)
+
+// Build tools (the build_tool variable), recorded per project in scbake.toml.
+const (
+	buildMaven  = "maven"
+	buildGradle = "gradle"
+)
+
+// buildFiles are the files that mark an existing project of each build tool.
+var buildFiles = map[string][]string{
+	buildMaven:  {"pom.xml"},
+	buildGradle: {"build.gradle.kts", "build.gradle"},
+}
+
+// initializrURL is the Spring Initializr endpoint used in initializr mode.
+const initializrURL = "https://start.spring.io/starter.zip"
+
+// dependency is a Maven dependency selectable through the dependencies variable.
+type dependency struct {
+	GroupID    string
+	ArtifactID string
+	Scope      string
+	Optional   bool
+	starter    bool     // A Spring Boot starter; without one, spring-boot-starter is added.
+	gradle     []string // Gradle configurations, if not derived from Scope.
+}
+
+// GradleConfigurations returns the Gradle configurations the dependency is
+// declared in.
+func (d dependency) GradleConfigurations() []string {
+	switch {
+	case len(d.gradle) > 0:
+		return d.gradle
+	case d.Scope == "runtime":
+		return []string{"runtimeOnly"}
+	default:
+		return []string{"implementation"}
+	}
+}
+
+// catalog maps dependency IDs, which match those of start.spring.io, to
+// their Maven coordinates. Versions are managed by the Spring Boot parent.
+var catalog = map[string]dependency{
+	"web":        {GroupID: "org.springframework.boot", ArtifactID: "spring-boot-starter-web", starter: true},
+	"webflux":    {GroupID: "org.springframework.boot", ArtifactID: "spring-boot-starter-webflux", starter: true},
+	"actuator":   {GroupID: "org.springframework.boot", ArtifactID: "spring-boot-starter-actuator", starter: true},
+	"validation": {GroupID: "org.springframework.boot", ArtifactID: "spring-boot-starter-validation", starter: true},
+	"security":   {GroupID: "org.springframework.boot", ArtifactID: "spring-boot-starter-security", starter: true},
+	"data-jpa":   {GroupID: "org.springframework.boot", ArtifactID: "spring-boot-starter-data-jpa", starter: true},
+	"lombok":     {GroupID: "org.projectlombok", ArtifactID: "lombok", Optional: true, gradle: []string{"compileOnly", "annotationProcessor"}},
+	"devtools":   {GroupID: "org.springframework.boot", ArtifactID: "spring-boot-devtools", Scope: "runtime", Optional: true, gradle: []string{"developmentOnly"}},
+	"h2":         {GroupID: "com.h2database", ArtifactID: "h2", Scope: "runtime"},
+	"postgresql": {GroupID: "org.postgresql", ArtifactID: "postgresql", Scope: "runtime"},
+}
+
+// settings are the resolved project settings.
+type settings struct {
+	Mode              string
+	BuildTool         string
+	GroupID           string
+	ArtifactID        string
+	JavaVersion       string
+	SpringBootVersion string
+	DependencyIDs     []string
+}
+
+// projectSettings resolves the settings from the validated metadata,
+// falling back to the schema defaults and, for the artifact ID, to the
+// directory name. Unknown dependency IDs are rejected.
+func projectSettings(metadata map[string]string, targetPath string) (*settings, error) {
+	s := &settings{ArtifactID: metadata["artifact_id"]}
+	if s.ArtifactID == "" {
+		name, err := util.SanitizeModuleName(targetPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not determine artifact ID: %w", err)
+		}
+		s.ArtifactID = name
+	}
+
+	for name, dst := range map[string]*string{
+		"spring_mode":         &s.Mode,
+		"build_tool":          &s.BuildTool,
+		"group_id":            &s.GroupID,
+		"java_version":        &s.JavaVersion,
+		"spring_boot_version": &s.SpringBootVersion,
+	} {
+		v, err := setting(metadata, name)
+		if err != nil {
+			return nil, err
+		}
+		*dst = v
+	}
+
+	deps, err := setting(metadata, "dependencies")
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range strings.Split(deps, ",") {
+		id = strings.TrimSpace(id)
+		if _, ok := catalog[id]; !ok {
+			return nil, fmt.Errorf("unknown Spring dependency %q (known: %s)", id, strings.Join(knownDependencies(), ", "))
+		}
+		s.DependencyIDs = append(s.DependencyIDs, id)
+	}
+	return s, nil
+}
+
+func knownDependencies() []string {
+	ids := make([]string, 0, len(catalog))
+	for id := range catalog {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Package returns the base Java package: the group ID followed by the
+// artifact ID reduced to lowercase letters and digits.
+func (s *settings) Package() string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s.ArtifactID) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	seg := b.String()
+	if seg == "" || (seg[0] >= '0' && seg[0] <= '9') {
+		seg = "app" + seg
+	}
+	return s.GroupID + "." + seg
+}
+
+// ClassName returns the application class name, e.g. BillingApiApplication
+// for billing-api.
+func (s *settings) ClassName() string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s.ArtifactID {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "App" + name
+	}
+	return name + "Application"
+}
+
+// setting returns a metadata value, falling back to its schema default.
+func setting(metadata map[string]string, name string) (string, error) {
+	if v := metadata[name]; v != "" {
+		return v, nil
+	}
+	return schemaDefault(name)
+}
+
+// schemaDefault reads a variable's default from the embedded schema, which
+// stays the single source of truth.
+func schemaDefault(name string) (string, error) {
+	s, err := schema.ReadSchema(templates, "schema.json")
+	if err != nil {
+		return "", err
+	}
+	def, ok := s.Variables[name]
+	if !ok || def.Default == nil {
+		return "", fmt.Errorf("schema.json declares no %s default", name)
+	}
+	return *def.Default, nil
+}
+
+// templateData is what the Spring pack's templates are rendered with: the
+// standard template context plus the resolved settings, including their
+// Package and ClassName.
+type templateData struct {
+	*tasks.TemplateContext
+	*settings
+	Dependencies []dependency // Selected dependencies, in order.
+}
+
+// Uses reports whether the dependency with the given ID is selected.
+func (d *templateData) Uses(id string) bool {
+	for _, dep := range d.DependencyIDs {
+		if dep == id {
+			return true
+		}
+	}
+	return false
+}
+
+func newTemplateData(ctx *tasks.TemplateContext, s *settings) *templateData {
+	d := &templateData{TemplateContext: ctx, settings: s}
+	hasStarter := false
+	for _, id := range s.DependencyIDs {
+		d.Dependencies = append(d.Dependencies, catalog[id])
+		hasStarter = hasStarter || catalog[id].starter
+	}
+	if !hasStarter {
+		base := dependency{GroupID: "org.springframework.boot", ArtifactID: "spring-boot-starter", starter: true}
+		d.Dependencies = append([]dependency{base}, d.Dependencies...)
+	}
+	return d
+}
+
+// projectTask generates the Maven project in the mode selected by the
+// spring_mode variable. The settings come from the validated metadata, which
+// is only known when the plan executes.
+type projectTask struct {
+	prio       int
+	targetPath string
+}
+
+func (t *projectTask) Description() string { return "Generate Spring Boot project" }
+func (t *projectTask) Priority() int       { return t.prio }
+func (t *projectTask) Scheduling() types.Schedule {
+	return types.Schedule{Resources: []string{t.targetPath}}
+}
+
+func (t *projectTask) Execute(tc types.TaskContext) error {
+	s, err := projectSettings(tc.Metadata(), tc.TargetPath)
+	if err != nil {
+		return err
+	}
+	// The build tool recorded for the project at plan time (see Handler.BuildTool) wins.
+	if tc.Project != nil && tc.Project.BuildTool != "" {
+		s.BuildTool = tc.Project.BuildTool
+	}
+	if _, ok := buildFiles[s.BuildTool]; !ok {
+		return fmt.Errorf("unknown Spring build tool %q (expected %s or %s)", s.BuildTool, buildMaven, buildGradle)
+	}
+
+	switch s.Mode {
+	case modeEmbedded:
+		return t.embedded(tc, s)
+	case modeInitializr:
+		return t.initializr(tc, s)
+	default:
+		return fmt.Errorf("unknown Spring mode %q (expected %s or %s)", s.Mode, modeEmbedded, modeInitializr)
+	}
+}
+
+// embedded renders the project from the embedded templates. The build files
+// come from maven/ or gradle/ and common/, the Java sources from sources/main
+// and sources/test, which are written into the directories of the base
+// package. Every file resolves through --template-dir and the registry cache
+// first.
+func (t *projectTask) embedded(tc types.TaskContext, s *settings) error {
+	contextData := func(ctx *tasks.TemplateContext) (interface{}, error) {
+		return newTemplateData(ctx, s), nil
+	}
+	pkgDir := filepath.FromSlash(strings.ReplaceAll(s.Package(), ".", "/"))
+
+	trees := []*tasks.CreateTreeTask{
+		{Root: s.BuildTool, Executable: []string{"mvnw", "gradlew"}},
+		{Root: "common"},
+		{Root: "sources/main", OutputDir: filepath.Join("src", "main", "java", pkgDir)},
+		{Root: "sources/test", OutputDir: filepath.Join("src", "test", "java", pkgDir)},
+	}
+	for _, tree := range trees {
+		tree.TemplateFS = templates
+		tree.TaskPrio = t.prio
+		tree.ContextData = contextData
+		if err := tree.Execute(tc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initializr downloads and extracts the project from start.spring.io with
+// curl and unzip (see Handler.Requirements).
+func (t *projectTask) initializr(tc types.TaskContext, s *settings) error {
+	const zipFile = "spring-init.zip"
+	extracted := []string{"src", ".gitignore", ".gitattributes", "HELP.md"}
+	wrapper := "mvnw"
+	if s.BuildTool == buildGradle {
+		wrapper = "gradlew"
+		extracted = append(extracted, "build.gradle.kts", "settings.gradle.kts", "gradlew", "gradlew.bat", "gradle")
+	} else {
+		extracted = append(extracted, "pom.xml", "mvnw", "mvnw.cmd", ".mvn")
+	}
+
+	steps := []*tasks.ExecCommandTask{
+		{Cmd: "curl", Args: []string{"-f", "-sS", "-o", zipFile, s.initializrURL()}, RunInTarget: true, PredictedCreated: []string{zipFile}},
+		{Cmd: "unzip", Args: []string{"-q", "-o", zipFile}, RunInTarget: true, PredictedCreated: extracted},
+		{Cmd: "rm", Args: []string{zipFile}, RunInTarget: true},
+		{Cmd: "chmod", Args: []string{"+x", wrapper}, RunInTarget: true},
+	}
+	for _, step := range steps {
+		if err := step.Execute(tc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initializrURL returns the start.spring.io request for the settings.
+func (s *settings) initializrURL() string {
+	q := url.Values{}
+	q.Set("type", "maven-project")
+	if s.BuildTool == buildGradle {
+		q.Set("type", "gradle-project-kotlin")
+	}
+	q.Set("language", "java")
+	q.Set("packaging", "jar")
+	q.Set("groupId", s.GroupID)
+	q.Set("artifactId", s.ArtifactID)
+	q.Set("name", s.ArtifactID)
+	q.Set("packageName", s.Package())
+	q.Set("javaVersion", s.JavaVersion)
+	q.Set("bootVersion", s.SpringBootVersion)
+	q.Set("dependencies", strings.Join(s.DependencyIDs, ","))
+	return initializrURL + "?" + q.Encode()
+}
@@ -0,0 +1,13 @@
+package {{.Package}};
+
+import org.springframework.boot.SpringApplication;
+import org.springframework.boot.autoconfigure.SpringBootApplication;
+
+@SpringBootApplication
+public class {{.ClassName}} {
+
+	public static void main(String[] args) {
+		SpringApplication.run({{.ClassName}}.class, args);
+	}
+
+}
@@ -0,0 +1,13 @@
+package {{.Package}};
+
+import org.junit.jupiter.api.Test;
+import org.springframework.boot.test.context.SpringBootTest;
+
+@SpringBootTest
+class {{.ClassName}}Tests {
+
+	@Test
+	void contextLoads() {
+	}
+
+}
@@ -4,20 +4,93 @@
 package spring
 
 import (
+	"embed"
 	"fmt"
-	"net/url"
+	"io/fs"
 	"os"
 	"path/filepath"
-	"strings"
 
+	"scbake/internal/preflight"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
 )
 
+//go:embed schema.json all:maven all:gradle common sources
+var templates embed.FS
+
 // Handler implements the lang.Handler interface for Spring Boot projects.
 type Handler struct{}
 
-// GetTasks returns the execution plan for initializing a Spring Boot project at targetPath.
+// SchemaFS returns the embedded filesystem containing schema.json.
+func (h *Handler) SchemaFS() fs.FS { return templates }
+
+// SchemaPath returns the path to the embedded schema definition.
+func (h *Handler) SchemaPath() string { return "schema.json" }
+
+// BuildTool returns the build tool of the project at targetPath: the one
+// whose build file exists, otherwise the build_tool variable.
+func (h *Handler) BuildTool(targetPath string, metadata map[string]string) (string, error) {
+	existing, err := existingBuildTool(targetPath)
+	if err != nil || existing != "" {
+		return existing, err
+	}
+	return setting(metadata, "build_tool")
+}
+
+// Requirements returns java in at least the java_version release and, for
+// a new project in initializr mode, curl and unzip. An existing project
+// declares its own release, so any java is accepted for it.
+func (h *Handler) Requirements(targetPath string, metadata map[string]string) ([]preflight.Requirement, error) {
+	existing, err := existingBuildTool(targetPath)
+	if err != nil {
+		return nil, err
+	}
+	java := preflight.Requirement{Binary: "java", VersionArgs: []string{"-version"}}
+	if existing != "" {
+		return []preflight.Requirement{java}, nil
+	}
+
+	javaVersion, err := setting(metadata, "java_version")
+	if err != nil {
+		return nil, err
+	}
+	java.Constraint = ">=" + javaVersion
+	java.Reason = "java_version = " + javaVersion
+	reqs := []preflight.Requirement{java}
+
+	mode, err := setting(metadata, "spring_mode")
+	if err != nil {
+		return nil, err
+	}
+	if mode == modeInitializr {
+		reqs = append(reqs,
+			preflight.Requirement{Binary: "curl", Reason: "spring_mode = " + modeInitializr},
+			preflight.Requirement{Binary: "unzip", Reason: "spring_mode = " + modeInitializr},
+		)
+	}
+	return reqs, nil
+}
+
+// existingBuildTool returns the build tool whose build file exists in
+// targetPath, or "" for a new project.
+func existingBuildTool(targetPath string) (string, error) {
+	for _, tool := range []string{buildMaven, buildGradle} {
+		for _, f := range buildFiles[tool] {
+			_, err := os.Stat(filepath.Join(targetPath, f))
+			if err == nil {
+				return tool, nil
+			}
+			if !os.IsNotExist(err) {
+				return "", fmt.Errorf("could not check for %s: %w", f, err)
+			}
+		}
+	}
+	return "", nil
+}
+
+// GetTasks returns the execution plan for initializing a Spring Boot project
+// at targetPath. The generation mode, build tool, Maven coordinates, versions
+// and dependencies are read from the metadata when the tasks execute.
 func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task, error) {
 	var plan []types.Task
 
@@ -40,95 +113,25 @@ func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task,
 		Path:     targetPath,
 		Desc:     fmt.Sprintf("Create project directory '%s'", targetPath),
 		TaskPrio: int(p), // Now 50
+		Schedule: types.Schedule{Resources: []string{targetPath}},
 	})
 
-	// Idempotency Check: Check for existence of pom.xml
-	pomXMLPath := filepath.Join(targetPath, "pom.xml")
-	_, checkErr := os.Stat(pomXMLPath)
-
-	if os.IsNotExist(checkErr) {
-		// --- Path 1: pom.xml does NOT exist (Initialization) ---
-
-		// Determine project name from target path.
-		projectName := filepath.Base(targetPath)
-
-		// URL-encode project name for query parameters.
-		encodedName := url.QueryEscape(projectName)
-
-		// Sanitize package name for Java conventions.
-		sanitizedPackage := strings.ReplaceAll(projectName, "-", "")
-		sanitizedPackage = strings.ReplaceAll(sanitizedPackage, " ", "")
-
-		// Construct Spring Initializr URL.
-		zipURL := fmt.Sprintf(
-			"https://start.spring.io/starter.zip?type=maven-project&language=java&groupId=com.example&artifactId=%s&name=%s&packageName=com.example.%s&packaging=jar&javaVersion=17&dependencies=web,lombok,actuator",
-			encodedName, encodedName, sanitizedPackage,
-		)
-
-		const zipFile = "spring-init.zip"
+	// Idempotency Check: Check for an existing Maven or Gradle build
+	existing, err := existingBuildTool(targetPath)
+	if err != nil {
+		return nil, err
+	}
 
-		// Task 1: Download Spring Boot starter zip
+	if existing == "" {
+		// --- Path 1: no build file exists (Initialization) ---
+		// Task 1: Generate the Maven or Gradle project (embedded templates or start.spring.io)
 		p, err := langSeq.Next()
 		if err != nil {
 			return nil, err
 		}
-		plan = append(plan, &tasks.ExecCommandTask{
-			Cmd:         "curl",
-			Args:        []string{"-f", "-sS", "-o", zipFile, zipURL},
-			Desc:        fmt.Sprintf("Download Spring Boot starter for '%s'", projectName),
-			TaskPrio:    int(p), // Now 100
-			RunInTarget: true,
-		})
-
-		// Task 2: Extract the zip, preserving directory structure
-		p, err = langSeq.Next()
-		if err != nil {
-			return nil, err
-		}
-		plan = append(plan, &tasks.ExecCommandTask{
-			Cmd: "unzip",
-			Args: []string{
-				"-q",
-				"-o",
-				zipFile,
-			},
-			Desc:        "Extract project files",
-			TaskPrio:    int(p), // Now 101
-			RunInTarget: true,
-		})
-
-		// Task 3: Remove the zip file after extraction.
-		p, err = langSeq.Next()
-		if err != nil {
-			return nil, err
-		}
-		plan = append(plan, &tasks.ExecCommandTask{
-			Cmd:         "rm",
-			Args:        []string{zipFile},
-			Desc:        "Cleanup initialization artifacts",
-			TaskPrio:    int(p), // Now 102
-			RunInTarget: true,
-		})
-
-		// Task 4: Make Maven wrapper executable
-		p, err = langSeq.Next()
-		if err != nil {
-			return nil, err
-		}
-		plan = append(plan, &tasks.ExecCommandTask{
-			Cmd:         "chmod",
-			Args:        []string{"+x", "mvnw"},
-			Desc:        "Make Maven wrapper executable",
-			TaskPrio:    int(p), // Now 103
-			RunInTarget: true,
-		})
-	} else if checkErr != nil {
-		// Path 2 (pom.xml *does* exist, checkErr == nil) now falls through to return plan, nil.
-		// If no initialization tasks were run, plan contains only the CreateDirTask.
-
-		// --- Path 3: Some other error ---
-		return nil, fmt.Errorf("could not check for pom.xml: %w", checkErr)
+		plan = append(plan, &projectTask{prio: int(p), targetPath: targetPath}) // Now 100
 	}
+	// --- Path 2: the project exists; the plan contains only the CreateDirTask.
 
 	return plan, nil
 }
@@ -4,6 +4,7 @@
 package spring
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,19 +14,19 @@ import (
 	"scbake/pkg/tasks"
 )
 
-// TestGetTasks_NewSpringProject validates the full initialization sequence.
+// TestGetTasks_NewSpringProject validates the initialization sequence.
 func TestGetTasks_NewSpringProject(t *testing.T) {
 	tempDir := t.TempDir()
 	handler := &Handler{}
-	projectName := "test-spring-app"
-	targetPath := filepath.Join(tempDir, projectName)
+	targetPath := filepath.Join(tempDir, "test-spring-app")
 
 	plan := getPlanOrFail(t, handler, targetPath)
 
-	assertPlanLength(t, plan, 5)
+	assertPlanLength(t, plan, 2)
 	assertCreateDirTask(t, plan[0], targetPath)
-	assertCurlTask(t, plan[1], projectName)
-	assertChmodTask(t, plan[4])
+	if _, ok := plan[1].(*projectTask); !ok {
+		t.Fatalf("Second task should generate the project, got %T", plan[1])
+	}
 }
 
 func getPlanOrFail(t *testing.T, h *Handler, path string) []types.Task {
@@ -52,46 +53,127 @@ func assertCreateDirTask(t *testing.T, task types.Task, expectedPath string) {
 	}
 }
 
-func assertCurlTask(t *testing.T, task types.Task, projectName string) {
-	t.Helper()
-	curlTask, ok := task.(*tasks.ExecCommandTask)
-	if !ok || curlTask.Cmd != "curl" {
-		t.Fatal("Second task should be 'curl'")
+// TestProjectSettings checks the schema defaults and metadata overrides.
+func TestProjectSettings(t *testing.T) {
+	s, err := projectSettings(nil, "/work/Billing Api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Mode != modeEmbedded || s.GroupID != "com.example" || s.ArtifactID != "billing-api" ||
+		s.JavaVersion != "17" || strings.Join(s.DependencyIDs, ",") != "web,lombok,actuator" {
+		t.Errorf("Unexpected defaults: %+v", s)
+	}
+	if s.Package() != "com.example.billingapi" || s.ClassName() != "BillingApiApplication" {
+		t.Errorf("Unexpected names: %s, %s", s.Package(), s.ClassName())
 	}
 
-	urlArg := findSpringURL(t, curlTask.Args)
-	assertSpringURL(t, urlArg, projectName)
-}
+	s, err = projectSettings(map[string]string{
+		"group_id":     "org.acme",
+		"artifact_id":  "2fa",
+		"java_version": "21",
+		"dependencies": "h2",
+	}, "/work/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Package() != "org.acme.app2fa" || s.ClassName() != "App2faApplication" || s.JavaVersion != "21" {
+		t.Errorf("Unexpected settings: %+v, %s, %s", s, s.Package(), s.ClassName())
+	}
 
-func findSpringURL(t *testing.T, args []string) string {
-	t.Helper()
-	for _, arg := range args {
-		if strings.Contains(arg, "https://start.spring.io") {
-			return arg
-		}
+	if _, err := projectSettings(map[string]string{"dependencies": "web,kafka"}, "/work/app"); err == nil ||
+		!strings.Contains(err.Error(), `unknown Spring dependency "kafka"`) {
+		t.Errorf("Expected unknown dependency error, got %v", err)
 	}
-	t.Fatal("Curl task missing the Spring Initializr URL")
-	return ""
 }
 
-func assertSpringURL(t *testing.T, urlArg, projectName string) {
-	t.Helper()
+// TestProjectTask_Embedded renders the offline project and checks the build files and sources.
+func TestProjectTask_Embedded(t *testing.T) {
+	dir := t.TempDir()
+	tc := types.TaskContext{
+		Ctx:        context.Background(),
+		TargetPath: dir,
+		Manifest: &types.Manifest{Metadata: map[string]string{
+			"group_id":     "org.acme",
+			"artifact_id":  "billing-api",
+			"dependencies": "lombok,h2",
+		}},
+	}
+	if err := (&projectTask{prio: 100}).Execute(tc); err != nil {
+		t.Fatal(err)
+	}
+
+	pom := readFile(t, filepath.Join(dir, "pom.xml"))
+	for _, want := range []string{
+		"<groupId>org.acme</groupId>",
+		"<artifactId>billing-api</artifactId>",
+		"<java.version>17</java.version>",
+		"<artifactId>spring-boot-starter</artifactId>",
+		"<artifactId>h2</artifactId>\n\t\t\t<scope>runtime</scope>",
+		"<annotationProcessorPaths>",
+	} {
+		if !strings.Contains(pom, want) {
+			t.Errorf("pom.xml missing %q:\n%s", want, pom)
+		}
+	}
+	if strings.Contains(pom, "spring-boot-starter-web") {
+		t.Errorf("pom.xml should only contain the selected dependencies:\n%s", pom)
+	}
 
-	if !strings.Contains(urlArg, "artifactId="+projectName) {
-		t.Errorf("URL missing correct artifactId. URL: %s", urlArg)
+	app := readFile(t, filepath.Join(dir, "src", "main", "java", "org", "acme", "billingapi", "BillingApiApplication.java"))
+	if !strings.Contains(app, "package org.acme.billingapi;") || !strings.Contains(app, "SpringApplication.run(BillingApiApplication.class, args);") {
+		t.Errorf("Unexpected application class:\n%s", app)
+	}
+	readFile(t, filepath.Join(dir, "src", "test", "java", "org", "acme", "billingapi", "BillingApiApplicationTests.java"))
+	readFile(t, filepath.Join(dir, ".gitignore"))
+	if props := readFile(t, filepath.Join(dir, "src", "main", "resources", "application.properties")); props != "spring.application.name=billing-api\n" {
+		t.Errorf("Unexpected application.properties: %q", props)
+	}
+	if !strings.Contains(readFile(t, filepath.Join(dir, ".mvn", "wrapper", "maven-wrapper.properties")), "distributionUrl=") {
+		t.Error("Maven wrapper properties missing distributionUrl")
 	}
 
-	expectedPkg := "com.example.testspringapp"
-	if !strings.Contains(urlArg, "packageName="+expectedPkg) {
-		t.Errorf("URL missing sanitized package name. URL: %s", urlArg)
+	info, err := os.Stat(filepath.Join(dir, "mvnw"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&0o100 == 0 {
+		t.Errorf("mvnw should be executable, mode %v", info.Mode())
 	}
 }
 
-func assertChmodTask(t *testing.T, task types.Task) {
+func readFile(t *testing.T, path string) string {
 	t.Helper()
-	chmodTask, ok := task.(*tasks.ExecCommandTask)
-	if !ok || chmodTask.Cmd != "chmod" {
-		t.Error("Final task should be 'chmod'")
+	data, err := os.ReadFile(path) //nolint:gosec // Test reads files it generated
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// TestInitializrURL checks that initializr mode requests the configured project.
+func TestInitializrURL(t *testing.T) {
+	s, err := projectSettings(map[string]string{"spring_mode": modeInitializr, "java_version": "21"}, "/work/test-spring-app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := s.initializrURL()
+	for _, want := range []string{
+		"https://start.spring.io/starter.zip?",
+		"artifactId=test-spring-app",
+		"packageName=com.example.testspringapp",
+		"javaVersion=21",
+		"bootVersion=3.3.5",
+		"dependencies=web%2Clombok%2Cactuator",
+		"type=maven-project",
+	} {
+		if !strings.Contains(u, want) {
+			t.Errorf("URL missing %q: %s", want, u)
+		}
+	}
+
+	s.BuildTool = buildGradle
+	if u := s.initializrURL(); !strings.Contains(u, "type=gradle-project-kotlin") {
+		t.Errorf("Gradle projects should request the Kotlin DSL: %s", u)
 	}
 }
 
@@ -115,6 +197,89 @@ func TestGetTasks_ExistingSpringProject(t *testing.T) {
 	}
 }
 
+// TestBuildTool checks that existing build files win over the build_tool variable.
+func TestBuildTool(t *testing.T) {
+	handler := &Handler{}
+	dir := t.TempDir()
+
+	for _, tc := range []struct {
+		metadata map[string]string
+		file     string
+		want     string
+	}{
+		{nil, "", buildMaven},
+		{map[string]string{"build_tool": buildGradle}, "", buildGradle},
+		{nil, "build.gradle.kts", buildGradle},
+		{map[string]string{"build_tool": buildGradle}, "pom.xml", buildMaven},
+	} {
+		target := dir
+		if tc.file != "" {
+			target = t.TempDir()
+			if err := os.WriteFile(filepath.Join(target, tc.file), nil, 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got, err := handler.BuildTool(target, tc.metadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("BuildTool(%q, %v) = %q, want %q", tc.file, tc.metadata, got, tc.want)
+		}
+	}
+
+	// An existing Gradle project is not initialized again.
+	gradleDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(gradleDir, "build.gradle"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	assertPlanLength(t, getPlanOrFail(t, handler, gradleDir), 1)
+}
+
+// TestProjectTask_Gradle renders the Gradle build using the recorded build tool.
+func TestProjectTask_Gradle(t *testing.T) {
+	dir := t.TempDir()
+	tc := types.TaskContext{
+		Ctx:        context.Background(),
+		TargetPath: dir,
+		Manifest:   &types.Manifest{Metadata: map[string]string{"dependencies": "web,lombok,h2", "java_version": "21"}},
+		Project:    &types.Project{Name: "inventory", Path: ".", Language: "spring", BuildTool: buildGradle},
+	}
+	if err := (&projectTask{prio: 100}).Execute(tc); err != nil {
+		t.Fatal(err)
+	}
+
+	build := readFile(t, filepath.Join(dir, "build.gradle.kts"))
+	for _, want := range []string{
+		`id("org.springframework.boot") version "3.3.5"`,
+		`group = "com.example"`,
+		"JavaLanguageVersion.of(21)",
+		`implementation("org.springframework.boot:spring-boot-starter-web")`,
+		`compileOnly("org.projectlombok:lombok")`,
+		`annotationProcessor("org.projectlombok:lombok")`,
+		`runtimeOnly("com.h2database:h2")`,
+	} {
+		if !strings.Contains(build, want) {
+			t.Errorf("build.gradle.kts missing %q:\n%s", want, build)
+		}
+	}
+	name := filepath.Base(dir)
+	if settings := readFile(t, filepath.Join(dir, "settings.gradle.kts")); settings != "rootProject.name = \""+name+"\"\n" {
+		t.Errorf("Unexpected settings.gradle.kts: %q", settings)
+	}
+	if !strings.Contains(readFile(t, filepath.Join(dir, ".gitignore")), ".gradle/") {
+		t.Error(".gitignore should ignore Gradle output")
+	}
+	for _, absent := range []string{"pom.xml", "mvnw"} {
+		if _, err := os.Stat(filepath.Join(dir, absent)); !os.IsNotExist(err) {
+			t.Errorf("%s should not be created for Gradle projects", absent)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(dir, "gradlew")); err != nil || info.Mode()&0o100 == 0 {
+		t.Errorf("gradlew should exist and be executable: %v", err)
+	}
+}
+
 // TestSpringPriorityBands ensures the sequence respects the priority architecture.
 func TestSpringPriorityBands(t *testing.T) {
 	handler := &Handler{}
@@ -142,3 +307,34 @@ func assertPriorityBand(t *testing.T, index int, prio int) {
 		t.Errorf("Lang task %d priority %d out of band", index, prio)
 	}
 }
+
+// TestRequirements checks the Java release and the initializr binaries.
+func TestRequirements(t *testing.T) {
+	dir := t.TempDir()
+	h := &Handler{}
+
+	reqs, err := h.Requirements(dir, map[string]string{"java_version": "21"})
+	if err != nil || len(reqs) != 1 || reqs[0].Binary != "java" || reqs[0].Constraint != ">=21" {
+		t.Fatalf("Unexpected requirements: %+v, %v", reqs, err)
+	}
+
+	reqs, err = h.Requirements(dir, map[string]string{"spring_mode": modeInitializr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var binaries []string
+	for _, r := range reqs {
+		binaries = append(binaries, r.Binary)
+	}
+	if strings.Join(binaries, " ") != "java curl unzip" {
+		t.Errorf("Expected java, curl and unzip in initializr mode, got %v", binaries)
+	}
+
+	// An existing project declares its own Java release.
+	if err := os.WriteFile(filepath.Join(dir, "pom.xml"), []byte("<project/>\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if reqs, _ = h.Requirements(dir, map[string]string{"java_version": "21"}); len(reqs) != 1 || reqs[0].Constraint != "" {
+		t.Errorf("Expected java without a constraint for an existing project, got %+v", reqs)
+	}
+}
@@ -0,0 +1,25 @@
+{
+  "name": {{toJson .Name}},
+  "private": true,
+  "version": "0.0.0",
+  "type": "module",
+  "scripts": {
+    "dev": "vite",
+    "build": "vite build",
+    "preview": "vite preview"{{if .TypeScript}},
+    "check": "svelte-check --tsconfig ./tsconfig.json && tsc -p tsconfig.node.json"{{end}}
+  },
+  "devDependencies": {
+    "@sveltejs/vite-plugin-svelte": "^4.0.0",
+{{- if .TypeScript}}
+    "@tsconfig/svelte": "^5.0.4",
+{{- end}}
+    "svelte": "^5.2.0",
+{{- if .TypeScript}}
+    "svelte-check": "^4.0.5",
+    "tslib": "^2.8.0",
+    "typescript": "~5.6.2",
+{{- end}}
+    "vite": "^5.4.10"
+  }
+}
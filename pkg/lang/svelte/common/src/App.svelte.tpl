@@ -0,0 +1,15 @@
+<script{{if .TypeScript}} lang="ts"{{end}}>
+  import Counter from './lib/Counter.svelte'
+</script>
+
+<main>
+  <h1>{{.Name}}</h1>
+
+  <div class="card">
+    <Counter />
+  </div>
+
+  <p>
+    Edit <code>src/App.svelte</code> and save to reload.
+  </p>
+</main>
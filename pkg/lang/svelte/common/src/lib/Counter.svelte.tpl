@@ -0,0 +1,10 @@
+<script{{if .TypeScript}} lang="ts"{{end}}>
+  let count{{if .TypeScript}}: number{{end}} = $state(0)
+  const increment = () => {
+    count += 1
+  }
+</script>
+
+<button onclick={increment}>
+  count is {count}
+</button>
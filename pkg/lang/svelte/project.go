@@ -0,0 +1,149 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package svelte
+
+import (
+	"fmt"
+	"scbake/internal/schema"
+	"scbake/internal/types"
+	"scbake/internal/util"
+	"scbake/pkg/tasks"
+)
+
+// Generation modes (the svelte_mode variable).
+const (
+	modeEmbedded = "embedded"
+	modeCreate   = "create"
+)
+
+// settings are the resolved project settings.
+type settings struct {
+	Mode       string
+	TypeScript bool
+}
+
+// projectSettings resolves the settings from the validated metadata,
+// falling back to the schema defaults.
+func projectSettings(metadata map[string]string) (*settings, error) {
+	mode, err := setting(metadata, "svelte_mode")
+	if err != nil {
+		return nil, err
+	}
+	ts, err := setting(metadata, "typescript")
+	if err != nil {
+		return nil, err
+	}
+	return &settings{Mode: mode, TypeScript: ts == "true"}, nil
+}
+
+// setting returns a metadata value, falling back to its schema default.
+func setting(metadata map[string]string, name string) (string, error) {
+	if v := metadata[name]; v != "" {
+		return v, nil
+	}
+	s, err := schema.ReadSchema(templates, "schema.json")
+	if err != nil {
+		return "", err
+	}
+	def, ok := s.Variables[name]
+	if !ok || def.Default == nil {
+		return "", fmt.Errorf("schema.json declares no %s default", name)
+	}
+	return *def.Default, nil
+}
+
+// templateData is what the Svelte pack's templates are rendered with: the
+// standard template context plus the package name and the resolved settings.
+type templateData struct {
+	*tasks.TemplateContext
+	*settings
+	Name string // Package name, e.g. billing-ui.
+}
+
+// projectTask generates the project in the mode selected by the svelte_mode
+// variable. The settings come from the validated metadata, which is only
+// known when the plan executes.
+type projectTask struct {
+	prio       int
+	targetPath string
+}
+
+func (t *projectTask) Description() string { return "Generate Svelte project" }
+func (t *projectTask) Priority() int       { return t.prio }
+func (t *projectTask) Scheduling() types.Schedule {
+	return types.Schedule{Resources: []string{t.targetPath}}
+}
+
+func (t *projectTask) Execute(tc types.TaskContext) error {
+	s, err := projectSettings(tc.Metadata())
+	if err != nil {
+		return err
+	}
+
+	switch s.Mode {
+	case modeEmbedded:
+		return t.embedded(tc, s)
+	case modeCreate:
+		return t.create(tc, s)
+	default:
+		return fmt.Errorf("unknown Svelte mode %q (expected %s or %s)", s.Mode, modeEmbedded, modeCreate)
+	}
+}
+
+// embedded renders the pinned Vite + Svelte skeleton: the shared files from
+// common/ and the JavaScript or TypeScript entry point and configuration
+// from js/ or ts/. Every file resolves through --template-dir and the
+// registry cache first and is tracked in ManagedFiles.
+func (t *projectTask) embedded(tc types.TaskContext, s *settings) error {
+	name, err := util.SanitizeModuleName(tc.TargetPath)
+	if err != nil {
+		return fmt.Errorf("could not determine package name: %w", err)
+	}
+	contextData := func(ctx *tasks.TemplateContext) (interface{}, error) {
+		return &templateData{TemplateContext: ctx, settings: s, Name: name}, nil
+	}
+
+	variant := "js"
+	if s.TypeScript {
+		variant = "ts"
+	}
+	for _, root := range []string{"common", variant} {
+		tree := &tasks.CreateTreeTask{
+			TemplateFS:  templates,
+			Root:        root,
+			TaskPrio:    t.prio,
+			ContextData: contextData,
+		}
+		if err := tree.Execute(tc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// create runs 'npm create vite@latest' and sets the standard scripts, so
+// 'npm run build' works for the Makefile even if the upstream template
+// changes its default script names. The output follows the latest Vite
+// release and is not tracked in ManagedFiles.
+func (t *projectTask) create(tc types.TaskContext, s *settings) error {
+	template := "svelte"
+	scripts := []string{"scripts.dev=vite", "scripts.build=vite build", "scripts.preview=vite preview"}
+	if s.TypeScript {
+		// Only the TypeScript template has a tsconfig for svelte-check.
+		template = "svelte-ts"
+		scripts = append(scripts, "scripts.check=svelte-check")
+	}
+
+	steps := []*tasks.ExecCommandTask{
+		// '.' creates the project in the target directory, '--' bypasses prompts.
+		{Cmd: "npm", Args: []string{"create", "vite@latest", ".", "--", "--template", template}, RunInTarget: true},
+		{Cmd: "npm", Args: append([]string{"pkg", "set"}, scripts...), RunInTarget: true},
+	}
+	for _, step := range steps {
+		if err := step.Execute(tc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
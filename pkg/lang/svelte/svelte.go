@@ -4,17 +4,70 @@
 package svelte
 
 import (
+	"embed"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"scbake/internal/preflight"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
 )
 
+//go:embed schema.json all:common js ts
+var templates embed.FS
+
 // Handler implements the lang.Handler interface for Svelte projects.
 type Handler struct{}
 
+// SchemaFS returns the embedded filesystem containing schema.json.
+func (h *Handler) SchemaFS() fs.FS { return templates }
+
+// SchemaPath returns the path to the embedded schema definition.
+func (h *Handler) SchemaPath() string { return "schema.json" }
+
+// minNodeVersion is the oldest Node.js release the pinned Vite supports.
+const minNodeVersion = "18"
+
+// Requirements returns npm and Node.js when they run: in create mode and
+// for npm install of a new project. The embedded skeleton is rendered
+// without them.
+func (h *Handler) Requirements(targetPath string, metadata map[string]string) ([]preflight.Requirement, error) {
+	_, err := os.Stat(filepath.Join(targetPath, "package.json"))
+	if err == nil {
+		return nil, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to check for existing Svelte project: %w", err)
+	}
+
+	s, err := projectSettings(metadata)
+	if err != nil {
+		return nil, err
+	}
+	install, err := setting(metadata, "install")
+	if err != nil {
+		return nil, err
+	}
+
+	var reason string
+	switch {
+	case s.Mode == modeCreate:
+		reason = "svelte_mode = " + modeCreate
+	case install != "false":
+		reason = "install = " + install
+	default:
+		return nil, nil
+	}
+	return []preflight.Requirement{
+		{Binary: "node", Constraint: ">=" + minNodeVersion, Reason: reason},
+		{Binary: "npm", Reason: reason},
+	}, nil
+}
+
 // GetTasks returns the list of tasks required to set up a Svelte project.
+// The generation mode, the typescript switch and the install switch are read
+// from the metadata when the tasks execute.
 func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task, error) {
 	var plan []types.Task
 
@@ -39,66 +92,34 @@ func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task,
 		Path:     targetPath,
 		Desc:     fmt.Sprintf("Create project directory '%s'", targetPath),
 		TaskPrio: int(p), // Now 50
+		Schedule: types.Schedule{Resources: []string{targetPath}},
 	})
 
 	packageJSONPath := filepath.Join(targetPath, "package.json")
 	_, checkErr := os.Stat(packageJSONPath)
 
 	if os.IsNotExist(checkErr) {
-		// Task 1: Run 'npm create vite@latest .' inside the target directory.
-		// This initializes the project into the existing (empty) directory.
+		// Task 1: Generate the project (embedded skeleton or npm create vite)
 		p, err := langSeq.Next()
 		if err != nil {
 			return nil, err
 		}
-		plan = append(plan, &tasks.ExecCommandTask{
-			Cmd: "npm",
-			Args: []string{
-				"create",
-				"vite@latest",
-				".",  // Use '.' to create project in the CWD
-				"--", // Bypasses prompts
-				"--template",
-				"svelte",
-			},
-			Desc:        "Run npm create vite@latest .",
-			TaskPrio:    int(p), // Now 100
-			RunInTarget: true,   // Runs inside targetPath
-		})
-
-		// Task 2: Run 'npm install'
-		p, err = langSeq.Next()
-		if err != nil {
-			return nil, err
-		}
-		plan = append(plan, &tasks.ExecCommandTask{
-			Cmd:         "npm",
-			Args:        []string{"install"},
-			Desc:        "Run npm install",
-			TaskPrio:    int(p), // Now 101
-			RunInTarget: true,
-		})
+		plan = append(plan, &projectTask{prio: int(p), targetPath: targetPath}) // Now 100
 
-		// Task 3: Explicitly set standard NPM scripts.
-		// This guarantees 'npm run build' works for the Makefile, even if the
-		// upstream template changes its default script names.
+		// Task 2: Run 'npm install' unless install=false
 		p, err = langSeq.Next()
 		if err != nil {
 			return nil, err
 		}
 		plan = append(plan, &tasks.ExecCommandTask{
-			Cmd: "npm",
-			Args: []string{
-				"pkg",
-				"set",
-				"scripts.dev=vite",
-				"scripts.build=vite build",
-				"scripts.preview=vite preview",
-				"scripts.check=svelte-check --tsconfig ./tsconfig.json",
-			},
-			Desc:        "Ensure standard NPM scripts are set",
-			TaskPrio:    int(p), // Now 102
-			RunInTarget: true,
+			Cmd:              "npm",
+			Args:             []string{"install"},
+			Desc:             "Run npm install",
+			TaskPrio:         int(p), // Now 101
+			RunInTarget:      true,
+			PredictedCreated: []string{"node_modules", "package-lock.json"},
+			When:             `ne .Metadata.install "false"`,
+			Schedule:         types.Schedule{Resources: []string{targetPath}},
 		})
 	} else if checkErr != nil {
 		return nil, fmt.Errorf("failed to check for existing Svelte project: %w", checkErr)
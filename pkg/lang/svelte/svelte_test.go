@@ -4,10 +4,13 @@
 package svelte
 
 import (
+	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
+	"strings"
 	"testing"
 )
 
@@ -20,8 +23,10 @@ func TestGetTasks_NewSvelteProject(t *testing.T) {
 	plan := getPlanOrFail(t, handler, targetPath)
 	assertNewProjectPlanLength(t, plan)
 	assertCreateDirTask(t, plan[0], targetPath)
-	assertViteInitTask(t, plan[1])
-	assertScriptSetupTask(t, plan[3])
+	if _, ok := plan[1].(*projectTask); !ok {
+		t.Fatalf("Second task should generate the project, got %T", plan[1])
+	}
+	assertInstallTask(t, plan[2])
 }
 
 func getPlanOrFail(t *testing.T, h *Handler, path string) []types.Task {
@@ -35,7 +40,7 @@ func getPlanOrFail(t *testing.T, h *Handler, path string) []types.Task {
 
 func assertNewProjectPlanLength(t *testing.T, plan []types.Task) {
 	t.Helper()
-	const expected = 4
+	const expected = 3
 	if len(plan) != expected {
 		t.Fatalf("Expected %d tasks, got %d", expected, len(plan))
 	}
@@ -49,27 +54,99 @@ func assertCreateDirTask(t *testing.T, task types.Task, expectedPath string) {
 	}
 }
 
-func assertViteInitTask(t *testing.T, task types.Task) {
+func assertInstallTask(t *testing.T, task types.Task) {
 	t.Helper()
-	viteTask, ok := task.(*tasks.ExecCommandTask)
-	if !ok || viteTask.Cmd != "npm" {
-		t.Fatal("Second task should be 'npm create'")
+	installTask, ok := task.(*tasks.ExecCommandTask)
+	if !ok || installTask.Cmd != "npm" || !contains(installTask.Args, "install") {
+		t.Fatal("Final task should be 'npm install'")
+	}
+	if !installTask.RunInTarget {
+		t.Fatal("npm install must run in target directory")
 	}
 
-	if !viteTask.RunInTarget {
-		t.Fatal("Vite command must run in target directory")
+	// Installing is the default; install=false keeps scaffolding offline.
+	for value, want := range map[string]bool{"": true, "true": true, "false": false} {
+		m := &types.Manifest{Metadata: map[string]string{"install": value}}
+		got, err := tasks.EvalCondition(installTask.When, m)
+		if err != nil {
+			t.Fatalf("EvalCondition failed: %v", err)
+		}
+		if got != want {
+			t.Errorf("install=%q: got %v, want %v", value, got, want)
+		}
 	}
+}
 
-	if !contains(viteTask.Args, "svelte") {
-		t.Fatal("Vite command missing 'svelte' template flag")
+// TestProjectTask_Embedded renders the JavaScript and TypeScript skeletons.
+func TestProjectTask_Embedded(t *testing.T) {
+	for _, tc := range []struct {
+		typescript   string
+		files        []string
+		missing      []string
+		checkScript  bool
+		mainLanguage string
+	}{
+		{"false", []string{"vite.config.js", "jsconfig.json", "src/main.js"}, []string{"tsconfig.json", "src/main.ts"}, false, "js"},
+		{"true", []string{"vite.config.ts", "tsconfig.json", "tsconfig.node.json", "src/main.ts", "src/vite-env.d.ts"}, []string{"jsconfig.json", "src/main.js"}, true, "ts"},
+	} {
+		t.Run("typescript="+tc.typescript, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "Billing UI")
+			taskCtx := types.TaskContext{
+				Ctx:        context.Background(),
+				TargetPath: dir,
+				Manifest:   &types.Manifest{Metadata: map[string]string{"typescript": tc.typescript}},
+			}
+			if err := (&projectTask{prio: 100}).Execute(taskCtx); err != nil {
+				t.Fatal(err)
+			}
+
+			var pkg struct {
+				Name    string            `json:"name"`
+				Scripts map[string]string `json:"scripts"`
+			}
+			if err := json.Unmarshal([]byte(readFile(t, filepath.Join(dir, "package.json"))), &pkg); err != nil {
+				t.Fatalf("package.json is not valid JSON: %v", err)
+			}
+			if pkg.Name != "billing-ui" || pkg.Scripts["build"] != "vite build" {
+				t.Errorf("Unexpected package.json: %+v", pkg)
+			}
+			if _, ok := pkg.Scripts["check"]; ok != tc.checkScript {
+				t.Errorf("check script present: %v, want %v", ok, tc.checkScript)
+			}
+
+			for _, f := range append([]string{"index.html", "svelte.config.js", ".gitignore", "src/App.svelte", "src/app.css", "src/lib/Counter.svelte"}, tc.files...) {
+				readFile(t, filepath.Join(dir, filepath.FromSlash(f)))
+			}
+			for _, f := range tc.missing {
+				if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(f))); !os.IsNotExist(err) {
+					t.Errorf("%s should not exist", f)
+				}
+			}
+			if html := readFile(t, filepath.Join(dir, "index.html")); !strings.Contains(html, "/src/main."+tc.mainLanguage) {
+				t.Errorf("index.html should load main.%s:\n%s", tc.mainLanguage, html)
+			}
+		})
 	}
 }
 
-func assertScriptSetupTask(t *testing.T, task types.Task) {
+func readFile(t *testing.T, path string) string {
 	t.Helper()
-	pkgTask, ok := task.(*tasks.ExecCommandTask)
-	if !ok || len(pkgTask.Args) == 0 || pkgTask.Args[0] != "pkg" {
-		t.Fatalf("Final task should be 'npm pkg set', got %v", pkgTask)
+	data, err := os.ReadFile(path) //nolint:gosec // Test reads files it generated
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// TestProjectTask_UnknownMode checks that an invalid mode is reported.
+func TestProjectTask_UnknownMode(t *testing.T) {
+	tc := types.TaskContext{
+		TargetPath: t.TempDir(),
+		Manifest:   &types.Manifest{Metadata: map[string]string{"svelte_mode": "degit"}},
+	}
+	err := (&projectTask{prio: 100}).Execute(tc)
+	if err == nil || !strings.Contains(err.Error(), `unknown Svelte mode "degit"`) {
+		t.Errorf("Expected unknown mode error, got %v", err)
 	}
 }
 
@@ -129,3 +206,22 @@ func contains(slice []string, val string) bool {
 	}
 	return false
 }
+
+// TestRequirements checks that npm and Node.js are only required when they run.
+func TestRequirements(t *testing.T) {
+	dir := t.TempDir()
+	h := &Handler{}
+
+	if reqs, err := h.Requirements(dir, map[string]string{"install": "false"}); err != nil || len(reqs) != 0 {
+		t.Errorf("Expected no requirements for the embedded skeleton without install, got %+v, %v", reqs, err)
+	}
+	for _, metadata := range []map[string]string{
+		nil,
+		{"svelte_mode": modeCreate, "install": "false"},
+	} {
+		reqs, err := h.Requirements(dir, metadata)
+		if err != nil || len(reqs) != 2 || reqs[0].Binary != "node" || reqs[0].Constraint != ">="+minNodeVersion || reqs[1].Binary != "npm" {
+			t.Errorf("%v: unexpected requirements %+v, %v", metadata, reqs, err)
+		}
+	}
+}
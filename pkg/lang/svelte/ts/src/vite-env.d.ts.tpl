@@ -0,0 +1,2 @@
+/// <reference types="svelte" />
+/// <reference types="vite/client" />
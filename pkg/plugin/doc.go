@@ -0,0 +1,49 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+// Package plugin runs handlers implemented as external executables, so
+// templates and languages can be written in any language (Python, Rust, ...)
+// without giving up scbake's rollback guarantees.
+//
+// A plugin is an executable named "scbake-plugin-<name>" found in the user's
+// plugins directory (~/.config/scbake/plugins) or on PATH. Only commands that
+// look up languages and templates discover plugins. scbake runs a plugin once
+// per request, writes a single JSON request to its stdin and reads a single
+// JSON response from its stdout. Anything the plugin writes to stderr is shown
+// to the user.
+//
+// Every request and response carries the protocol version:
+//
+//	→ {"protocol": 1, "method": "describe"}
+//	← {"protocol": 1, "name": "hello", "kind": "template", "band": "config",
+//	   "description": "Says hello", "schema": {"variables": {...}}}
+//
+//	→ {"protocol": 1, "method": "tasks", "target_path": "/abs/project",
+//	   "metadata": {"greeting": "Hello", "license": "MIT"}}
+//	← {"protocol": 1, "tasks": [
+//	     {"type": "create", "path": "HELLO.md", "content": "# Hello\n"},
+//	     {"type": "append", "path": ".gitignore", "content": "*.log\n"},
+//	     {"type": "merge", "path": "pom.xml", "element_path": "/project/build/plugins",
+//	      "content": "<plugin>...</plugin>"},
+//	     {"type": "exec", "cmd": "npm", "args": ["install"],
+//	      "predicted_created": ["node_modules"], "when": "has_language \"svelte\""}
+//	   ]}
+//
+// The metadata of a tasks request is the run's metadata, including --set
+// values, with the defaults of the plugin's schema filled in.
+//
+// Tasks may also carry "id", "after" and "resources" (relative to the target
+// path) to take part in parallel scheduling (see types.Schedule).
+//
+// A response with a non-empty "error" field fails the request. The kind is
+// "template" (the default) or "lang"; templates run in the named band
+// ("config" by default, see declarative.BandRange), languages in the language
+// setup band and may list the "binaries" they need on PATH. The name must
+// match the executable's suffix.
+//
+// Plugins never touch the filesystem themselves: they return task
+// descriptions, which are turned into ordinary tasks (tasks.ManagedFile,
+// tasks.AppendFileTask, tasks.InsertXMLTask, tasks.ExecCommandTask) and run by
+// the engine inside the transaction. Paths are relative to the target
+// directory and may not escape it.
+package plugin
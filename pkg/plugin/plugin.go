@@ -0,0 +1,257 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"scbake/internal/schema"
+	"scbake/internal/types"
+	"scbake/pkg/declarative"
+	"sort"
+	"strings"
+	"testing/fstest"
+	"time"
+)
+
+// Prefix is the executable name prefix that marks a plugin.
+const Prefix = "scbake-plugin-"
+
+// schemaFile is the path of the schema in SchemaFS.
+const schemaFile = "schema.json"
+
+// Timeout bounds a single plugin request.
+var Timeout = 30 * time.Second
+
+// Plugin is a handler backed by an external executable.
+// It implements templates.Handler, lang.Handler and their SchemaProvider
+// interfaces, templates.DependencyProvider, lang.PrerequisitesProvider and
+// types.MetadataTaskProvider.
+type Plugin struct {
+	// Path is the plugin executable.
+	Path string
+
+	Description
+}
+
+// Load asks the executable at path to describe itself and validates the answer.
+func Load(path string) (*Plugin, error) {
+	p := &Plugin{Path: path}
+	if err := p.call(Request{Method: MethodDescribe}, &p.Description); err != nil {
+		return nil, err
+	}
+
+	if p.Kind == "" {
+		p.Kind = KindTemplate
+	}
+	if p.Kind == KindTemplate && p.Band == "" {
+		p.Band = "config"
+	}
+
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("invalid plugin %s: %w", path, err)
+	}
+	return p, nil
+}
+
+func (p *Plugin) validate() error {
+	if want := NameOf(p.Path); p.Name != want {
+		return fmt.Errorf("name %q does not match executable name %q", p.Name, want)
+	}
+	switch p.Kind {
+	case KindTemplate:
+		if _, _, err := declarative.BandRange(p.Band); err != nil {
+			return err
+		}
+	case KindLang:
+	default:
+		return fmt.Errorf("unknown kind %q (expected %s or %s)", p.Kind, KindTemplate, KindLang)
+	}
+	if len(p.Schema) > 0 && !json.Valid(p.Schema) {
+		return errors.New("schema is not valid JSON")
+	}
+	return nil
+}
+
+// GetTasks asks the plugin for the tasks for targetPath without metadata.
+func (p *Plugin) GetTasks(targetPath, templateDir, registryCacheDir string) ([]types.Task, error) {
+	return p.GetTasksWithMetadata(targetPath, templateDir, registryCacheDir, nil)
+}
+
+// GetTasksWithMetadata asks the plugin for the tasks for targetPath and
+// converts them. The request carries metadata with the defaults of the
+// plugin's schema filled in, so the plugin sees the values it declared.
+func (p *Plugin) GetTasksWithMetadata(targetPath, _, _ string, metadata map[string]string) ([]types.Task, error) {
+	base, limit := types.PrioLangSetup, types.MaxLangSetup
+	if p.Kind == KindTemplate {
+		var err error
+		if base, limit, err = declarative.BandRange(p.Band); err != nil {
+			return nil, err
+		}
+	}
+	seq, err := types.NewPrioritySequence(base, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create priority sequence: %w", err)
+	}
+
+	resolved, err := p.resolveMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp tasksResponse
+	if err := p.call(Request{Method: MethodTasks, TargetPath: targetPath, Metadata: resolved}, &resp); err != nil {
+		return nil, err
+	}
+
+	plan := make([]types.Task, 0, len(resp.Tasks))
+	for i, spec := range resp.Tasks {
+		prio, err := seq.Next()
+		if err != nil {
+			return nil, err
+		}
+		task, err := spec.task(int(prio), targetPath, p.Name)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %s: tasks[%d]: %w", p.Name, i, err)
+		}
+		plan = append(plan, task)
+	}
+	return plan, nil
+}
+
+// resolveMetadata returns a copy of metadata with the defaults of the
+// plugin's schema added for absent variables. Validation errors are left to
+// the schema check of the run.
+func (p *Plugin) resolveMetadata(metadata map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		resolved[k] = v
+	}
+	if len(p.Schema) == 0 {
+		return resolved, nil
+	}
+	s, err := schema.ReadSchema(p.SchemaFS(), schemaFile)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: %w", p.Name, err)
+	}
+	schema.Validate(s, resolved)
+	return resolved, nil
+}
+
+// SchemaFS returns an in-memory filesystem holding the described schema.
+func (p *Plugin) SchemaFS() fs.FS {
+	return fstest.MapFS{schemaFile: {Data: p.Schema}}
+}
+
+// SchemaPath returns the schema's path in SchemaFS, or "" if the plugin has none.
+func (p *Plugin) SchemaPath() string {
+	if len(p.Schema) == 0 {
+		return ""
+	}
+	return schemaFile
+}
+
+// RequiredBinaries returns the binaries the plugin described.
+func (p *Plugin) RequiredBinaries() []string { return p.Binaries }
+
+// Dependencies returns the relations the plugin described.
+func (p *Plugin) Dependencies() types.Dependencies {
+	return types.Dependencies{
+		Requires:           p.Requires,
+		Conflicts:          p.Conflicts,
+		Suggests:           p.Suggests,
+		RequiresLanguages:  p.RequiresLanguages,
+		ConflictsLanguages: p.ConflictsLanguages,
+		RequiresBuildTools: p.RequiresBuildTools,
+	}
+}
+
+// call runs the plugin with req on stdin and decodes its response into v.
+func (p *Plugin) call(req Request, v interface{}) error {
+	req.Protocol = ProtocolVersion
+	in, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", req.Method, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	var out bytes.Buffer
+	// G204: The executable was discovered by its plugin name prefix; running it is the point.
+	//nolint:gosec
+	cmd := exec.CommandContext(ctx, p.Path)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("plugin %s failed on %s: %w", p.Path, req.Method, err)
+	}
+
+	var h header
+	if err := json.Unmarshal(out.Bytes(), &h); err != nil {
+		return fmt.Errorf("plugin %s returned invalid JSON for %s: %w", p.Path, req.Method, err)
+	}
+	if h.Protocol != ProtocolVersion {
+		return fmt.Errorf("plugin %s speaks protocol %d, scbake speaks %d", p.Path, h.Protocol, ProtocolVersion)
+	}
+	if h.Error != "" {
+		return fmt.Errorf("plugin %s: %s", p.Path, h.Error)
+	}
+	if err := json.Unmarshal(out.Bytes(), v); err != nil {
+		return fmt.Errorf("plugin %s returned an invalid %s response: %w", p.Path, req.Method, err)
+	}
+	return nil
+}
+
+// NameOf returns the handler name of a plugin executable
+// ("scbake-plugin-hello.exe" → "hello").
+func NameOf(path string) string {
+	name := strings.TrimPrefix(filepath.Base(path), Prefix)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// Discover returns the plugin executables in dirs, sorted by name. As with
+// PATH lookups, the first directory providing a name wins.
+func Discover(dirs ...string) []string {
+	found := map[string]string{}
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		matches, _ := filepath.Glob(filepath.Join(dir, Prefix+"*"))
+		for _, m := range matches {
+			name := NameOf(m)
+			if _, ok := found[name]; ok || name == "" || !isExecutable(m) {
+				continue
+			}
+			found[name] = m
+		}
+	}
+
+	names := make([]string, 0, len(found))
+	for n := range found {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	paths := make([]string, len(names))
+	for i, n := range names {
+		paths[i] = found[n]
+	}
+	return paths
+}
+
+// isExecutable reports whether path is a regular file with an execute bit.
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular() && info.Mode()&0o111 != 0
+}
@@ -0,0 +1,221 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"scbake/internal/schema"
+	"scbake/internal/types"
+	"scbake/internal/util/fileutil"
+	"scbake/pkg/tasks"
+	"strings"
+	"testing"
+)
+
+// fakePlugin writes a shell plugin that answers describe and tasks with the
+// given JSON documents and returns its path. The last request is saved next
+// to it, with the suffix .request.
+func fakePlugin(t *testing.T, dir, name, describe, taskList string) string {
+	t.Helper()
+	script := "#!/bin/sh\nreq=$(cat)\nprintf '%s' \"$req\" > \"$0.request\"\ncase \"$req\" in\n" +
+		"*'\"describe\"'*) printf '%s\\n' '" + describe + "' ;;\n" +
+		"*'\"tasks\"'*) printf '%s\\n' '" + taskList + "' ;;\n" +
+		"esac\n"
+	path := filepath.Join(dir, Prefix+name)
+	if err := os.WriteFile(path, []byte(script), fileutil.ExecFilePerms); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const helloTasks = `{"protocol": 1, "tasks": [
+	{"type": "create", "path": "HELLO.md", "content": "# Hello\n"},
+	{"type": "append", "path": ".gitignore", "content": "*.log", "when": "has_language \"go\""},
+	{"type": "exec", "cmd": "true", "predicted_created": ["build"]}
+]}`
+
+func TestLoad(t *testing.T) {
+	path := fakePlugin(t, t.TempDir(), "hello",
+		`{"protocol": 1, "name": "hello", "band": "devenv", "schema": {"variables": {"greeting": {"type": "string", "default": "hi"}}}}`,
+		helloTasks)
+
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if p.Kind != KindTemplate || p.Band != "devenv" {
+		t.Errorf("Kind/Band = %q/%q, want template/devenv", p.Kind, p.Band)
+	}
+
+	s, err := schema.ReadSchema(p.SchemaFS(), p.SchemaPath())
+	if err != nil {
+		t.Fatalf("ReadSchema failed: %v", err)
+	}
+	metadata := map[string]string{}
+	if res := schema.Validate(s, metadata); res.HasErrors() || metadata["greeting"] != "hi" {
+		t.Errorf("schema should validate and fill defaults, got %v (%s)", metadata, res.Error())
+	}
+
+	plan, err := p.GetTasks("/repo", "", "")
+	if err != nil {
+		t.Fatalf("GetTasks failed: %v", err)
+	}
+	if len(plan) != 3 {
+		t.Fatalf("expected 3 tasks, got %d", len(plan))
+	}
+	for i, task := range plan {
+		if want := int(types.PrioDevEnv) + i; task.Priority() != want {
+			t.Errorf("task %d priority = %d, want %d", i, task.Priority(), want)
+		}
+	}
+	if c, ok := plan[1].(types.ConditionalTask); !ok || c.Condition() != `has_language "go"` {
+		t.Errorf("append task should carry its when condition, got %#v", plan[1])
+	}
+	if e, ok := plan[2].(*tasks.ExecCommandTask); !ok || !e.RunInTarget {
+		t.Errorf("exec task should run in the target, got %#v", plan[2])
+	}
+}
+
+// Verifies that the tasks request carries the run's metadata with the
+// defaults of the plugin's schema filled in.
+func TestGetTasksWithMetadata(t *testing.T) {
+	path := fakePlugin(t, t.TempDir(), "hello",
+		`{"protocol": 1, "name": "hello", "schema": {"variables": {"greeting": {"type": "string", "default": "hi"}}}}`,
+		helloTasks)
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	metadata := map[string]string{"owner": "acme"}
+	if _, err := p.GetTasksWithMetadata("/repo", "", "", metadata); err != nil {
+		t.Fatalf("GetTasksWithMetadata failed: %v", err)
+	}
+	//nolint:gosec // Test temp directory
+	req, err := os.ReadFile(path + ".request")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"metadata":{"greeting":"hi","owner":"acme"}`; !strings.Contains(string(req), want) {
+		t.Errorf("request = %s, want it to contain %s", req, want)
+	}
+	if len(metadata) != 1 {
+		t.Errorf("the caller's metadata should not be modified, got %v", metadata)
+	}
+}
+
+func TestCreateFileTask_WritesManagedFile(t *testing.T) {
+	path := fakePlugin(t, t.TempDir(), "hello", `{"protocol": 1, "name": "hello"}`, helloTasks)
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if p.SchemaPath() != "" {
+		t.Errorf("plugin without schema should have an empty SchemaPath, got %q", p.SchemaPath())
+	}
+
+	tmpDir := t.TempDir()
+	plan, err := p.GetTasks(tmpDir, "", "")
+	if err != nil {
+		t.Fatalf("GetTasks failed: %v", err)
+	}
+	tc := types.TaskContext{TargetPath: tmpDir, Manifest: &types.Manifest{}}
+	if err := plan[0].Execute(tc); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	//nolint:gosec // Test temp directory
+	content, err := os.ReadFile(filepath.Join(tmpDir, "HELLO.md"))
+	if err != nil || string(content) != "# Hello\n" {
+		t.Errorf("HELLO.md = %q (err: %v)", content, err)
+	}
+	if _, ok := tc.Manifest.ManagedFiles["HELLO.md"]; !ok {
+		t.Error("HELLO.md should be recorded as a managed file")
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	for name, tc := range map[string]struct{ describe, tasks, want string }{
+		"version":  {`{"protocol": 2, "name": "version"}`, "", "speaks protocol 2"},
+		"error":    {`{"protocol": 1, "error": "boom"}`, "", "boom"},
+		"mismatch": {`{"protocol": 1, "name": "other"}`, "", `does not match executable name "mismatch"`},
+		"kind":     {`{"protocol": 1, "name": "kind", "kind": "magic"}`, "", `unknown kind "magic"`},
+		"garbage":  {`not json`, "", "invalid JSON"},
+	} {
+		_, err := Load(fakePlugin(t, t.TempDir(), name, tc.describe, tc.tasks))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: error = %v, want it to contain %q", name, err, tc.want)
+		}
+	}
+}
+
+func TestGetTasks_Invalid(t *testing.T) {
+	for name, tc := range map[string]struct{ tasks, want string }{
+		"escape":  {`{"protocol": 1, "tasks": [{"type": "create", "path": "../x", "content": ""}]}`, "stay within the target"},
+		"type":    {`{"protocol": 1, "tasks": [{"type": "delete", "path": "x"}]}`, `unknown type "delete"`},
+		"merge":   {`{"protocol": 1, "tasks": [{"type": "merge", "path": "pom.xml"}]}`, "element_path"},
+		"exec":    {`{"protocol": 1, "tasks": [{"type": "exec"}]}`, "cmd: must not be empty"},
+		"predict": {`{"protocol": 1, "tasks": [{"type": "exec", "cmd": "x", "predicted_created": ["/tmp"]}]}`, "predicted_created"},
+	} {
+		p, err := Load(fakePlugin(t, t.TempDir(), name, `{"protocol": 1, "name": "`+name+`"}`, tc.tasks))
+		if err != nil {
+			t.Fatalf("%s: Load failed: %v", name, err)
+		}
+		if _, err := p.GetTasks(t.TempDir(), "", ""); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: error = %v, want it to contain %q", name, err, tc.want)
+		}
+	}
+}
+
+// Verifies that two plugins can use the same task IDs.
+func TestGetTasks_ScheduleNamespace(t *testing.T) {
+	const taskList = `{"protocol": 1, "tasks": [
+		{"type": "exec", "cmd": "true", "id": "init"},
+		{"type": "exec", "cmd": "true", "after": ["init"]}
+	]}`
+	dir := t.TempDir()
+	var got []types.Schedule
+	for _, name := range []string{"alpha", "beta"} {
+		p, err := Load(fakePlugin(t, dir, name, `{"protocol": 1, "name": "`+name+`"}`, taskList))
+		if err != nil {
+			t.Fatalf("%s: Load failed: %v", name, err)
+		}
+		plan, err := p.GetTasks(t.TempDir(), "", "")
+		if err != nil {
+			t.Fatalf("%s: GetTasks failed: %v", name, err)
+		}
+		for _, task := range plan {
+			st, ok := task.(types.ScheduledTask)
+			if !ok {
+				t.Fatalf("%s: task %#v declares no schedule", name, task)
+			}
+			got = append(got, st.Scheduling())
+		}
+	}
+
+	want := []types.Schedule{
+		{ID: "alpha/init"}, {After: []string{"alpha/init"}},
+		{ID: "beta/init"}, {After: []string{"beta/init"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("schedules = %+v, want %+v", got, want)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	want := fakePlugin(t, first, "hello", "", "")
+	fakePlugin(t, second, "hello", "", "")
+	other := fakePlugin(t, second, "another", "", "")
+	if err := os.WriteFile(filepath.Join(second, Prefix+"notexec"), nil, fileutil.FilePerms); err != nil {
+		t.Fatal(err)
+	}
+
+	got := Discover("", first, second)
+	if len(got) != 2 || got[0] != other || got[1] != want {
+		t.Errorf("Discover = %v, want [%s %s]", got, other, want)
+	}
+}
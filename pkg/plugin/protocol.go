@@ -0,0 +1,104 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package plugin
+
+import "encoding/json"
+
+// ProtocolVersion is the plugin protocol spoken by this scbake.
+const ProtocolVersion = 1
+
+// Request methods.
+const (
+	MethodDescribe = "describe"
+	MethodTasks    = "tasks"
+)
+
+// Handler kinds.
+const (
+	KindTemplate = "template"
+	KindLang     = "lang"
+)
+
+// Task types.
+const (
+	TaskCreate = "create"
+	TaskAppend = "append"
+	TaskMerge  = "merge"
+	TaskExec   = "exec"
+)
+
+// Request is written to the plugin's stdin.
+type Request struct {
+	Protocol   int    `json:"protocol"`
+	Method     string `json:"method"`
+	TargetPath string `json:"target_path,omitempty"`
+
+	// Metadata is the run's metadata, with the defaults of the plugin's
+	// schema filled in (tasks only).
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// header is the part shared by every response.
+type header struct {
+	Protocol int    `json:"protocol"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Description is the response to MethodDescribe.
+type Description struct {
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+
+	// Band is the priority band of a template (see declarative.BandRange).
+	Band string `json:"band"`
+
+	// Schema is the handler's input schema, if any (see internal/schema).
+	Schema json.RawMessage `json:"schema"`
+
+	// Binaries must be on PATH before a language plugin runs.
+	Binaries []string `json:"binaries"`
+
+	// Relations of a template to other templates and to project languages
+	// and build tools (see types.Dependencies).
+	Requires           []string `json:"requires"`
+	Conflicts          []string `json:"conflicts"`
+	Suggests           []string `json:"suggests"`
+	RequiresLanguages  []string `json:"requires_languages"`
+	ConflictsLanguages []string `json:"conflicts_languages"`
+	RequiresBuildTools []string `json:"requires_build_tools"`
+}
+
+// TaskSpec describes one task returned by MethodTasks.
+type TaskSpec struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	When        string `json:"when"`
+
+	// create, append and merge
+	Path    string `json:"path"`
+	Content string `json:"content"`
+
+	// create: mark the file executable
+	Executable bool `json:"executable"`
+
+	// merge: where to insert the XML fragment in Content
+	ElementPath string `json:"element_path"`
+
+	// exec
+	Cmd              string   `json:"cmd"`
+	Args             []string `json:"args"`
+	PredictedCreated []string `json:"predicted_created"`
+
+	// Optional scheduling (see types.Schedule); resources are relative to
+	// the target path, IDs and After entries to the plugin.
+	ID        string   `json:"id"`
+	After     []string `json:"after"`
+	Resources []string `json:"resources"`
+}
+
+// tasksResponse is the response to MethodTasks.
+type tasksResponse struct {
+	Tasks []TaskSpec `json:"tasks"`
+}
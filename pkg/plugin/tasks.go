@@ -0,0 +1,154 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"scbake/internal/types"
+	"scbake/internal/util/fileutil"
+	"scbake/pkg/tasks"
+	"strings"
+)
+
+// task converts the description into an engine task with the given priority.
+// Its ID and After entries are prefixed with "<plugin>/", so plugins can't
+// collide with each other's IDs.
+//
+//nolint:cyclop // One short case per task type.
+func (s TaskSpec) task(prio int, targetPath, plugin string) (types.Task, error) {
+	if s.Type != TaskExec {
+		if err := checkLocal(s.Path); err != nil {
+			return nil, fmt.Errorf("path: %w", err)
+		}
+	}
+
+	var sched types.Schedule
+	if s.ID != "" {
+		sched.ID = plugin + "/" + s.ID
+	}
+	for _, id := range s.After {
+		sched.After = append(sched.After, plugin+"/"+id)
+	}
+	for _, r := range s.Resources {
+		if err := checkLocal(r); err != nil {
+			return nil, fmt.Errorf("resources: %w", err)
+		}
+		sched.Resources = append(sched.Resources, filepath.Join(targetPath, filepath.FromSlash(r)))
+	}
+
+	var task types.Task
+	switch s.Type {
+	case TaskCreate:
+		f := tasks.ManagedFile{OutputPath: filepath.FromSlash(s.Path), Content: []byte(s.Content)}
+		if s.Executable {
+			f.Mode = fileutil.ExecFilePerms
+		}
+		return &createFileTask{file: f, desc: orDefault(s.Description, "Create "+s.Path), prio: prio, when: s.When, sched: sched}, nil
+
+	case TaskAppend:
+		task = &tasks.AppendFileTask{
+			FilePath: filepath.FromSlash(s.Path),
+			Content:  s.Content,
+			Desc:     orDefault(s.Description, "Append to "+s.Path),
+			TaskPrio: prio,
+			Schedule: sched,
+		}
+
+	case TaskMerge:
+		if s.ElementPath == "" {
+			return nil, errors.New("element_path: must not be empty")
+		}
+		task = &tasks.InsertXMLTask{
+			FilePath:    filepath.FromSlash(s.Path),
+			ElementPath: s.ElementPath,
+			XMLContent:  s.Content,
+			Desc:        orDefault(s.Description, "Merge into "+s.Path),
+			TaskPrio:    prio,
+			Schedule:    sched,
+		}
+
+	case TaskExec:
+		if s.Cmd == "" {
+			return nil, errors.New("cmd: must not be empty")
+		}
+		for _, p := range s.PredictedCreated {
+			if err := checkLocal(p); err != nil {
+				return nil, fmt.Errorf("predicted_created: %w", err)
+			}
+		}
+		return &tasks.ExecCommandTask{
+			Cmd:              s.Cmd,
+			Args:             s.Args,
+			Desc:             orDefault(s.Description, "Run "+strings.Join(append([]string{s.Cmd}, s.Args...), " ")),
+			TaskPrio:         prio,
+			RunInTarget:      true,
+			PredictedCreated: s.PredictedCreated,
+			When:             s.When,
+			Schedule:         sched,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown type %q (expected %s, %s, %s or %s)", s.Type, TaskCreate, TaskAppend, TaskMerge, TaskExec)
+	}
+
+	if s.When != "" {
+		return conditionalTask{Task: task, when: s.When}, nil
+	}
+	return task, nil
+}
+
+// createFileTask writes plugin-provided content through tasks.ManagedFile, so
+// drift detection, conflict strategies and rollback apply as for templates.
+type createFileTask struct {
+	file  tasks.ManagedFile
+	desc  string
+	prio  int
+	when  string
+	sched types.Schedule
+}
+
+func (t *createFileTask) Description() string        { return t.desc }
+func (t *createFileTask) Priority() int              { return t.prio }
+func (t *createFileTask) Condition() string          { return t.when }
+func (t *createFileTask) Scheduling() types.Schedule { return t.sched }
+
+func (t *createFileTask) Execute(tc types.TaskContext) error {
+	return t.file.Write(tc)
+}
+
+// conditionalTask adds a `when` expression to tasks that have none of their own.
+type conditionalTask struct {
+	types.Task
+	when string
+}
+
+func (t conditionalTask) Condition() string { return t.when }
+
+// Scheduling forwards the wrapped task's schedule.
+func (t conditionalTask) Scheduling() types.Schedule {
+	if st, ok := t.Task.(types.ScheduledTask); ok {
+		return st.Scheduling()
+	}
+	return types.Schedule{}
+}
+
+// checkLocal rejects empty, absolute and escaping paths.
+func checkLocal(p string) error {
+	if p == "" {
+		return errors.New("must not be empty")
+	}
+	if !filepath.IsLocal(filepath.FromSlash(p)) {
+		return fmt.Errorf("%q must be relative and stay within the target directory", p)
+	}
+	return nil
+}
+
+func orDefault(s, def string) string {
+	if s != "" {
+		return s
+	}
+	return def
+}
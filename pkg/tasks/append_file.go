@@ -18,6 +18,7 @@ type AppendFileTask struct {
 	Content  string
 	Desc     string
 	TaskPrio int
+	Schedule types.Schedule
 }
 
 // Execute performs the task of appending content to the file.
@@ -91,3 +92,6 @@ func (t *AppendFileTask) Description() string { return t.Desc }
 
 // Priority returns the execution priority level.
 func (t *AppendFileTask) Priority() int { return t.TaskPrio }
+
+// Scheduling returns the task's schedule.
+func (t *AppendFileTask) Scheduling() types.Schedule { return t.Schedule }
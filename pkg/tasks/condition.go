@@ -0,0 +1,65 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package tasks
+
+import (
+	"bytes"
+	"fmt"
+	"scbake/internal/types"
+	"strings"
+	"text/template"
+)
+
+// EvalCondition evaluates a `when` expression against the manifest.
+// The expression is a template pipeline without the surrounding braces, so
+// anything valid inside `{{ if ... }}` works, e.g.:
+//
+//	has_language "go"
+//	eq .Metadata.ci "github"
+//	and (has_language "spring") (ne .Metadata.build_tool "gradle")
+//
+// An empty expression is always true.
+func EvalCondition(expr string, m *types.Manifest) (bool, error) {
+	var metadata map[string]string
+	if m != nil {
+		metadata = m.Metadata
+	}
+	return evalCondition(expr, m, metadata)
+}
+
+// EvalTaskCondition evaluates a `when` expression for a task, with .Metadata
+// including the metadata of the task's project (see TaskContext.Metadata).
+func EvalTaskCondition(expr string, tc types.TaskContext) (bool, error) {
+	return evalCondition(expr, tc.Manifest, tc.Metadata())
+}
+
+// conditionData is what `when` expressions are evaluated against.
+type conditionData struct {
+	*types.Manifest
+	Metadata map[string]string
+}
+
+func evalCondition(expr string, m *types.Manifest, metadata map[string]string) (bool, error) {
+	if strings.TrimSpace(expr) == "" {
+		return true, nil
+	}
+
+	tpl, err := template.New("when").
+		Funcs(TemplateFuncs(m)).
+		Option("missingkey=zero").
+		Parse("{{ if " + expr + " }}true{{ end }}")
+	if err != nil {
+		return false, fmt.Errorf("invalid when expression %q: %w", expr, err)
+	}
+
+	if m == nil {
+		m = &types.Manifest{}
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, conditionData{Manifest: m, Metadata: metadata}); err != nil {
+		return false, fmt.Errorf("failed to evaluate when expression %q: %w", expr, err)
+	}
+	return buf.String() == "true", nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package tasks
+
+import (
+	"scbake/internal/types"
+	"testing"
+)
+
+func TestEvalCondition(t *testing.T) {
+	m := &types.Manifest{
+		Projects: []types.Project{{Name: "api", Language: "go"}},
+		Metadata: map[string]string{"ci": "github"},
+	}
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    bool
+		wantErr bool
+	}{
+		{"Empty expression", "", true, false},
+		{"Language present", `has_language "go"`, true, false},
+		{"Language absent", `has_language "spring"`, false, false},
+		{"Metadata equality", `eq .Metadata.ci "github"`, true, false},
+		{"Missing metadata key", `eq .Metadata.runner "self-hosted"`, false, false},
+		{"Combined", `and (has_language "go") (ne .Metadata.ci "gitlab")`, true, false},
+		{"Negation", `not (has_language "go")`, false, false},
+		{"Syntax error", `eq .Metadata.ci`, false, true},
+		{"Unknown function", `is_cool "go"`, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvalCondition(tt.expr, m)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EvalCondition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("EvalCondition(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvalCondition_NilManifest(t *testing.T) {
+	got, err := EvalCondition(`has_language "go"`, nil)
+	if err != nil {
+		t.Fatalf("EvalCondition() error = %v", err)
+	}
+	if got {
+		t.Error("has_language should be false without a manifest")
+	}
+}
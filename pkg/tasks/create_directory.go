@@ -16,6 +16,7 @@ type CreateDirTask struct {
 	Path     string
 	Desc     string
 	TaskPrio int
+	Schedule types.Schedule
 }
 
 // Execute performs the task of creating the directory.
@@ -47,3 +48,6 @@ func (t *CreateDirTask) Description() string { return t.Desc }
 
 // Priority returns the execution priority level.
 func (t *CreateDirTask) Priority() int { return t.TaskPrio }
+
+// Scheduling returns the task's schedule.
+func (t *CreateDirTask) Scheduling() types.Schedule { return t.Schedule }
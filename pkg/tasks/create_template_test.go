@@ -9,11 +9,15 @@ import (
 	"os"
 	"path/filepath"
 	"scbake/internal/filesystem/transaction"
+	"scbake/internal/merge"
 	"scbake/internal/types"
+	"scbake/internal/util/fileutil"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
-//go:embed testdata/simple.tpl
+//go:embed testdata/simple.tpl testdata/multiline.tpl
 var testTemplates embed.FS
 
 func TestCreateTemplateTask(t *testing.T) {
@@ -333,3 +337,252 @@ func TestCreateTemplateTask_Transaction(t *testing.T) {
 		t.Error("File was not removed by rollback")
 	}
 }
+
+func TestCreateTemplateTask_ConflictStrategyMerge(t *testing.T) {
+	newTask := func() *CreateTemplateTask {
+		return &CreateTemplateTask{
+			TemplateFS:   testTemplates,
+			TemplatePath: "testdata/multiline.tpl",
+			OutputPath:   "README.md",
+			Desc:         "Merge test",
+			TaskPrio:     100,
+		}
+	}
+
+	setup := func(t *testing.T, localEdit func(string) string) (string, types.TaskContext) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		tc := types.TaskContext{
+			TargetPath: tmpDir,
+			Manifest: &types.Manifest{
+				SbakeVersion: "v1.0.0",
+				Projects:     []types.Project{{Name: "MergeTest"}},
+			},
+			ConflictStrategy: "merge",
+			Conflicts:        &types.ConflictReport{},
+		}
+
+		if err := newTask().Execute(tc); err != nil {
+			t.Fatalf("First execute failed: %v", err)
+		}
+
+		path := filepath.Join(tmpDir, "README.md")
+		//nolint:gosec // Test temp directory
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read output: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(localEdit(string(content))), 0600); err != nil {
+			t.Fatalf("Failed to modify file: %v", err)
+		}
+
+		// Simulate a template update.
+		tc.Manifest.SbakeVersion = "v2.0.0"
+		return path, tc
+	}
+
+	t.Run("Clean merge keeps local edits", func(t *testing.T) {
+		path, tc := setup(t, func(s string) string {
+			return strings.Replace(s, "Generated by scbake.", "Generated by scbake, then edited by hand.", 1)
+		})
+
+		if err := newTask().Execute(tc); err != nil {
+			t.Fatalf("Merge strategy should succeed: %v", err)
+		}
+
+		//nolint:gosec // Test temp directory
+		content, _ := os.ReadFile(path)
+		want := "# MergeTest\n\nGenerated by scbake, then edited by hand.\n\nversion: v2.0.0\n"
+		if string(content) != want {
+			t.Errorf("Merged content mismatch.\nWant:\n%s\nGot:\n%s", want, content)
+		}
+		if files := tc.Conflicts.Files(); len(files) != 0 {
+			t.Errorf("Expected no conflicts, got %v", files)
+		}
+
+		// The base must track the new rendering, not the merged result.
+		//nolint:gosec // Test temp directory
+		base, err := os.ReadFile(basePath(tc.TargetPath, "README.md"))
+		if err != nil {
+			t.Fatalf("Merge base was not stored: %v", err)
+		}
+		if !strings.Contains(string(base), "v2.0.0") || strings.Contains(string(base), "edited by hand") {
+			t.Errorf("Merge base should hold the pristine rendering, got:\n%s", base)
+		}
+	})
+
+	t.Run("Overlapping edits produce markers", func(t *testing.T) {
+		path, tc := setup(t, func(s string) string {
+			return strings.Replace(s, "version: v1.0.0", "version: pinned", 1)
+		})
+
+		if err := newTask().Execute(tc); err != nil {
+			t.Fatalf("Merge strategy should succeed even with conflicts: %v", err)
+		}
+
+		//nolint:gosec // Test temp directory
+		content, _ := os.ReadFile(path)
+		if !strings.Contains(string(content), merge.MarkerLocal) || !strings.Contains(string(content), "version: v2.0.0") {
+			t.Errorf("Expected conflict markers in merged file, got:\n%s", content)
+		}
+		if files := tc.Conflicts.Files(); len(files) != 1 || files[0] != "README.md" {
+			t.Errorf("Expected README.md to be reported as conflicted, got %v", files)
+		}
+	})
+
+	t.Run("Missing base falls back to artifact", func(t *testing.T) {
+		path, tc := setup(t, func(s string) string { return s + "local addition\n" })
+
+		if err := os.RemoveAll(filepath.Join(tc.TargetPath, fileutil.InternalDir)); err != nil {
+			t.Fatalf("Failed to remove merge base: %v", err)
+		}
+
+		if err := newTask().Execute(tc); err != nil {
+			t.Fatalf("Merge strategy should fall back without error: %v", err)
+		}
+
+		if _, err := os.Stat(path + ArtifactSuffix); os.IsNotExist(err) {
+			t.Error("Artifact file was not created when no merge base exists")
+		}
+	})
+}
+
+// stubResolver answers every conflict with a fixed strategy and records the files asked about.
+type stubResolver struct {
+	strategy string
+	asked    []string
+}
+
+func (r *stubResolver) ResolveConflict(path string, _, _ []byte) (string, error) {
+	r.asked = append(r.asked, path)
+	return r.strategy, nil
+}
+
+func TestCreateTemplateTask_ConflictStrategyOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		global    string
+		overrides map[string]string
+		resolver  *stubResolver
+		wantErr   bool
+		wantLocal bool
+		wantAsked int
+	}{
+		{
+			name:      "Exact path override wins over global fail",
+			global:    "fail",
+			overrides: map[string]string{"README.md": "keep-local"},
+			wantLocal: true,
+		},
+		{
+			name:      "Glob override",
+			global:    "fail",
+			overrides: map[string]string{"*.md": "overwrite"},
+		},
+		{
+			name:      "Non-matching override falls back to global",
+			global:    "fail",
+			overrides: map[string]string{"docs/*.md": "overwrite"},
+			wantErr:   true,
+		},
+		{
+			name:      "Prompt delegates to resolver",
+			global:    "prompt",
+			resolver:  &stubResolver{strategy: "keep-local"},
+			wantLocal: true,
+			wantAsked: 1,
+		},
+		{
+			name:      "Override bypasses prompt",
+			global:    "prompt",
+			overrides: map[string]string{"README.md": "overwrite"},
+			resolver:  &stubResolver{strategy: "keep-local"},
+		},
+		{
+			name:    "Prompt without resolver fails",
+			global:  "prompt",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			tc := types.TaskContext{
+				TargetPath: tmpDir,
+				Manifest: &types.Manifest{
+					SbakeVersion:       "v1.0.0",
+					Projects:           []types.Project{{Name: "OverrideTest"}},
+					ConflictStrategies: tt.overrides,
+				},
+				ConflictStrategy: tt.global,
+			}
+			if tt.resolver != nil {
+				tc.ConflictResolver = tt.resolver
+			}
+
+			task := &CreateTemplateTask{
+				TemplateFS:   testTemplates,
+				TemplatePath: "testdata/simple.tpl",
+				OutputPath:   "README.md",
+				Desc:         "Override test",
+				TaskPrio:     100,
+			}
+			if err := task.Execute(tc); err != nil {
+				t.Fatalf("First execute failed: %v", err)
+			}
+
+			path := filepath.Join(tmpDir, "README.md")
+			if err := os.WriteFile(path, []byte("DRIFTED"), 0600); err != nil {
+				t.Fatalf("Failed to modify file: %v", err)
+			}
+
+			err := task.Execute(tc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			//nolint:gosec // Test temp directory
+			content, _ := os.ReadFile(path)
+			if (string(content) == "DRIFTED") != tt.wantLocal {
+				t.Errorf("Unexpected content after reconciliation: %q", content)
+			}
+			if tt.resolver != nil && len(tt.resolver.asked) != tt.wantAsked {
+				t.Errorf("Resolver asked %d times, want %d", len(tt.resolver.asked), tt.wantAsked)
+			}
+		})
+	}
+}
+
+func TestCreateTemplateTask_ContextData(t *testing.T) {
+	tmpDir := t.TempDir()
+	tc := types.TaskContext{Ctx: context.Background(), TargetPath: tmpDir, Manifest: &types.Manifest{}}
+
+	type extended struct {
+		*TemplateContext
+		Module string
+	}
+	task := &CreateTemplateTask{
+		TemplateFS:   fstest.MapFS{"mod.tpl": {Data: []byte("{{ .Module }} in {{ .TargetPath }}")}},
+		TemplatePath: "mod.tpl",
+		OutputPath:   "mod.txt",
+		ContextData: func(ctx *TemplateContext) (interface{}, error) {
+			return &extended{TemplateContext: ctx, Module: "example.com/app"}, nil
+		},
+	}
+	if err := task.Execute(tc); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	//nolint:gosec // Test temp directory
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mod.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "example.com/app in " + tmpDir; string(content) != want {
+		t.Errorf("content = %q, want %q", content, want)
+	}
+}
@@ -0,0 +1,433 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package tasks
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"scbake/internal/templateregistry"
+	"scbake/internal/types"
+	"scbake/internal/util/fileutil"
+	"sort"
+	"strings"
+)
+
+// TemplateSuffix marks files whose contents are rendered; it is stripped from the output name.
+const TemplateSuffix = ".tpl"
+
+// treeSourceFile is a file discovered in one of the tree sources.
+type treeSourceFile struct {
+	// read returns the raw file content.
+	read func() ([]byte, error)
+
+	// executable reports whether the source file carries an executable bit.
+	executable bool
+
+	// source is where the file was found (see TemplateContext.Source).
+	source string
+}
+
+// CreateTreeTask renders a whole directory of templates into the target path.
+//
+// Files are collected from the same resolution chain as ReadTemplate: the
+// override directory, the registry cache and TemplateFS. A file present in a
+// higher-precedence source replaces the one below it, and sources may add
+// files of their own. For every file:
+//   - each path segment is rendered as a template (e.g. "cmd/{{.Name}}/main.go.tpl");
+//     a file is skipped if any segment renders empty, which allows conditional paths
+//   - entries matching a pattern in Conditions are skipped unless its `when` expression holds
+//   - files ending in ".tpl" are rendered and the suffix is stripped; other files are copied verbatim
+//   - partials ("partials/*.tpl", see IsPartial) are not written; they are
+//     available to every file of the tree as named templates
+//   - the executable bit is preserved for files read from disk; embedded files
+//     lose their modes, so list them in Executable instead
+//   - the result is written through ManagedFile, so it is tracked in ManagedFiles
+//
+// Note: go:embed skips files starting with "." or "_" unless the pattern uses the "all:" prefix.
+type CreateTreeTask struct {
+	// TemplateFS holds the built-in tree (typically an embed.FS)
+	TemplateFS fs.FS
+
+	// Root is the directory *within* TemplateFS to walk (e.g., "templates/layout")
+	Root string
+
+	// OutputDir is the destination directory relative to the TargetPath ("" for the target itself)
+	OutputDir string
+
+	// Optional: Glob patterns (relative to Root) of files to skip. A pattern
+	// matches either the full relative path or the file name.
+	Ignore []string
+
+	// Optional: Glob patterns (relative to Root) of files to write as executable.
+	Executable []string
+
+	// Optional: `when` expressions for individual entries, keyed by glob pattern
+	// (matched like Ignore). An entry is skipped unless every matching expression holds.
+	Conditions map[string]string
+
+	// Human-readable description
+	Desc string
+
+	// Execution priority
+	TaskPrio int
+
+	// Optional: Custom data to pass to the templates instead of the TemplateContext
+	TemplateData interface{}
+
+	// Optional: Builds the template data from each file's TemplateContext when the
+	// task executes (see CreateTemplateTask.ContextData); ignored if TemplateData is set
+	ContextData func(ctx *TemplateContext) (interface{}, error)
+
+	// Optional: `when` expression; the task is skipped unless it holds (see EvalCondition)
+	When string
+
+	// Optional: ID, dependencies and resources for parallel scheduling (see types.Schedule)
+	Schedule types.Schedule
+}
+
+// Description returns a human-readable summary of the task.
+func (t *CreateTreeTask) Description() string {
+	return t.Desc
+}
+
+// Priority returns the execution priority level.
+func (t *CreateTreeTask) Priority() int {
+	return t.TaskPrio
+}
+
+// Condition returns the task's `when` expression.
+func (t *CreateTreeTask) Condition() string {
+	return t.When
+}
+
+// Scheduling returns the task's schedule.
+func (t *CreateTreeTask) Scheduling() types.Schedule {
+	return t.Schedule
+}
+
+// Execute renders every file of the tree and writes it through ManagedFile.
+// All files are rendered before the first one is written, so template errors
+// never leave a partially generated tree behind.
+func (t *CreateTreeTask) Execute(tc types.TaskContext) error {
+	sources, err := t.collect(tc)
+	if err != nil {
+		return err
+	}
+
+	rels := make([]string, 0, len(sources))
+	for rel := range sources {
+		rels = append(rels, rel)
+	}
+	sort.Strings(rels)
+
+	partials, rels, err := t.partials(sources, rels, tc)
+	if err != nil {
+		return err
+	}
+
+	files := make([]ManagedFile, 0, len(rels))
+	for _, rel := range rels {
+		include, _, err := t.entryEnabled(rel, tc)
+		if err != nil {
+			return err
+		}
+		if !include {
+			continue
+		}
+
+		data, err := templateData(t.TemplateData, t.ContextData, NewTemplateContext(tc, path.Join(t.Root, rel), sources[rel].source))
+		if err != nil {
+			return fmt.Errorf("failed to build data for %s: %w", rel, err)
+		}
+
+		f, ok, err := t.render(rel, sources[rel], data, tc.Manifest, partials)
+		if err != nil {
+			return err
+		}
+		if ok {
+			files = append(files, f)
+		}
+	}
+
+	for _, f := range files {
+		if err := f.Write(tc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// collect gathers the tree's files from all sources, keyed by slash-separated
+// path relative to Root. Later sources override earlier ones.
+func (t *CreateTreeTask) collect(tc types.TaskContext) (map[string]treeSourceFile, error) {
+	sources := make(map[string]treeSourceFile)
+
+	// 3. Embedded defaults (lowest precedence)
+	if err := t.collectFS(sources); err != nil {
+		return nil, err
+	}
+
+	// 2. Registry cache
+	if tc.RegistryCacheDir != "" {
+		if dir := templateregistry.ResolveCachePath(tc.RegistryCacheDir, t.Root); dir != "" {
+			if err := t.collectDir(sources, dir, SourceRegistry); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// 1. Local overrides (highest precedence)
+	if tc.TemplateDir != "" {
+		if err := t.collectDir(sources, filepath.Join(tc.TemplateDir, filepath.Clean(t.Root)), SourceOverride); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("template tree %s is empty or does not exist", t.Root)
+	}
+
+	return sources, nil
+}
+
+func (t *CreateTreeTask) collectFS(sources map[string]treeSourceFile) error {
+	if t.TemplateFS == nil {
+		return nil
+	}
+
+	err := fs.WalkDir(t.TemplateFS, t.Root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel := strings.TrimPrefix(strings.TrimPrefix(p, t.Root), "/")
+		if t.ignored(rel) {
+			return nil
+		}
+		sources[rel] = treeSourceFile{
+			read:       func() ([]byte, error) { return fs.ReadFile(t.TemplateFS, p) },
+			executable: matchesAny(t.Executable, rel),
+			source:     SourceEmbedded,
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to walk template tree %s: %w", t.Root, err)
+	}
+	return nil
+}
+
+func (t *CreateTreeTask) collectDir(sources map[string]treeSourceFile, dir, source string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read template tree %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return nil
+	}
+
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relOS, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel := filepath.ToSlash(relOS)
+		if t.ignored(rel) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		sources[rel] = treeSourceFile{
+			//nolint:gosec // Template directories are user-provided and trusted
+			read:       func() ([]byte, error) { return os.ReadFile(p) },
+			executable: info.Mode()&0o111 != 0 || matchesAny(t.Executable, rel),
+			source:     source,
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to walk template tree %s: %w", dir, err)
+	}
+	return nil
+}
+
+// render produces the managed file for one tree entry. It reports false if
+// the entry is skipped because a path segment rendered empty.
+func (t *CreateTreeTask) render(rel string, src treeSourceFile, data interface{}, m *types.Manifest, partials []partial) (ManagedFile, bool, error) {
+	segments := strings.Split(rel, "/")
+	for i, seg := range segments {
+		out, err := renderString(rel, seg, data, m, partials)
+		if err != nil {
+			return ManagedFile{}, false, fmt.Errorf("failed to render path %s: %w", rel, err)
+		}
+		out = strings.TrimSpace(out)
+		if out == "" {
+			return ManagedFile{}, false, nil
+		}
+		if strings.ContainsAny(out, `/\`) || out == ".." {
+			return ManagedFile{}, false, fmt.Errorf("path segment %q of %s renders to invalid name %q", seg, rel, out)
+		}
+		segments[i] = out
+	}
+
+	content, err := src.read()
+	if err != nil {
+		return ManagedFile{}, false, fmt.Errorf("failed to read template %s: %w", rel, err)
+	}
+
+	outRel := path.Join(segments...)
+	if strings.HasSuffix(outRel, TemplateSuffix) {
+		outRel = strings.TrimSuffix(outRel, TemplateSuffix)
+		rendered, err := renderString(rel, string(content), data, m, partials)
+		if err != nil {
+			return ManagedFile{}, false, fmt.Errorf("failed to render template %s: %w", rel, err)
+		}
+		content = []byte(rendered)
+	}
+
+	f := ManagedFile{
+		OutputPath: filepath.Join(t.OutputDir, filepath.FromSlash(outRel)),
+		Content:    content,
+	}
+	if src.executable {
+		f.Mode = fileutil.ExecFilePerms
+	}
+	return f, true, nil
+}
+
+// SkippedParts returns the entries of the tree that Conditions exclude, so
+// the executor can report them before the task runs.
+func (t *CreateTreeTask) SkippedParts(tc types.TaskContext) ([]types.SkippedPart, error) {
+	if len(t.Conditions) == 0 {
+		return nil, nil
+	}
+	sources, err := t.collect(tc)
+	if err != nil {
+		return nil, err
+	}
+
+	rels := make([]string, 0, len(sources))
+	for rel := range sources {
+		if !IsPartial(rel) {
+			rels = append(rels, rel)
+		}
+	}
+	sort.Strings(rels)
+
+	var skipped []types.SkippedPart
+	for _, rel := range rels {
+		include, reason, err := t.entryEnabled(rel, tc)
+		if err != nil {
+			return nil, err
+		}
+		if !include {
+			skipped = append(skipped, types.SkippedPart{
+				Description: path.Join(filepath.ToSlash(t.OutputDir), strings.TrimSuffix(rel, TemplateSuffix)),
+				Reason:      reason,
+			})
+		}
+	}
+	return skipped, nil
+}
+
+// entryEnabled evaluates the conditions matching rel and reports whether the
+// entry should be written and, if not, why.
+func (t *CreateTreeTask) entryEnabled(rel string, tc types.TaskContext) (bool, string, error) {
+	patterns := make([]string, 0, len(t.Conditions))
+	for p := range t.Conditions {
+		patterns = append(patterns, p)
+	}
+	sort.Strings(patterns)
+
+	for _, p := range patterns {
+		if !matchesAny([]string{p}, rel) {
+			continue
+		}
+		ok, err := EvalTaskCondition(t.Conditions[p], tc)
+		if err != nil {
+			return false, "", fmt.Errorf("condition for %s: %w", rel, err)
+		}
+		if !ok {
+			return false, "when: " + t.Conditions[p], nil
+		}
+	}
+	return true, "", nil
+}
+
+func (t *CreateTreeTask) ignored(rel string) bool {
+	return matchesAny(t.Ignore, rel)
+}
+
+// partials splits the partial entries off the tree. It returns the shared
+// partials followed by the tree's own, and the remaining entries to render.
+func (t *CreateTreeTask) partials(sources map[string]treeSourceFile, rels []string, tc types.TaskContext) ([]partial, []string, error) {
+	partials, err := loadPartials(nil, "", tc.TemplateDir, tc.RegistryCacheDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load partials for tree %s: %w", t.Root, err)
+	}
+
+	files := rels[:0]
+	for _, rel := range rels {
+		if !IsPartial(rel) {
+			files = append(files, rel)
+			continue
+		}
+		content, err := sources[rel].read()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read partial %s: %w", rel, err)
+		}
+		partials = append(partials, partial{name: partialName(rel), content: content})
+	}
+	return partials, files, nil
+}
+
+// renderString executes text as a template named name, with partials available.
+func renderString(name, text string, data interface{}, m *types.Manifest, partials []partial) (string, error) {
+	tpl, err := newTemplate(name, m, partials)
+	if err != nil {
+		return "", err
+	}
+	if _, err := tpl.Parse(text); err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// matchesAny reports whether the slash-separated path rel, or its base name,
+// matches one of the glob patterns.
+func matchesAny(patterns []string, rel string) bool {
+	base := path.Base(rel)
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, rel); ok {
+			return true
+		}
+		if ok, _ := path.Match(p, base); ok {
+			return true
+		}
+	}
+	return false
+}
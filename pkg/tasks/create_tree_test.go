@@ -0,0 +1,216 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package tasks
+
+import (
+	"embed"
+	"os"
+	"path/filepath"
+	"scbake/internal/types"
+	"testing"
+)
+
+//go:embed all:testdata/tree
+var testTree embed.FS
+
+type treeData struct {
+	Name    string
+	DocsDir string
+}
+
+func TestCreateTreeTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       treeData
+		wantFiles  map[string]string
+		wantAbsent []string
+	}{
+		{
+			name: "Renders paths and contents",
+			data: treeData{Name: "api", DocsDir: "docs"},
+			wantFiles: map[string]string{
+				"README.md":       "# api\n",
+				"cmd/api/main.go": "package main\n\n// api entrypoint\nfunc main() {}\n",
+				"docs/guide.md":   "Docs for {{.Name}} stay verbatim\n",
+				".gitignore":      "hidden api\n",
+				"scripts/run.sh":  "#!/bin/sh\necho hi\n",
+			},
+			wantAbsent: []string{"notes.bak", "README.md.tpl"},
+		},
+		{
+			name: "Empty path segment skips file",
+			data: treeData{Name: "api"},
+			wantFiles: map[string]string{
+				"README.md": "# api\n",
+			},
+			wantAbsent: []string{"guide.md", "docs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			tc := types.TaskContext{
+				TargetPath: tmpDir,
+				Manifest:   &types.Manifest{},
+			}
+
+			task := &CreateTreeTask{
+				TemplateFS:   testTree,
+				Root:         "testdata/tree",
+				Ignore:       []string{"*.bak"},
+				Executable:   []string{"scripts/*.sh"},
+				Desc:         "Create tree",
+				TaskPrio:     100,
+				TemplateData: tt.data,
+			}
+			if err := task.Execute(tc); err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+
+			for rel, want := range tt.wantFiles {
+				//nolint:gosec // Test temp directory
+				got, err := os.ReadFile(filepath.Join(tmpDir, rel))
+				if err != nil {
+					t.Errorf("Expected %s: %v", rel, err)
+					continue
+				}
+				if string(got) != want {
+					t.Errorf("%s content mismatch. Got %q, want %q", rel, got, want)
+				}
+				if _, ok := tc.Manifest.ManagedFiles[rel]; !ok {
+					t.Errorf("%s was not registered in ManagedFiles", rel)
+				}
+			}
+			for _, rel := range tt.wantAbsent {
+				if _, err := os.Stat(filepath.Join(tmpDir, rel)); !os.IsNotExist(err) {
+					t.Errorf("%s should not exist", rel)
+				}
+			}
+
+			info, err := os.Stat(filepath.Join(tmpDir, "scripts", "run.sh"))
+			if err != nil {
+				t.Fatalf("Stat failed: %v", err)
+			}
+			if info.Mode().Perm()&0o100 == 0 {
+				t.Errorf("run.sh should be executable, got %v", info.Mode().Perm())
+			}
+		})
+	}
+}
+
+func TestCreateTreeTask_OverrideDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	overrideDir := t.TempDir()
+
+	// Override one file and add an executable one that is not in the embedded tree.
+	treeDir := filepath.Join(overrideDir, "testdata", "tree")
+	if err := os.MkdirAll(filepath.Join(treeDir, "bin"), 0750); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(treeDir, "README.md.tpl"), []byte("# Custom {{.Name}}\n"), 0600); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	//nolint:gosec // Executable test fixture
+	if err := os.WriteFile(filepath.Join(treeDir, "bin", "tool"), []byte("#!/bin/sh\n"), 0700); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	tc := types.TaskContext{
+		TargetPath:  tmpDir,
+		Manifest:    &types.Manifest{},
+		TemplateDir: overrideDir,
+	}
+	task := &CreateTreeTask{
+		TemplateFS:   testTree,
+		Root:         "testdata/tree",
+		OutputDir:    "svc",
+		TemplateData: treeData{Name: "api"},
+	}
+	if err := task.Execute(tc); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	//nolint:gosec // Test temp directory
+	got, _ := os.ReadFile(filepath.Join(tmpDir, "svc", "README.md"))
+	if string(got) != "# Custom api\n" {
+		t.Errorf("Override not applied, got %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "svc", "cmd", "api", "main.go")); err != nil {
+		t.Errorf("Embedded files should still be rendered: %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(tmpDir, "svc", "bin", "tool"))
+	if err != nil {
+		t.Fatalf("Override-only file missing: %v", err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("Executable bit from disk should be preserved, got %v", info.Mode().Perm())
+	}
+}
+
+func TestCreateTreeTask_Errors(t *testing.T) {
+	tc := types.TaskContext{
+		TargetPath: t.TempDir(),
+		Manifest:   &types.Manifest{},
+	}
+
+	missing := &CreateTreeTask{TemplateFS: testTree, Root: "testdata/does-not-exist"}
+	if err := missing.Execute(tc); err == nil {
+		t.Error("Expected error for missing tree")
+	}
+
+	// A segment rendering to a path separator must not escape its directory.
+	escape := &CreateTreeTask{
+		TemplateFS:   testTree,
+		Root:         "testdata/tree",
+		TemplateData: treeData{Name: "../x"},
+	}
+	if err := escape.Execute(tc); err == nil {
+		t.Error("Expected error for path segment containing a separator")
+	}
+}
+
+func TestCreateTreeTask_Conditions(t *testing.T) {
+	tmpDir := t.TempDir()
+	tc := types.TaskContext{
+		TargetPath: tmpDir,
+		Manifest: &types.Manifest{
+			Projects: []types.Project{{Name: "api", Language: "go"}},
+			Metadata: map[string]string{"scripts": "false"},
+		},
+	}
+
+	task := &CreateTreeTask{
+		TemplateFS: testTree,
+		Root:       "testdata/tree",
+		Conditions: map[string]string{
+			"cmd/*/*":   `has_language "go"`,
+			"scripts/*": `eq .Metadata.scripts "true"`,
+		},
+		TemplateData: treeData{Name: "api", DocsDir: "docs"},
+	}
+
+	skipped, err := task.SkippedParts(tc)
+	if err != nil {
+		t.Fatalf("SkippedParts failed: %v", err)
+	}
+	if len(skipped) != 1 || skipped[0].Description != "scripts/run.sh" || skipped[0].Reason != `when: eq .Metadata.scripts "true"` {
+		t.Errorf("Expected scripts/run.sh to be reported as skipped, got %+v", skipped)
+	}
+
+	if err := task.Execute(tc); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "cmd", "api", "main.go")); err != nil {
+		t.Errorf("Entry with true condition should be written: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "scripts")); !os.IsNotExist(err) {
+		t.Error("Entries with false condition should be skipped")
+	}
+	if _, ok := tc.Manifest.ManagedFiles["scripts/run.sh"]; ok {
+		t.Error("Skipped entries must not be registered in ManagedFiles")
+	}
+}
@@ -2,6 +2,7 @@
 //
 // Task Types:
 //   - CreateTemplateTask: Renders embedded templates and creates new files
+//   - CreateTreeTask: Renders a whole template directory, including templated file names
 //   - CreateDirectoryTask: Creates directories with transaction tracking
 //   - ExecCommandTask: Executes shell commands with optional output tracking
 //   - InsertXMLTask: Modifies existing XML files by inserting fragments (e.g., Maven pom.xml)
@@ -12,6 +13,9 @@
 //   - Dry-run mode (DryRun flag suppresses side effects)
 //   - Description strings for logging and reporting
 //
+// File-generating tasks write through ManagedFile, which implements drift
+// detection, conflict strategies and ManagedFiles bookkeeping in one place.
+//
 // InsertXMLTask is specialized for XML modifications. It:
 //   - Parses and validates existing XML files
 //   - Inserts XML fragments at specified element paths (e.g., "/project/build/plugins")
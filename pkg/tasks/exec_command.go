@@ -34,6 +34,12 @@ type ExecCommandTask struct {
 	// This allows the rollback system to clean up artifacts (like node_modules)
 	// even if the command is opaque.
 	PredictedCreated []string
+
+	// Optional: `when` expression; the task is skipped unless it holds (see EvalCondition)
+	When string
+
+	// Optional: ID, dependencies and resources for parallel scheduling (see types.Schedule)
+	Schedule types.Schedule
 }
 
 // Description returns a human-readable summary of the task.
@@ -46,6 +52,16 @@ func (t *ExecCommandTask) Priority() int {
 	return t.TaskPrio
 }
 
+// Condition returns the task's `when` expression.
+func (t *ExecCommandTask) Condition() string {
+	return t.When
+}
+
+// Scheduling returns the task's schedule.
+func (t *ExecCommandTask) Scheduling() types.Schedule {
+	return t.Schedule
+}
+
 // Execute performs the command execution task.
 func (t *ExecCommandTask) Execute(tc types.TaskContext) error {
 	if tc.DryRun {
@@ -0,0 +1,371 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"scbake/internal/types"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/BurntSushi/toml"
+)
+
+// EnvAllowlistVar names the environment variable holding a comma-separated list
+// of additional variables that templates may read with `env`.
+const EnvAllowlistVar = "SCBAKE_TEMPLATE_ENV"
+
+// envPrefix marks environment variables that templates may always read.
+const envPrefix = "SCBAKE_"
+
+// staticFuncs provides utility functions that do not depend on the manifest.
+var staticFuncs = template.FuncMap{
+	// --- Defaults & validation ---
+	"default": func(defaultValue interface{}, given interface{}) interface{} {
+		if isEmpty(given) {
+			return defaultValue
+		}
+		return given
+	},
+	"required": func(msg string, val interface{}) (interface{}, error) {
+		if isEmpty(val) {
+			return nil, errors.New(msg)
+		}
+		return val, nil
+	},
+
+	// --- Data structures ---
+	"dict": func(values ...interface{}) (map[string]interface{}, error) {
+		if len(values)%2 != 0 {
+			return nil, errors.New("invalid dict call")
+		}
+		//nolint:mnd // Keys and values come in pairs
+		dict := make(map[string]interface{}, len(values)/2)
+		for i := 0; i < len(values); i += 2 {
+			key, ok := values[i].(string)
+			if !ok {
+				return nil, errors.New("dict keys must be strings")
+			}
+			dict[key] = values[i+1]
+		}
+		return dict, nil
+	},
+
+	// --- Strings ---
+	"quote": func(s string) string {
+		return fmt.Sprintf("%q", s)
+	},
+	"camelCase":  camelCase,
+	"pascalCase": pascalCase,
+	"snakeCase":  func(s string) string { return strings.Join(lowerWords(s), "_") },
+	"kebabCase":  func(s string) string { return strings.Join(lowerWords(s), "-") },
+	"indent":     indent,
+	"nindent":    func(n int, s string) string { return "\n" + indent(n, s) },
+	"join":       join,
+	"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+
+	// --- Serialization ---
+	"toJson": toJSON,
+	"toYaml": toYAML,
+	"toToml": toTOML,
+
+	// --- Time, hashing & environment ---
+	"now":    time.Now,
+	"year":   func() string { return strconv.Itoa(time.Now().Year()) },
+	"sha256": func(s string) string { return HashContent([]byte(s)) },
+	"env":    env,
+}
+
+// TemplateFuncs returns the function map available to every scbake template
+// and `when` expression. Manifest helpers (hasLanguage, projectsByLanguage)
+// query m regardless of the data the template is executed with.
+func TemplateFuncs(m *types.Manifest) template.FuncMap {
+	funcs := make(template.FuncMap, len(staticFuncs)+3) //nolint:mnd // Manifest helpers below
+	for name, fn := range staticFuncs {
+		funcs[name] = fn
+	}
+
+	hasLanguage := func(lang string) bool {
+		return len(projectsByLanguage(m, lang)) > 0
+	}
+	funcs["hasLanguage"] = hasLanguage
+	funcs["has_language"] = hasLanguage // `when` expression spelling
+	funcs["projectsByLanguage"] = func(lang string) []types.Project {
+		return projectsByLanguage(m, lang)
+	}
+
+	return funcs
+}
+
+func projectsByLanguage(m *types.Manifest, lang string) []types.Project {
+	if m == nil {
+		return nil
+	}
+	var out []types.Project
+	for _, p := range m.Projects {
+		if p.Language == lang {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// isEmpty reports whether val is nil, an empty string, or an empty collection.
+func isEmpty(val interface{}) bool {
+	if val == nil {
+		return true
+	}
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// words splits s into words at non-alphanumeric characters and case changes,
+// keeping acronyms together ("HTTPServer_v2" -> HTTP, Server, v2).
+func words(s string) []string {
+	var out []string
+	var cur []rune
+	runes := []rune(s)
+
+	flush := func() {
+		if len(cur) > 0 {
+			out = append(out, string(cur))
+			cur = cur[:0]
+		}
+	}
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(cur) > 0 {
+			prev := cur[len(cur)-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		cur = append(cur, r)
+	}
+	flush()
+
+	return out
+}
+
+func lowerWords(s string) []string {
+	ws := words(s)
+	for i, w := range ws {
+		ws[i] = strings.ToLower(w)
+	}
+	return ws
+}
+
+func capitalize(w string) string {
+	r := []rune(strings.ToLower(w))
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func pascalCase(s string) string {
+	var b strings.Builder
+	for _, w := range words(s) {
+		b.WriteString(capitalize(w))
+	}
+	return b.String()
+}
+
+func camelCase(s string) string {
+	ws := words(s)
+	if len(ws) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(strings.ToLower(ws[0]))
+	for _, w := range ws[1:] {
+		b.WriteString(capitalize(w))
+	}
+	return b.String()
+}
+
+// indent prefixes every non-empty line of s with n spaces.
+func indent(n int, s string) string {
+	pad := strings.Repeat(" ", n)
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = pad + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// join concatenates the elements of a list (any slice) with sep.
+func join(sep string, list interface{}) (string, error) {
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: expected a list, got %T", list)
+	}
+	parts := make([]string, v.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+	return strings.Join(parts, sep), nil
+}
+
+// env reads an environment variable. Only SCBAKE_* variables and those listed
+// in SCBAKE_TEMPLATE_ENV may be read, so templates cannot leak arbitrary secrets.
+func env(name string) (string, error) {
+	allowed := strings.HasPrefix(name, envPrefix)
+	for _, n := range strings.Split(os.Getenv(EnvAllowlistVar), ",") {
+		if strings.TrimSpace(n) == name {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return "", fmt.Errorf("env: %s is not allowlisted (add it to %s)", name, EnvAllowlistVar)
+	}
+	return os.Getenv(name), nil
+}
+
+func toJSON(v interface{}) (string, error) {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("toJson: %w", err)
+	}
+	return string(out), nil
+}
+
+func toTOML(v interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+		return "", fmt.Errorf("toToml: %w", err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// toYAML encodes v as block-style YAML. Values are normalized through JSON,
+// so struct tags apply and map keys come out sorted.
+func toYAML(v interface{}) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("toYaml: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return "", fmt.Errorf("toYaml: %w", err)
+	}
+
+	var b strings.Builder
+	writeYAML(&b, generic, 0)
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+func writeYAML(b *strings.Builder, v interface{}, depth int) {
+	pad := strings.Repeat("  ", depth)
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if len(val) == 0 {
+			b.WriteString(pad + "{}\n")
+			return
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			writeYAMLEntry(b, pad+yamlScalar(k)+":", val[k], depth)
+		}
+	case []interface{}:
+		if len(val) == 0 {
+			b.WriteString(pad + "[]\n")
+			return
+		}
+		for _, item := range val {
+			writeYAMLEntry(b, pad+"-", item, depth)
+		}
+	default:
+		b.WriteString(pad + yamlScalar(val) + "\n")
+	}
+}
+
+// writeYAMLEntry writes a key or list marker followed by its value,
+// inline for scalars and empty collections, nested otherwise.
+func writeYAMLEntry(b *strings.Builder, prefix string, v interface{}, depth int) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if len(val) > 0 {
+			b.WriteString(prefix + "\n")
+			writeYAML(b, val, depth+1)
+			return
+		}
+		b.WriteString(prefix + " {}\n")
+	case []interface{}:
+		if len(val) > 0 {
+			b.WriteString(prefix + "\n")
+			writeYAML(b, val, depth+1)
+			return
+		}
+		b.WriteString(prefix + " []\n")
+	default:
+		b.WriteString(prefix + " " + yamlScalar(val) + "\n")
+	}
+}
+
+// yamlScalar renders a scalar, quoting strings that YAML would otherwise
+// interpret differently (booleans, numbers, null, special characters).
+func yamlScalar(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case string:
+		if yamlPlainSafe(val) {
+			return val
+		}
+		quoted, _ := json.Marshal(val)
+		return string(quoted)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+func yamlPlainSafe(s string) bool {
+	if s == "" {
+		return false
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return false
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("_-./", r) {
+			return false
+		}
+	}
+	return !strings.HasPrefix(s, "-")
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package tasks
+
+import (
+	"bytes"
+	"scbake/internal/types"
+	"strconv"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+// renderWithFuncs executes text with the full function map against data.
+func renderWithFuncs(t *testing.T, m *types.Manifest, text string, data interface{}) (string, error) {
+	t.Helper()
+	tpl, err := template.New("test").Funcs(TemplateFuncs(m)).Parse(text)
+	if err != nil {
+		t.Fatalf("parse %q: %v", text, err)
+	}
+	var buf bytes.Buffer
+	err = tpl.Execute(&buf, data)
+	return buf.String(), err
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	m := &types.Manifest{
+		Projects: []types.Project{
+			{Name: "api", Path: "backend", Language: "go"},
+			{Name: "web", Path: "frontend", Language: "svelte"},
+			{Name: "worker", Path: "worker", Language: "go"},
+		},
+	}
+	t.Setenv("SCBAKE_TEAM", "platform")
+
+	tests := []struct {
+		name string
+		tpl  string
+		data interface{}
+		want string
+	}{
+		{"camelCase", `{{ camelCase "my-service_name" }}`, nil, "myServiceName"},
+		{"pascalCase", `{{ pascalCase "http server" }}`, nil, "HttpServer"},
+		{"snakeCase acronym", `{{ snakeCase "HTTPServerV2" }}`, nil, "http_server_v2"},
+		{"kebabCase", `{{ kebabCase "MyService" }}`, nil, "my-service"},
+		{"indent", `{{ indent 2 "a\nb" }}`, nil, "  a\n  b"},
+		{"nindent keeps blank lines", `x:{{ nindent 2 "a\n\nb" }}`, nil, "x:\n  a\n\n  b"},
+		{"join", `{{ join "," (split " " "a b c") }}`, nil, "a,b,c"},
+		{"contains", `{{ if contains "api" "my-api" }}yes{{ end }}`, nil, "yes"},
+		{"toJson", `{{ toJson (dict "b" 1 "a" "x") }}`, nil, `{"a":"x","b":1}`},
+		{"toYaml", `{{ toYaml (dict "name" "api" "tags" (split "," "go,yes") "empty" "") }}`, nil,
+			"empty: \"\"\nname: api\ntags:\n  - go\n  - \"yes\""},
+		{"toToml", `{{ toToml (dict "name" "api") }}`, nil, `name = "api"`},
+		{"sha256", `{{ sha256 "abc" }}`, nil, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{"year", `{{ year }}`, nil, strconv.Itoa(time.Now().Year())},
+		{"env allowlisted prefix", `{{ env "SCBAKE_TEAM" }}`, nil, "platform"},
+		{"hasLanguage", `{{ hasLanguage "svelte" }} {{ hasLanguage "spring" }}`, nil, "true false"},
+		{"projectsByLanguage", `{{ range projectsByLanguage "go" }}{{ .Path }};{{ end }}`, nil, "backend;worker;"},
+		{"helpers ignore template data", `{{ hasLanguage "go" }}`, map[string]string{"x": "y"}, "true"},
+		{"required passes value", `{{ required "name is required" .Name }}`, map[string]string{"Name": "api"}, "api"},
+		{"default on empty", `{{ default "x" "" }}`, nil, "x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderWithFuncs(t, m, tt.tpl, tt.data)
+			if err != nil {
+				t.Fatalf("render error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateFuncs_Errors(t *testing.T) {
+	t.Setenv(EnvAllowlistVar, "CI_BRANCH")
+	t.Setenv("CI_BRANCH", "main")
+	t.Setenv("SECRET_TOKEN", "hunter2")
+
+	got, err := renderWithFuncs(t, nil, `{{ env "CI_BRANCH" }}`, nil)
+	if err != nil || got != "main" {
+		t.Errorf("allowlisted env: got %q, err %v", got, err)
+	}
+
+	if _, err := renderWithFuncs(t, nil, `{{ env "SECRET_TOKEN" }}`, nil); err == nil {
+		t.Error("expected error for non-allowlisted env variable")
+	}
+
+	_, err = renderWithFuncs(t, nil, `{{ required "service_id must be set" .ServiceID }}`, map[string]string{})
+	if err == nil || !strings.Contains(err.Error(), "service_id must be set") {
+		t.Errorf("required should fail with the given message, got %v", err)
+	}
+
+	if _, err := renderWithFuncs(t, nil, `{{ join "," "not a list" }}`, nil); err == nil {
+		t.Error("expected error for join on a non-list")
+	}
+}
@@ -34,6 +34,9 @@ type InsertXMLTask struct {
 
 	// TaskPrio is the priority/order for execution
 	TaskPrio int
+
+	// Schedule is the optional ID, dependencies and resources for parallel scheduling
+	Schedule types.Schedule
 }
 
 // Description returns a human-readable summary of the task.
@@ -46,6 +49,11 @@ func (t *InsertXMLTask) Priority() int {
 	return t.TaskPrio
 }
 
+// Scheduling returns the task's schedule.
+func (t *InsertXMLTask) Scheduling() types.Schedule {
+	return t.Schedule
+}
+
 // Execute performs the XML insertion with safety checks and transaction tracking.
 func (t *InsertXMLTask) Execute(tc types.TaskContext) error {
 	if tc.DryRun {
@@ -0,0 +1,389 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package tasks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"scbake/internal/merge"
+	"scbake/internal/types"
+	"scbake/internal/util/fileutil"
+	"sort"
+	"strings"
+)
+
+// ArtifactSuffix is appended to the output path when the artifact strategy
+// writes the new rendering next to a drifted file.
+const ArtifactSuffix = ".scbake-new"
+
+// ManagedFile is a file whose full content is owned by scbake and tracked in
+// the manifest's ManagedFiles. Tasks that generate files render the content
+// themselves and delegate writing to ManagedFile.Write, which implements the
+// state-aware protocol shared by all built-in writers.
+type ManagedFile struct {
+	// OutputPath is the destination path relative to the TargetPath (e.g., "LICENSE")
+	OutputPath string
+
+	// Content is the fully rendered file content.
+	Content []byte
+
+	// Optional: File permissions. Zero means fileutil.FilePerms for new files
+	// and leaves the mode of existing files untouched.
+	Mode os.FileMode
+}
+
+// Write reconciles the file with the manifest state and writes it:
+//  1. Rejects output paths that escape the target path.
+//  2. Detects drift against the recorded hash and applies the conflict
+//     strategy (fail, overwrite, artifact, keep-local, merge, prompt).
+//  3. Stops before touching the filesystem in dry-run mode.
+//  4. Tracks every written path with the transaction manager.
+//  5. Records the new hash and merge base for future runs.
+//
+//nolint:cyclop // Reconciliation and file ops are linear
+func (f ManagedFile) Write(tc types.TaskContext) (err error) {
+	newHash := HashContent(f.Content)
+
+	// 1. Determine and check the final output path
+	finalPath := filepath.Join(tc.TargetPath, f.OutputPath)
+	if err = checkFilePreconditions(finalPath, f.OutputPath, tc.TargetPath); err != nil {
+		return err
+	}
+
+	absPath, _ := filepath.Abs(finalPath)
+	key := managedPath(f.OutputPath, tc)
+
+	// 2. State-Aware Reconciliation
+	rec, err := checkReconciliation(absPath, f.OutputPath, key, f.Content, tc)
+	if err != nil {
+		return err // Conflict strategy is 'fail'
+	}
+	if rec.writePath == "" {
+		return nil // Conflict strategy is 'keep-local' (skip)
+	}
+
+	// 3. Dry run stops here
+	if tc.DryRun {
+		return nil
+	}
+
+	writePath, content := rec.writePath, f.Content
+	if rec.merge {
+		writePath, content, err = mergeWithBase(absPath, f.OutputPath, key, f.Content, tc)
+		if err != nil {
+			return err
+		}
+	}
+
+	dir := filepath.Dir(writePath)
+	if err = os.MkdirAll(dir, fileutil.DirPerms); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	// 4. Safety Tracking: Register the file with the transaction manager.
+	if tc.Tx != nil {
+		if err = tc.Tx.Track(writePath); err != nil {
+			return fmt.Errorf("failed to track file %s: %w", writePath, err)
+		}
+	}
+
+	if err = writeFile(writePath, content, f.Mode); err != nil {
+		return err
+	}
+
+	// 5. Record State
+	// The base always holds the pristine rendering, matching the recorded hash.
+	if err = writeBase(f.OutputPath, key, f.Content, tc); err != nil {
+		return err
+	}
+	// Always record the hash against the original output path, even if we wrote an artifact
+	tc.Manifest.SetManagedHash(key, newHash)
+
+	return nil
+}
+
+// writeFile writes content to dest, applying mode if one is given.
+func writeFile(dest string, content []byte, mode os.FileMode) (err error) {
+	perm := mode
+	if perm == 0 {
+		perm = fileutil.FilePerms
+	}
+
+	//nolint:gosec // Path is canonicalized by the caller
+	f, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", dest, err)
+	}
+
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	if _, err = f.Write(content); err != nil {
+		return fmt.Errorf("failed to write to %s: %w", dest, err)
+	}
+
+	// OpenFile only applies perm on creation; enforce an explicit mode on existing files too.
+	if mode != 0 {
+		if err = f.Chmod(mode); err != nil {
+			return fmt.Errorf("failed to set permissions on %s: %w", dest, err)
+		}
+	}
+
+	return nil
+}
+
+// HashContent calculates the SHA-256 hash of the given bytes.
+func HashContent(content []byte) string {
+	hasher := sha256.New()
+	hasher.Write(content)
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// reconciliation describes how a rendered file should be written after drift checks.
+type reconciliation struct {
+	// writePath is the absolute destination. Empty means skip (keep-local).
+	writePath string
+
+	// merge requests a three-way merge of the stored base, the local file and the new rendering.
+	merge bool
+}
+
+// managedPath returns the key of a managed file in the manifest's ManagedFiles:
+// its path relative to the manifest root, so files of different projects
+// don't collide.
+func managedPath(outputRelPath string, tc types.TaskContext) string {
+	if tc.RootPath == "" {
+		return filepath.ToSlash(outputRelPath)
+	}
+	rel, err := filepath.Rel(tc.RootPath, filepath.Join(tc.TargetPath, outputRelPath))
+	if err != nil {
+		return filepath.ToSlash(outputRelPath)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// baseRoot returns the directory the merge bases of tc's run are stored under.
+func baseRoot(tc types.TaskContext) string {
+	if tc.RootPath == "" {
+		return tc.TargetPath
+	}
+	return tc.RootPath
+}
+
+// checkReconciliation evaluates drift and conflict strategies; key is the
+// file's ManagedFiles key (see managedPath).
+// Returns where (and how) to write, or an error if the operation should abort.
+func checkReconciliation(absFinalPath, outputRelPath, key string, rendered []byte, tc types.TaskContext) (reconciliation, error) {
+	// If force is enabled, we always overwrite the original file, ignoring state.
+	if tc.Force {
+		return reconciliation{writePath: absFinalPath}, nil
+	}
+
+	// Read existing file
+	//nolint:gosec // Path is canonicalized
+	existingContent, err := os.ReadFile(absFinalPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return reconciliation{writePath: absFinalPath}, nil // Safe to write, file doesn't exist
+		}
+		return reconciliation{}, fmt.Errorf("failed to read existing file %s: %w", outputRelPath, err)
+	}
+
+	existingHash := HashContent(existingContent)
+	newContentHash := HashContent(rendered)
+
+	// Check state
+	originalHash := tc.Manifest.ManagedHash(key)
+
+	// If the file exists but we've never managed it (or didn't record it), it's a conflict
+	if originalHash == "" {
+		return handleConflict(absFinalPath, outputRelPath, existingContent, rendered, tc)
+	}
+
+	// If the file exactly matches what we originally generated, it hasn't drifted. Safe to update.
+	if existingHash == originalHash {
+		return reconciliation{writePath: absFinalPath}, nil
+	}
+
+	// If the existing file happens to already match what we're trying to generate, we're good (idempotent).
+	if existingHash == newContentHash {
+		return reconciliation{writePath: absFinalPath}, nil
+	}
+
+	// Drift detected! The user modified the file.
+	return handleConflict(absFinalPath, outputRelPath, existingContent, rendered, tc)
+}
+
+func handleConflict(absFinalPath, outputRelPath string, local, rendered []byte, tc types.TaskContext) (reconciliation, error) {
+	strategy := conflictStrategyFor(outputRelPath, tc)
+
+	if strategy == "prompt" {
+		if tc.DryRun {
+			fmt.Printf("⚠️  Conflict in %s (user modifications detected). Resolution will be prompted.\n", outputRelPath)
+			return reconciliation{}, nil
+		}
+		if tc.ConflictResolver == nil {
+			return reconciliation{}, fmt.Errorf("file %s has manual modifications (drift detected) and no interactive resolver is available", outputRelPath)
+		}
+		var err error
+		if strategy, err = tc.ConflictResolver.ResolveConflict(outputRelPath, local, rendered); err != nil {
+			return reconciliation{}, err
+		}
+	}
+
+	switch strategy {
+	case "overwrite":
+		return reconciliation{writePath: absFinalPath}, nil
+	case "artifact":
+		fmt.Printf("⚠️  Conflict in %s (user modifications detected). Writing new template to artifact.\n", outputRelPath)
+		return reconciliation{writePath: absFinalPath + ArtifactSuffix}, nil
+	case "keep-local":
+		fmt.Printf("⚠️  Conflict in %s (user modifications detected). Skipping update (--strategy=keep-local).\n", outputRelPath)
+		return reconciliation{}, nil // Signal to skip
+	case "merge":
+		return reconciliation{writePath: absFinalPath, merge: true}, nil
+	case "fail":
+		fallthrough
+	default:
+		return reconciliation{}, fmt.Errorf("file %s has manual modifications (drift detected). Use --conflict-strategy to resolve", outputRelPath)
+	}
+}
+
+// conflictStrategyFor returns the strategy for a drifted file. An entry in the
+// manifest's [conflict_strategies] section overrides the global strategy; exact
+// paths win over globs, and globs are tried in lexical order.
+func conflictStrategyFor(outputRelPath string, tc types.TaskContext) string {
+	if tc.Manifest == nil || len(tc.Manifest.ConflictStrategies) == 0 {
+		return tc.ConflictStrategy
+	}
+
+	overrides := tc.Manifest.ConflictStrategies
+	rel := filepath.ToSlash(outputRelPath)
+	if strategy, ok := overrides[rel]; ok {
+		return strategy
+	}
+
+	patterns := make([]string, 0, len(overrides))
+	for p := range overrides {
+		patterns = append(patterns, p)
+	}
+	sort.Strings(patterns)
+
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, rel); ok {
+			return overrides[p]
+		}
+	}
+
+	return tc.ConflictStrategy
+}
+
+// basePath returns where the last rendered content of the managed file with
+// the given key is stored, below the manifest root.
+func basePath(rootPath, key string) string {
+	return filepath.Join(rootPath, fileutil.InternalDir, fileutil.BaseDir, filepath.FromSlash(key))
+}
+
+// mergeWithBase performs a three-way merge of the stored base, the local file and
+// the new rendering. If no base was recorded (e.g. the file predates merge support),
+// it falls back to writing an artifact next to the local file.
+// Returns the path and content to write.
+func mergeWithBase(absFinalPath, outputRelPath, key string, rendered []byte, tc types.TaskContext) (string, []byte, error) {
+	//nolint:gosec // Path is constructed from the canonical root path
+	base, err := os.ReadFile(basePath(baseRoot(tc), key))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return "", nil, fmt.Errorf("failed to read merge base for %s: %w", outputRelPath, err)
+		}
+		fmt.Printf("⚠️  Conflict in %s (no merge base recorded). Writing new template to artifact.\n", outputRelPath)
+		return absFinalPath + ArtifactSuffix, rendered, nil
+	}
+
+	//nolint:gosec // Path is canonicalized
+	local, err := os.ReadFile(absFinalPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read local file %s: %w", outputRelPath, err)
+	}
+
+	res := merge.ThreeWay(base, local, rendered)
+	if res.HasConflicts() {
+		fmt.Printf("⚠️  Conflict in %s: %d hunk(s) need manual resolution (look for %q markers).\n",
+			outputRelPath, res.Conflicts, merge.MarkerLocal)
+		tc.Conflicts.Add(key)
+	} else {
+		fmt.Printf("🔀 Merged user modifications in %s with the updated template.\n", outputRelPath)
+	}
+
+	return absFinalPath, res.Content, nil
+}
+
+// writeBase records the rendered content as the merge base for future runs.
+// The directories it creates below .scbake are tracked so a rollback removes
+// them along with everything else; the transaction prunes .scbake itself.
+func writeBase(outputRelPath, key string, rendered []byte, tc types.TaskContext) error {
+	baseFile := basePath(baseRoot(tc), key)
+
+	if tc.Tx != nil {
+		internalDir := filepath.Join(baseRoot(tc), fileutil.InternalDir)
+		if dir := firstMissingDir(internalDir, filepath.Dir(baseFile)); dir != "" {
+			if err := tc.Tx.Track(dir); err != nil {
+				return fmt.Errorf("failed to track merge base directory: %w", err)
+			}
+		}
+		if err := tc.Tx.Track(baseFile); err != nil {
+			return fmt.Errorf("failed to track merge base for %s: %w", outputRelPath, err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(baseFile), fileutil.DirPerms); err != nil {
+		return fmt.Errorf("failed to create merge base directory: %w", err)
+	}
+	if err := os.WriteFile(baseFile, rendered, fileutil.PrivateFilePerms); err != nil {
+		return fmt.Errorf("failed to write merge base for %s: %w", outputRelPath, err)
+	}
+	return nil
+}
+
+// firstMissingDir returns the topmost directory below parent that
+// os.MkdirAll(dir) would create, or "" if dir already exists.
+func firstMissingDir(parent, dir string) string {
+	missing := ""
+	for d := dir; d != parent && d != filepath.Dir(d); d = filepath.Dir(d) {
+		if _, err := os.Stat(d); err == nil {
+			break
+		}
+		missing = d
+	}
+	return missing
+}
+
+// checkFilePreconditions verifies that the output path stays within the target path.
+func checkFilePreconditions(finalPath, output, target string) error {
+	// Path Safety Check (Canonicalization)
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		return fmt.Errorf("failed to resolve target path: %w", err)
+	}
+	absFinal, err := filepath.Abs(finalPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve output path: %w", err)
+	}
+
+	cleanTarget := filepath.Clean(absTarget)
+	cleanFinalPath := filepath.Clean(absFinal)
+
+	if !strings.HasPrefix(cleanFinalPath, cleanTarget) {
+		return fmt.Errorf("task failed (%s): output path '%s' is outside the target path '%s'",
+			filepath.Base(output), output, target)
+	}
+
+	return nil
+}
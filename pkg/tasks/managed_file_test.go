@@ -0,0 +1,205 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package tasks
+
+import (
+	"os"
+	"path/filepath"
+	"scbake/internal/filesystem/transaction"
+	"scbake/internal/types"
+	"testing"
+)
+
+func TestManagedFile_Write(t *testing.T) {
+	tmpDir := t.TempDir()
+	tc := types.TaskContext{
+		TargetPath: tmpDir,
+		Manifest:   &types.Manifest{},
+	}
+
+	f := ManagedFile{OutputPath: "nested/dir/out.txt", Content: []byte("content\n")}
+	if err := f.Write(tc); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	//nolint:gosec // Test temp directory
+	got, err := os.ReadFile(filepath.Join(tmpDir, "nested", "dir", "out.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if string(got) != "content\n" {
+		t.Errorf("Content mismatch: %q", got)
+	}
+	if tc.Manifest.ManagedFiles["nested/dir/out.txt"] != HashContent(f.Content) {
+		t.Error("Hash was not recorded in ManagedFiles")
+	}
+	if _, err := os.Stat(basePath(tmpDir, "nested/dir/out.txt")); err != nil {
+		t.Errorf("Merge base was not recorded: %v", err)
+	}
+}
+
+func TestManagedFile_DryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	tc := types.TaskContext{
+		TargetPath: tmpDir,
+		Manifest:   &types.Manifest{},
+		DryRun:     true,
+	}
+
+	if err := (ManagedFile{OutputPath: "sub/out.txt", Content: []byte("x")}).Write(tc); err != nil {
+		t.Fatalf("Dry-run write failed: %v", err)
+	}
+
+	// Neither the file nor its parent directory may be created.
+	if _, err := os.Stat(filepath.Join(tmpDir, "sub")); !os.IsNotExist(err) {
+		t.Error("Dry-run should not touch the filesystem")
+	}
+	if len(tc.Manifest.ManagedFiles) != 0 {
+		t.Error("Dry-run should not record state")
+	}
+}
+
+func TestManagedFile_Mode(t *testing.T) {
+	tmpDir := t.TempDir()
+	tc := types.TaskContext{
+		TargetPath: tmpDir,
+		Manifest:   &types.Manifest{},
+	}
+
+	script := ManagedFile{OutputPath: "run.sh", Content: []byte("#!/bin/sh\n"), Mode: 0o755}
+	if err := script.Write(tc); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(tmpDir, "run.sh"))
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("Expected executable bit, got %v", info.Mode().Perm())
+	}
+}
+
+func TestManagedFile_PathTraversal(t *testing.T) {
+	tmpDir := t.TempDir()
+	tc := types.TaskContext{
+		TargetPath: tmpDir,
+		Manifest:   &types.Manifest{},
+	}
+
+	if err := (ManagedFile{OutputPath: "../escape.txt", Content: []byte("x")}).Write(tc); err == nil {
+		t.Error("Expected error for output path outside the target path")
+	}
+}
+
+func TestManagedFile_Projects(t *testing.T) {
+	root := t.TempDir()
+	m := &types.Manifest{}
+	rootTC := types.TaskContext{TargetPath: root, RootPath: root, Manifest: m}
+	backendTC := types.TaskContext{TargetPath: filepath.Join(root, "backend"), RootPath: root, Manifest: m}
+
+	// Both projects render a file at the same project-relative path.
+	if err := (ManagedFile{OutputPath: "internal/cli/cli.go", Content: []byte("root v1\n")}).Write(rootTC); err != nil {
+		t.Fatalf("root write failed: %v", err)
+	}
+	if err := (ManagedFile{OutputPath: "internal/cli/cli.go", Content: []byte("backend v1\n")}).Write(backendTC); err != nil {
+		t.Fatalf("backend write failed: %v", err)
+	}
+	if m.ManagedFiles["internal/cli/cli.go"] != HashContent([]byte("root v1\n")) {
+		t.Error("root hash was not recorded at its root-relative path")
+	}
+	if m.ManagedFiles["backend/internal/cli/cli.go"] != HashContent([]byte("backend v1\n")) {
+		t.Error("backend hash was not recorded at its root-relative path")
+	}
+	if _, err := os.Stat(basePath(root, "backend/internal/cli/cli.go")); err != nil {
+		t.Errorf("backend merge base was not recorded below the root: %v", err)
+	}
+
+	// Re-rendering the root file must not see the backend's hash as drift.
+	if err := (ManagedFile{OutputPath: "internal/cli/cli.go", Content: []byte("root v2\n")}).Write(rootTC); err != nil {
+		t.Fatalf("root update failed: %v", err)
+	}
+}
+
+func TestManagedFile_Rollback(t *testing.T) {
+	tmpDir := t.TempDir()
+	tx, err := transaction.New(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create transaction: %v", err)
+	}
+
+	existing := filepath.Join(tmpDir, "existing.txt")
+	if err := os.WriteFile(existing, []byte("original"), 0600); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	tc := types.TaskContext{
+		TargetPath:       tmpDir,
+		Manifest:         &types.Manifest{},
+		ConflictStrategy: "overwrite",
+		Tx:               tx,
+	}
+
+	for _, f := range []ManagedFile{
+		{OutputPath: "existing.txt", Content: []byte("rendered")},
+		{OutputPath: "created.txt", Content: []byte("rendered")},
+	} {
+		if err := f.Write(tc); err != nil {
+			t.Fatalf("Write %s failed: %v", f.OutputPath, err)
+		}
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+
+	//nolint:gosec // Test temp directory
+	got, _ := os.ReadFile(existing)
+	if string(got) != "original" {
+		t.Errorf("Existing file not restored, got %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "created.txt")); !os.IsNotExist(err) {
+		t.Error("Created file should be removed on rollback")
+	}
+}
+
+func TestManagedFile_RollbackBase(t *testing.T) {
+	tmpDir := t.TempDir()
+	tx, err := transaction.New(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create transaction: %v", err)
+	}
+	tc := types.TaskContext{TargetPath: tmpDir, RootPath: tmpDir, Manifest: &types.Manifest{}, Tx: tx}
+
+	if err := (ManagedFile{OutputPath: "nested/out.txt", Content: []byte("x")}).Write(tc); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".scbake")); !os.IsNotExist(err) {
+		t.Error(".scbake should be removed on rollback")
+	}
+
+	// With existing bases, only the directories the write created are removed.
+	kept := basePath(tmpDir, "kept.txt")
+	if err := os.MkdirAll(filepath.Dir(kept), 0o750); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if err := os.WriteFile(kept, []byte("kept"), 0o600); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if err := (ManagedFile{OutputPath: "nested/out.txt", Content: []byte("x")}).Write(tc); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Dir(basePath(tmpDir, "nested/out.txt"))); !os.IsNotExist(err) {
+		t.Error("Created merge base directory should be removed on rollback")
+	}
+	if _, err := os.Stat(kept); err != nil {
+		t.Errorf("Existing merge base should survive rollback: %v", err)
+	}
+}
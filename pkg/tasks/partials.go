@@ -0,0 +1,153 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package tasks
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"scbake/internal/templateregistry"
+	"scbake/internal/types"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// PartialsDir is the directory name whose templates are loaded as partials.
+const PartialsDir = "partials"
+
+// sharedPartials are available to every template.
+//
+//go:embed partials/*.tpl
+var sharedPartials embed.FS
+
+// partial is a named template loaded next to the template being rendered.
+type partial struct {
+	name    string
+	content []byte
+}
+
+// IsPartial reports whether the slash-separated path rel names a partial:
+// a ".tpl" file inside a "partials" directory. Other files, including ones
+// starting with "_" such as "__init__.py.tpl", are regular templates.
+func IsPartial(rel string) bool {
+	return strings.HasSuffix(rel, TemplateSuffix) && path.Base(path.Dir(rel)) == PartialsDir
+}
+
+// partialName derives the template name of a partial from its path:
+// "partials/languages.tpl" becomes "languages".
+func partialName(rel string) string {
+	return strings.TrimSuffix(path.Base(rel), TemplateSuffix)
+}
+
+// loadPartials returns the partials visible to templates in dir of fsys,
+// lowest precedence first:
+//  1. shared partials embedded in this package (partials/*.tpl)
+//  2. "partials/*.tpl" next to the template
+//
+// Every partial is read through ReadTemplate, so the override directory and
+// registry cache replace embedded partials file by file, and partials that
+// only exist there are picked up as well.
+func loadPartials(fsys fs.FS, dir, overrideDir, registryCacheDir string) ([]partial, error) {
+	shared, err := readPartials(sharedPartials, ".", overrideDir, registryCacheDir)
+	if err != nil {
+		return nil, err
+	}
+	if fsys == nil {
+		return shared, nil
+	}
+	local, err := readPartials(fsys, dir, overrideDir, registryCacheDir)
+	if err != nil {
+		return nil, err
+	}
+	return append(shared, local...), nil
+}
+
+// readPartials resolves every partial in dir across all sources.
+func readPartials(fsys fs.FS, dir, overrideDir, registryCacheDir string) ([]partial, error) {
+	found := make(map[string]bool)
+
+	for _, pattern := range partialPatterns(dir) {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid partial pattern %s: %w", pattern, err)
+		}
+		for _, m := range matches {
+			found[m] = true
+		}
+	}
+
+	// Partials that only exist in the override dir or registry cache.
+	roots := []string{}
+	if overrideDir != "" {
+		roots = append(roots, filepath.Join(overrideDir, filepath.Clean(dir)))
+	}
+	if cached := templateregistry.ResolveCachePath(registryCacheDir, dir); cached != "" {
+		roots = append(roots, cached)
+	}
+	for _, root := range roots {
+		for _, pattern := range partialPatterns(".") {
+			matches, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(pattern)))
+			if err != nil {
+				return nil, fmt.Errorf("invalid partial pattern %s: %w", pattern, err)
+			}
+			for _, m := range matches {
+				rel, err := filepath.Rel(root, m)
+				if err != nil {
+					return nil, err
+				}
+				found[path.Join(dir, filepath.ToSlash(rel))] = true
+			}
+		}
+	}
+
+	paths := make([]string, 0, len(found))
+	for p := range found {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	partials := make([]partial, 0, len(paths))
+	for _, p := range paths {
+		content, err := ReadTemplate(fsys, p, overrideDir, registryCacheDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read partial %s: %w", p, err)
+		}
+		partials = append(partials, partial{name: partialName(p), content: content})
+	}
+	return partials, nil
+}
+
+func partialPatterns(dir string) []string {
+	return []string{path.Join(dir, PartialsDir, "*"+TemplateSuffix)}
+}
+
+// newTemplate creates a template named name with the partials defined as
+// associated templates. Partials are parsed in order, so a later partial
+// replaces an earlier one of the same name; the caller parses the main
+// template last, letting its own {{ define }} blocks win.
+func newTemplate(name string, m *types.Manifest, partials []partial) (*template.Template, error) {
+	tpl := template.New(name)
+	tpl.Funcs(TemplateFuncs(m)).Funcs(template.FuncMap{
+		// include renders a named template to a string so its output can be
+		// piped, e.g. {{ include "languages" . | split "," }}.
+		"include": func(name string, data interface{}) (string, error) {
+			var buf bytes.Buffer
+			if err := tpl.ExecuteTemplate(&buf, name, data); err != nil {
+				return "", err
+			}
+			return buf.String(), nil
+		},
+	})
+
+	for _, p := range partials {
+		if _, err := tpl.New(p.name).Parse(string(p.content)); err != nil {
+			return nil, fmt.Errorf("failed to parse partial %s: %w", p.name, err)
+		}
+	}
+	return tpl, nil
+}
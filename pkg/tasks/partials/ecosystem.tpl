@@ -0,0 +1,6 @@
+{{- /* Dependabot package ecosystem of a language; empty if unsupported. */ -}}
+{{- if eq . "go" }}gomod
+{{- else if or (eq . "svelte") (eq . "node") }}npm
+{{- else if eq . "python" }}pip
+{{- else if eq . "rust" }}cargo
+{{- end -}}
@@ -0,0 +1,8 @@
+{{- /* Distinct project languages in manifest order, comma-separated (e.g. "go,svelte"). */ -}}
+{{- $out := "" -}}
+{{- range .Projects -}}
+{{- if and .Language (not (contains (printf ",%s," .Language) (printf ",%s," $out))) -}}
+{{- if $out }}{{ $out = printf "%s,%s" $out .Language }}{{ else }}{{ $out = .Language }}{{ end -}}
+{{- end -}}
+{{- end -}}
+{{- $out -}}
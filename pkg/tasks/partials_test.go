@@ -0,0 +1,152 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package tasks
+
+import (
+	"os"
+	"path/filepath"
+	"scbake/internal/types"
+	"testing"
+	"testing/fstest"
+)
+
+func partialsManifest() *types.Manifest {
+	return &types.Manifest{Projects: []types.Project{
+		{Name: "api", Language: "go"},
+		{Name: "web", Language: "svelte"},
+		{Name: "worker", Language: "go"},
+		{Name: "docs"},
+	}}
+}
+
+func renderPartialsTemplate(t *testing.T, fsys fstest.MapFS, overrideDir string) string {
+	t.Helper()
+
+	tpl, err := ReadTemplate(fsys, "tpl/main.tpl", overrideDir, "")
+	if err != nil {
+		t.Fatalf("ReadTemplate failed: %v", err)
+	}
+	partials, err := loadPartials(fsys, "tpl", overrideDir, "")
+	if err != nil {
+		t.Fatalf("loadPartials failed: %v", err)
+	}
+	m := partialsManifest()
+	out, err := renderString("tpl/main.tpl", string(tpl), m, m, partials)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	return out
+}
+
+func TestPartials_SharedAndLocal(t *testing.T) {
+	fsys := fstest.MapFS{
+		"tpl/main.tpl":              {Data: []byte(`[{{ template "greeting" . }}] {{ include "languages" . }} {{ template "footer" }}`)},
+		"tpl/partials/greeting.tpl": {Data: []byte(`hi {{ (index .Projects 0).Name }}`)},
+		"tpl/partials/footer.tpl":   {Data: []byte(`bye`)},
+	}
+
+	got := renderPartialsTemplate(t, fsys, "")
+	if want := "[hi api] go,svelte bye"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPartials_OverridePrecedence(t *testing.T) {
+	fsys := fstest.MapFS{
+		"tpl/main.tpl":              {Data: []byte(`[{{ template "greeting" . }}] {{ template "languages" . }} {{ template "extra" }}`)},
+		"tpl/partials/greeting.tpl": {Data: []byte(`hi`)},
+	}
+
+	overrideDir := t.TempDir()
+	for rel, content := range map[string]string{
+		"tpl/partials/greeting.tpl": "override",
+		"tpl/partials/extra.tpl":    "added",
+		"partials/languages.tpl":    "custom",
+	} {
+		p := filepath.Join(overrideDir, rel)
+		//nolint:gosec // Test temp directory
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		//nolint:gosec // Test temp directory
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := renderPartialsTemplate(t, fsys, overrideDir)
+	if want := "[override] custom added"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPartials_Ecosystem(t *testing.T) {
+	shared, err := loadPartials(nil, "", "", "")
+	if err != nil {
+		t.Fatalf("loadPartials failed: %v", err)
+	}
+
+	for lang, want := range map[string]string{"go": "gomod", "svelte": "npm", "cobol": ""} {
+		got, err := renderString("eco", `{{ include "ecosystem" . }}`, lang, nil, shared)
+		if err != nil {
+			t.Fatalf("render failed: %v", err)
+		}
+		if got != want {
+			t.Errorf("ecosystem %s: got %q, want %q", lang, got, want)
+		}
+	}
+}
+
+func TestCreateTreeTask_Partials(t *testing.T) {
+	tmpDir := t.TempDir()
+	fsys := fstest.MapFS{
+		"tree/README.md.tpl":          {Data: []byte(`{{ template "title" . }}`)},
+		"tree/partials/title.tpl":     {Data: []byte(`# {{ include "languages" . }}`)},
+		"tree/__init__.py.tpl":        {Data: []byte(`"""{{ (index .Projects 0).Name }}"""`)},
+		"tree/partials/unused.tpl":    {Data: []byte(`unused`)},
+		"tree/docs/partials/note.tpl": {Data: []byte(`note`)},
+	}
+	task := &CreateTreeTask{TemplateFS: fsys, Root: "tree"}
+	tc := types.TaskContext{TargetPath: tmpDir, Manifest: partialsManifest()}
+
+	if err := task.Execute(tc); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	//nolint:gosec // Test temp directory
+	content, err := os.ReadFile(filepath.Join(tmpDir, "README.md"))
+	if err != nil {
+		t.Fatalf("README.md not written: %v", err)
+	}
+	if want := "# go,svelte"; string(content) != want {
+		t.Errorf("README.md = %q, want %q", content, want)
+	}
+
+	//nolint:gosec // Test temp directory
+	if content, err := os.ReadFile(filepath.Join(tmpDir, "__init__.py")); err != nil || string(content) != `"""api"""` {
+		t.Errorf("__init__.py = %q (err: %v), want it rendered like any template", content, err)
+	}
+
+	for _, rel := range []string{"partials", "docs"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, rel)); !os.IsNotExist(err) {
+			t.Errorf("partial %s should not be written", rel)
+		}
+	}
+}
+
+func TestIsPartial(t *testing.T) {
+	for rel, want := range map[string]bool{
+		"partials/languages.tpl":   true,
+		"a/partials/languages.tpl": true,
+		"_languages.tpl":           false,
+		"__init__.py.tpl":          false,
+		"_notes.md":                false,
+		"main.yml.tpl":             false,
+		"partials/README.md":       false,
+	} {
+		if got := IsPartial(rel); got != want {
+			t.Errorf("IsPartial(%q) = %v, want %v", rel, got, want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package tasks
+
+import (
+	"scbake/internal/types"
+	"time"
+)
+
+// Template sources reported in TemplateContext.Source.
+const (
+	SourceOverride = "override" // --template-dir or SCBAKE_TEMPLATE_DIR
+	SourceRegistry = "registry" // registry cache
+	SourceEmbedded = "embedded" // built into the binary
+)
+
+// TemplateContext is the data every scbake template is rendered with, unless
+// a task passes its own TemplateData. Its fields are a stable contract for
+// template authors:
+//
+//	.Project        the project being rendered for (nil for repository-level templates)
+//	.Projects       all projects in the manifest, including those added by this run
+//	.Metadata       manifest metadata merged with --set values and schema defaults,
+//	                overridden by the metadata of .Project
+//	.Template       the template path, e.g. "go.mod.tpl"
+//	.Source         where the template was read from: "override", "registry" or "embedded"
+//	.Version        the running scbake version
+//	.TargetPath     the directory the template is rendered into
+//	.Timestamp      the render time (UTC)
+//
+// The manifest is embedded, so templates written against the manifest
+// (.Projects, .Metadata, .SbakeVersion) keep working unchanged.
+type TemplateContext struct {
+	*types.Manifest
+
+	Project    *types.Project
+	Metadata   map[string]string // Shadows Manifest.Metadata.
+	Template   string
+	Source     string
+	Version    string
+	TargetPath string
+	Timestamp  time.Time
+}
+
+// NewTemplateContext builds the context for rendering tplPath from source.
+func NewTemplateContext(tc types.TaskContext, tplPath, source string) *TemplateContext {
+	m := tc.Manifest
+	if m == nil {
+		m = &types.Manifest{}
+	}
+	return &TemplateContext{
+		Manifest:   m,
+		Project:    tc.Project,
+		Metadata:   tc.Metadata(),
+		Template:   tplPath,
+		Source:     source,
+		Version:    tc.ScbakeVersion,
+		TargetPath: tc.TargetPath,
+		Timestamp:  time.Now().UTC(),
+	}
+}
+
+// templateData picks the data a template is rendered with: custom data if
+// set, else the context passed through the task's ContextData, else ctx.
+func templateData(custom interface{}, contextData func(*TemplateContext) (interface{}, error), ctx *TemplateContext) (interface{}, error) {
+	if custom != nil {
+		return custom, nil
+	}
+	if contextData != nil {
+		return contextData(ctx)
+	}
+	return ctx, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package tasks
+
+import (
+	"os"
+	"path/filepath"
+	"scbake/internal/types"
+	"testing"
+	"testing/fstest"
+)
+
+func TestNewTemplateContext(t *testing.T) {
+	m := &types.Manifest{
+		SbakeVersion: "v0.3.0",
+		Projects:     []types.Project{{Name: "api", Path: "api", Language: "go"}},
+		Metadata:     map[string]string{"go_version": "1.23"},
+	}
+	tc := types.TaskContext{
+		TargetPath:    "/tmp/repo/api",
+		Manifest:      m,
+		Project:       &m.Projects[0],
+		ScbakeVersion: "v0.5.0",
+	}
+
+	ctx := NewTemplateContext(tc, "go.mod.tpl", SourceEmbedded)
+	text := `{{ .Project.Name }} {{ .Template }} {{ .Source }} {{ .Version }} {{ .TargetPath }} ` +
+		`{{ .Metadata.go_version }} {{ len .Projects }} {{ .SbakeVersion }} {{ not .Timestamp.IsZero }}`
+
+	got, err := renderString("ctx", text, ctx, m, nil)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	want := "api go.mod.tpl embedded v0.5.0 /tmp/repo/api 1.23 1 v0.3.0 true"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// Repository-level runs have no project and may lack a manifest.
+	ctx = NewTemplateContext(types.TaskContext{}, "x.tpl", SourceEmbedded)
+	if got, err := renderString("ctx", `{{ if .Project }}p{{ end }}{{ len .Projects }}`, ctx, nil, nil); err != nil || got != "0" {
+		t.Errorf("empty context rendered %q (err: %v), want \"0\"", got, err)
+	}
+}
+
+func TestResolveTemplate_Source(t *testing.T) {
+	fsys := fstest.MapFS{"a.tpl": {Data: []byte("embedded")}}
+	overrideDir := t.TempDir()
+
+	if _, src, err := resolveTemplate(fsys, "a.tpl", overrideDir, ""); err != nil || src != SourceEmbedded {
+		t.Errorf("source = %q (err: %v), want %q", src, err, SourceEmbedded)
+	}
+
+	//nolint:gosec // Test temp directory
+	if err := os.WriteFile(filepath.Join(overrideDir, "a.tpl"), []byte("override"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, src, err := resolveTemplate(fsys, "a.tpl", overrideDir, ""); err != nil || src != SourceOverride {
+		t.Errorf("source = %q (err: %v), want %q", src, err, SourceOverride)
+	}
+}
+
+func TestCreateTemplateTask_TemplateContext(t *testing.T) {
+	tmpDir := t.TempDir()
+	overrideDir := t.TempDir()
+	tplDir := filepath.Join(overrideDir, "testdata")
+	//nolint:gosec // Test temp directory
+	if err := os.MkdirAll(tplDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	//nolint:gosec // Test temp directory
+	if err := os.WriteFile(filepath.Join(tplDir, "simple.tpl"), []byte("{{ .Template }} from {{ .Source }} by {{ .Version }}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	task := &CreateTemplateTask{TemplateFS: testTemplates, TemplatePath: "testdata/simple.tpl", OutputPath: "out.txt"}
+	tc := types.TaskContext{TargetPath: tmpDir, Manifest: &types.Manifest{}, TemplateDir: overrideDir, ScbakeVersion: "v9"}
+	if err := task.Execute(tc); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	//nolint:gosec // Test temp directory
+	content, err := os.ReadFile(filepath.Join(tmpDir, "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "testdata/simple.tpl from override by v9"; string(content) != want {
+		t.Errorf("got %q, want %q", content, want)
+	}
+}
@@ -1,7 +1,6 @@
 package tasks
 
 import (
-	"embed"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -15,7 +14,13 @@ import (
 //
 // If overrideDir or registryCacheDir is empty, those steps are skipped.
 // Permission errors on override files are propagated; missing files fall through.
-func ReadTemplate(efs embed.FS, tplPath string, overrideDir, registryCacheDir string) ([]byte, error) {
+func ReadTemplate(efs fs.FS, tplPath string, overrideDir, registryCacheDir string) ([]byte, error) {
+	content, _, err := resolveTemplate(efs, tplPath, overrideDir, registryCacheDir)
+	return content, err
+}
+
+// resolveTemplate is ReadTemplate, also reporting the source the template was read from.
+func resolveTemplate(efs fs.FS, tplPath string, overrideDir, registryCacheDir string) ([]byte, string, error) {
 	if overrideDir != "" {
 		cleanTplPath := filepath.Clean(tplPath)
 		overridePath := filepath.Join(overrideDir, cleanTplPath)
@@ -23,11 +28,11 @@ func ReadTemplate(efs embed.FS, tplPath string, overrideDir, registryCacheDir st
 		//nolint:gosec // overrideDir is user-provided and trusted
 		content, err := os.ReadFile(overridePath)
 		if err == nil {
-			return content, nil
+			return content, SourceOverride, nil
 		}
 
 		if !os.IsNotExist(err) {
-			return nil, err
+			return nil, "", err
 		}
 	}
 
@@ -37,10 +42,14 @@ func ReadTemplate(efs embed.FS, tplPath string, overrideDir, registryCacheDir st
 			//nolint:gosec // cachePath is within our controlled cache dir
 			content, err := os.ReadFile(cachePath)
 			if err == nil {
-				return content, nil
+				return content, SourceRegistry, nil
 			}
 		}
 	}
 
-	return fs.ReadFile(efs, tplPath)
+	content, err := fs.ReadFile(efs, tplPath)
+	if err != nil {
+		return nil, "", err
+	}
+	return content, SourceEmbedded, nil
 }
@@ -0,0 +1,5 @@
+# {{ (index .Projects 0).Name }}
+
+Generated by scbake.
+
+version: {{ .SbakeVersion }}
@@ -0,0 +1 @@
+# {{.Name}}
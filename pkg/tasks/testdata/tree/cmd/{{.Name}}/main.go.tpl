@@ -0,0 +1,4 @@
+package main
+
+// {{.Name}} entrypoint
+func main() {}
@@ -6,6 +6,8 @@ package cighub
 import (
 	"embed"
 	"fmt"
+	"io/fs"
+	"path/filepath"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
 )
@@ -17,13 +19,18 @@ var templates embed.FS
 type Handler struct{}
 
 // SchemaFS returns the embedded filesystem containing schema.json.
-func (h *Handler) SchemaFS() embed.FS { return templates }
+func (h *Handler) SchemaFS() fs.FS { return templates }
 
 // SchemaPath returns the path to the embedded schema definition.
 func (h *Handler) SchemaPath() string { return "schema.json" }
 
+// Dependencies suggests git, since the workflow runs on pushes to a repository.
+func (h *Handler) Dependencies() types.Dependencies {
+	return types.Dependencies{Suggests: []string{"git"}}
+}
+
 // GetTasks returns the plan to create the GitHub Actions workflow file.
-func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
+func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task, error) {
 	var plan []types.Task
 
 	// Initialize sequence for the CI band (1100-1199)
@@ -34,7 +41,7 @@ func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
 
 	// This task creates the workflow file in the required GitHub location.
 	// Since no TemplateData is provided here, the template will receive the
-	// *full manifest* as its execution context, allowing conditional logic.
+	// TemplateContext (all projects and metadata), allowing conditional logic.
 	p, err := seq.Next()
 	if err != nil {
 		return nil, err
@@ -45,6 +52,7 @@ func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
 		OutputPath:   ".github/workflows/main.yml",
 		Desc:         "Create GitHub Actions CI workflow",
 		TaskPrio:     int(p), // Now 1100
+		Schedule:     types.Schedule{Resources: []string{filepath.Join(targetPath, ".github/workflows/main.yml")}},
 	})
 
 	return plan, nil
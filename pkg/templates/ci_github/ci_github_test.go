@@ -4,8 +4,11 @@
 package cighub
 
 import (
+	"os"
+	"path/filepath"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
+	"strings"
 	"testing"
 )
 
@@ -53,3 +56,106 @@ func TestGetTasks_TemplateBinding(t *testing.T) {
 		t.Errorf("Embedded template file '%s' not found: %v", task.TemplatePath, err)
 	}
 }
+
+// TestRender_SetupStepsPerLanguage checks that each language gets exactly one
+// setup step, however many projects use it.
+func TestRender_SetupStepsPerLanguage(t *testing.T) {
+	handler := &Handler{}
+	plan, _ := handler.GetTasks("", "", "")
+	task := plan[0].(*tasks.CreateTemplateTask)
+
+	tmpDir := t.TempDir()
+	m := &types.Manifest{Projects: []types.Project{
+		{Name: "api", Language: "go", Path: "api"},
+		{Name: "worker", Language: "go", Path: "worker"},
+		{Name: "web", Language: "svelte", Path: "web"},
+		{Name: "gateway", Language: "node", Path: "gateway", BuildTool: "npm"},
+		{Name: "etl", Language: "python", Path: "etl"},
+		{Name: "core", Language: "rust", Path: "core"},
+	}}
+	if err := task.Execute(types.TaskContext{TargetPath: tmpDir, Manifest: m}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	//nolint:gosec // Test temp directory
+	content, err := os.ReadFile(filepath.Join(tmpDir, task.OutputPath))
+	if err != nil {
+		t.Fatalf("Workflow not written: %v", err)
+	}
+	for step, want := range map[string]int{
+		"Setup Go Environment":      1,
+		"Setup Node.js Environment": 1,
+		"Setup Python Environment":  1,
+		"Setup Rust Toolchain":      1,
+		"Setup Java Environment":    0,
+	} {
+		if got := strings.Count(string(content), step); got != want {
+			t.Errorf("%q appears %d times, want %d", step, got, want)
+		}
+	}
+}
+
+// TestRender_SpringBuildTool checks that Spring projects are built with the
+// wrapper of their recorded build tool.
+func TestRender_SpringBuildTool(t *testing.T) {
+	handler := &Handler{}
+	plan, _ := handler.GetTasks("", "", "")
+	task := plan[0].(*tasks.CreateTemplateTask)
+
+	tmpDir := t.TempDir()
+	m := &types.Manifest{Projects: []types.Project{
+		{Name: "orders", Language: "spring", Path: "orders", BuildTool: "maven"},
+		{Name: "stock", Language: "spring", Path: "stock", BuildTool: "gradle"},
+	}}
+	if err := task.Execute(types.TaskContext{TargetPath: tmpDir, Manifest: m}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	//nolint:gosec // Test temp directory
+	content, err := os.ReadFile(filepath.Join(tmpDir, task.OutputPath))
+	if err != nil {
+		t.Fatalf("Workflow not written: %v", err)
+	}
+	for cmd, want := range map[string]int{
+		"./mvnw clean package -DskipTests": 1,
+		"./gradlew build -x test":          1,
+		"Setup Java Environment":           1,
+	} {
+		if got := strings.Count(string(content), cmd); got != want {
+			t.Errorf("%q appears %d times, want %d", cmd, got, want)
+		}
+	}
+}
+
+// TestRender_NodePackageManager checks that Node.js projects are built with
+// their recorded package manager and share one Node.js setup step.
+func TestRender_NodePackageManager(t *testing.T) {
+	handler := &Handler{}
+	plan, _ := handler.GetTasks("", "", "")
+	task := plan[0].(*tasks.CreateTemplateTask)
+
+	tmpDir := t.TempDir()
+	m := &types.Manifest{Projects: []types.Project{
+		{Name: "api", Language: "node", Path: "api", BuildTool: "npm"},
+		{Name: "jobs", Language: "node", Path: "jobs", BuildTool: "pnpm"},
+	}}
+	if err := task.Execute(types.TaskContext{TargetPath: tmpDir, Manifest: m}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	//nolint:gosec // Test temp directory
+	content, err := os.ReadFile(filepath.Join(tmpDir, task.OutputPath))
+	if err != nil {
+		t.Fatalf("Workflow not written: %v", err)
+	}
+	for cmd, want := range map[string]int{
+		"npm install --silent":      1,
+		"pnpm install":              1,
+		"corepack enable":           1,
+		"Setup Node.js Environment": 1,
+	} {
+		if got := strings.Count(string(content), cmd); got != want {
+			t.Errorf("%q appears %d times, want %d", cmd, got, want)
+		}
+	}
+}
@@ -15,40 +15,41 @@ jobs:
 
       # --- Conditional Setup Steps based on Languages in Manifest ---
       {{ $root := . }}
-      {{ $hasGo := false }}
-      {{ $hasSvelte := false }}
-      {{ $hasSpring := false }}
-
-      {{ range .Projects }}
-        {{ if eq .Language "go" }}
-          {{ if not $hasGo }}
+      {{ range split "," (include "languages" .) }}
+        {{ if eq . "go" }}
             - name: Setup Go Environment
               uses: actions/setup-go@v5
               with:
                 go-version: {{ index $root.Metadata "go_version" | default "1.22" | quote }}
-            {{ $hasGo = true }}
-          {{ end }}
         {{ end }}
         
-        {{ if eq .Language "svelte" }}
-          {{ if not $hasSvelte }}
+        {{ if or (eq . "svelte") (and (eq . "node") (not (has_language "svelte"))) }}
             - name: Setup Node.js Environment
               uses: actions/setup-node@v4
               with:
                 node-version: {{ index $root.Metadata "node_version" | default "20" | quote }}
-            {{ $hasSvelte = true }}
-          {{ end }}
         {{ end }}
         
-        {{ if eq .Language "spring" }}
-          {{ if not $hasSpring }}
+        {{ if eq . "python" }}
+            - name: Setup Python Environment
+              uses: actions/setup-python@v5
+              with:
+                python-version: {{ index $root.Metadata "python_version" | default "3.12" | quote }}
+        {{ end }}
+
+        {{ if eq . "rust" }}
+            - name: Setup Rust Toolchain
+              uses: dtolnay/rust-toolchain@stable
+              with:
+                components: clippy
+        {{ end }}
+
+        {{ if eq . "spring" }}
             - name: Setup Java Environment
               uses: actions/setup-java@v4
               with:
                 distribution: {{ index $root.Metadata "java_distribution" | default "temurin" | quote }}
                 java-version: {{ index $root.Metadata "java_version" | default "17" | quote }}
-          {{ $hasSpring = true }}
-          {{ end }}
         {{ end }}
       {{ end }}
 
@@ -65,7 +66,7 @@ jobs:
             cd {{ .Path }}
             go mod download
             go test ./...
-            go build .
+            go build ./...
             cd -
             {{ end }}
             
@@ -77,10 +78,52 @@ jobs:
             cd -
             {{ end }}
             
+            {{ if eq .Language "node" }}
+            # Install, Build and Test Node.js Project
+            cd {{ .Path }}
+            {{ if eq .BuildTool "pnpm" }}
+            corepack enable
+            pnpm install
+            pnpm run build
+            pnpm test
+            {{ else if eq .BuildTool "yarn" }}
+            corepack enable
+            yarn install
+            yarn run build
+            yarn test
+            {{ else }}
+            npm install --silent
+            npm run build
+            npm test
+            {{ end }}
+            cd -
+            {{ end }}
+
+            {{ if eq .Language "python" }}
+            # Install and Test Python Project
+            cd {{ .Path }}
+            python -m pip install --quiet -e ".[dev]"
+            python -m pytest
+            cd -
+            {{ end }}
+
+            {{ if eq .Language "rust" }}
+            # Build and Test Rust Crate
+            cd {{ .Path }}
+            cargo build --verbose
+            cargo test --verbose
+            cd -
+            {{ end }}
+
             {{ if eq .Language "spring" }}
-            # Build Spring Project using Maven Wrapper
             cd {{ .Path }}
+            {{ if eq .BuildTool "gradle" }}
+            # Build Spring Project using Gradle Wrapper
+            ./gradlew build -x test
+            {{ else }}
+            # Build Spring Project using Maven Wrapper
             ./mvnw clean package -DskipTests
+            {{ end }}
             cd -
             {{ end }}
           {{ end }}
\ No newline at end of file
@@ -7,6 +7,8 @@ package community
 import (
 	"embed"
 	"fmt"
+	"io/fs"
+	"path/filepath"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
 )
@@ -17,8 +19,11 @@ var templates embed.FS
 // Handler implements the templates.Handler interface for community governance.
 type Handler struct{}
 
+// TemplateFS returns the embedded template files.
+func (h *Handler) TemplateFS() fs.FS { return templates }
+
 // GetTasks returns the plan to create community governance files.
-func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
+func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task, error) {
 	var plan []types.Task
 
 	// Initialize sequence for the Community/Governance band (1000-1099 range, reusing PrioConfigUniversal)
@@ -49,6 +54,7 @@ func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
 			OutputPath:   f.dest,
 			Desc:         f.desc,
 			TaskPrio:     int(p),
+			Schedule:     types.Schedule{Resources: []string{filepath.Join(targetPath, f.dest)}},
 		})
 	}
 
@@ -8,10 +8,9 @@ import (
 	"embed"
 	"errors"
 	"fmt"
-	"os"
+	"io/fs"
 	"path/filepath"
 	"scbake/internal/types"
-	"scbake/internal/util/fileutil"
 	"scbake/pkg/tasks"
 	"strconv"
 	"strings"
@@ -26,8 +25,11 @@ var templates embed.FS
 // Handler implements the templates.Handler interface for compliance.
 type Handler struct{}
 
+// TemplateFS returns the embedded template files.
+func (h *Handler) TemplateFS() fs.FS { return templates }
+
 // GetTasks returns the plan to create compliance files.
-func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
+func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task, error) {
 	var plan []types.Task
 
 	// Initialize sequence for the Compliance band (1200-1399 range, PrioLinter)
@@ -44,6 +46,7 @@ func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
 		OutputPath:   "SECURITY.md",
 		Desc:         "Create SECURITY.md",
 		TaskPrio:     int(p),
+		Schedule:     types.Schedule{Resources: []string{filepath.Join(targetPath, "SECURITY.md")}},
 	})
 
 	// 2. dependabot.yml (in .github/)
@@ -54,6 +57,9 @@ func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
 		OutputPath:   ".github/dependabot.yml",
 		Desc:         "Create dependabot.yml",
 		TaskPrio:     int(p),
+		// Only ecosystems listed in the template get an update entry.
+		When:     `or (has_language "go") (has_language "svelte") (has_language "node") (has_language "python") (has_language "rust")`,
+		Schedule: types.Schedule{Resources: []string{filepath.Join(targetPath, ".github/dependabot.yml")}},
 	})
 
 	// 3. LICENSE (Dynamic)
@@ -61,6 +67,7 @@ func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
 	p, _ = seq.Next()
 	plan = append(plan, &LicenseTask{
 		TaskPrio: int(p),
+		Schedule: types.Schedule{Resources: []string{filepath.Join(targetPath, "LICENSE")}},
 	})
 
 	// 4. CODEOWNERS (Surgical Append)
@@ -70,6 +77,7 @@ func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
 		Content:  "# Managed by scbake\n* @maintainers\n",
 		Desc:     "Initialize .github/CODEOWNERS",
 		TaskPrio: int(p),
+		Schedule: types.Schedule{Resources: []string{filepath.Join(targetPath, ".github/CODEOWNERS")}},
 	})
 
 	return plan, nil
@@ -78,6 +86,9 @@ func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
 // LicenseTask is a custom task for dynamic license generation.
 type LicenseTask struct {
 	TaskPrio int
+
+	// Optional: ID, dependencies and resources for parallel scheduling (see types.Schedule)
+	Schedule types.Schedule
 }
 
 // Description returns a human-readable summary of the task.
@@ -86,8 +97,10 @@ func (t *LicenseTask) Description() string { return "Generate LICENSE file" }
 // Priority returns the execution priority level.
 func (t *LicenseTask) Priority() int { return t.TaskPrio }
 
+// Scheduling returns the task's schedule.
+func (t *LicenseTask) Scheduling() types.Schedule { return t.Schedule }
+
 // Execute performs the license generation task.
-//nolint:cyclop // Complex string replacement and file operations
 func (t *LicenseTask) Execute(tc types.TaskContext) error {
 	if tc.Manifest.Metadata == nil {
 		return errors.New("missing compliance metadata (license and copyright_holder) in manifest")
@@ -123,63 +136,6 @@ func (t *LicenseTask) Execute(tc types.TaskContext) error {
 	)
 	text = replacer.Replace(text)
 
-	// 3. Manual write with State-Aware Reconciliation
-	if tc.DryRun {
-		return nil
-	}
-
-	finalPath := filepath.Join(tc.TargetPath, "LICENSE")
-	absPath, _ := filepath.Abs(finalPath)
-
-	newHash := tasks.HashContent([]byte(text))
-
-	// Re-implement reconciliation logic here to avoid circular dependency
-	writePath := absPath
-	if !tc.Force {
-		//nolint:gosec
-		existingContent, err := os.ReadFile(absPath)
-		if err == nil {
-			existingHash := tasks.HashContent(existingContent)
-			var originalHash string
-			if tc.Manifest.ManagedFiles != nil {
-				originalHash = tc.Manifest.ManagedFiles["LICENSE"]
-			}
-
-			if originalHash == "" || (existingHash != originalHash && existingHash != newHash) {
-				// Drift detected
-				switch tc.ConflictStrategy {
-				case "overwrite":
-					writePath = absPath
-				case "artifact":
-					fmt.Printf("⚠️  Conflict in LICENSE (user modifications detected). Writing new template to artifact.\n")
-					writePath = absPath + ".scbake-new"
-				case "keep-local":
-					fmt.Printf("⚠️  Conflict in LICENSE (user modifications detected). Skipping update (--strategy=keep-local).\n")
-					return nil
-				case "fail":
-					fallthrough
-				default:
-					return errors.New("file LICENSE has manual modifications (drift detected). Use --conflict-strategy to resolve")
-				}
-			}
-		}
-	}
-
-	if tc.Tx != nil {
-		if err := tc.Tx.Track(writePath); err != nil {
-			return fmt.Errorf("failed to track LICENSE file: %w", err)
-		}
-	}
-
-	if err := os.WriteFile(writePath, []byte(text), fileutil.FilePerms); err != nil {
-		return fmt.Errorf("failed to write LICENSE: %w", err)
-	}
-
-	// Record State
-	if tc.Manifest.ManagedFiles == nil {
-		tc.Manifest.ManagedFiles = make(map[string]string)
-	}
-	tc.Manifest.ManagedFiles["LICENSE"] = newHash
-
-	return nil
+	// 3. Write with State-Aware Reconciliation
+	return tasks.ManagedFile{OutputPath: "LICENSE", Content: []byte(text)}.Write(tc)
 }
@@ -8,6 +8,8 @@ import (
 	"os"
 	"path/filepath"
 	"scbake/internal/types"
+	"scbake/pkg/tasks"
+	"strings"
 	"testing"
 )
 
@@ -243,3 +245,78 @@ func TestLicenseTask_Metadata(t *testing.T) {
 		t.Errorf("Priority() mismatch")
 	}
 }
+
+func TestComplianceHandler_DependabotCondition(t *testing.T) {
+	h := &Handler{}
+	plan, err := h.GetTasks(".", "", "")
+	if err != nil {
+		t.Fatalf("GetTasks failed: %v", err)
+	}
+
+	var when string
+	for _, task := range plan {
+		if tt, ok := task.(*tasks.CreateTemplateTask); ok && tt.OutputPath == ".github/dependabot.yml" {
+			when = tt.When
+		}
+	}
+	if when == "" {
+		t.Fatal("dependabot.yml task should carry a when condition")
+	}
+
+	for lang, want := range map[string]bool{"go": true, "svelte": true, "node": true, "python": true, "rust": true, "spring": false} {
+		m := &types.Manifest{Projects: []types.Project{{Name: "p", Language: lang}}}
+		got, err := tasks.EvalCondition(when, m)
+		if err != nil {
+			t.Fatalf("EvalCondition failed: %v", err)
+		}
+		if got != want {
+			t.Errorf("dependabot for %s-only repo: got %v, want %v", lang, got, want)
+		}
+	}
+}
+
+func TestComplianceHandler_DependabotEcosystems(t *testing.T) {
+	h := &Handler{}
+	plan, err := h.GetTasks(".", "", "")
+	if err != nil {
+		t.Fatalf("GetTasks failed: %v", err)
+	}
+
+	var task *tasks.CreateTemplateTask
+	for _, p := range plan {
+		if tt, ok := p.(*tasks.CreateTemplateTask); ok && tt.OutputPath == ".github/dependabot.yml" {
+			task = tt
+		}
+	}
+
+	tmpDir := t.TempDir()
+	m := &types.Manifest{Projects: []types.Project{
+		{Name: "api", Language: "go", Path: "api"},
+		{Name: "web", Language: "svelte", Path: "web"},
+		{Name: "svc", Language: "spring", Path: "svc"},
+		{Name: "etl", Language: "python", Path: "etl"},
+		{Name: "core", Language: "rust", Path: "core"},
+	}}
+	if err := task.Execute(types.TaskContext{TargetPath: tmpDir, Manifest: m}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	//nolint:gosec // Test temp directory
+	content, err := os.ReadFile(filepath.Join(tmpDir, ".github", "dependabot.yml"))
+	if err != nil {
+		t.Fatalf("dependabot.yml not written: %v", err)
+	}
+	for _, want := range []string{
+		"package-ecosystem: \"gomod\"\n    directory: \"api\"",
+		"package-ecosystem: \"npm\"\n    directory: \"web\"",
+		"package-ecosystem: \"pip\"\n    directory: \"etl\"",
+		"package-ecosystem: \"cargo\"\n    directory: \"core\"",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("missing %q in:\n%s", want, content)
+		}
+	}
+	if strings.Contains(string(content), `"svc"`) {
+		t.Errorf("spring project should have no entry:\n%s", content)
+	}
+}
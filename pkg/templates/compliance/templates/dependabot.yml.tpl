@@ -1,15 +1,9 @@
 version: 2
 updates:
-{{ range .Projects }}
-  {{ if eq .Language "go" }}
-  - package-ecosystem: "gomod"
-    directory: "{{ .Path }}"
-    schedule:
-      interval: "weekly"
-  {{ end }}
-  {{ if eq .Language "svelte" }}
-  - package-ecosystem: "npm"
-    directory: "{{ .Path }}"
+{{ range $p := .Projects }}
+  {{ with include "ecosystem" $p.Language }}
+  - package-ecosystem: "{{ . }}"
+    directory: "{{ $p.Path }}"
     schedule:
       interval: "weekly"
   {{ end }}
@@ -6,19 +6,27 @@ package devcontainer
 import (
 	"embed"
 	"fmt"
+	"io/fs"
+	"path/filepath"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
 )
 
-//go:embed devcontainer.json.tpl Dockerfile.tpl
+//go:embed devcontainer.json.tpl Dockerfile.tpl schema.json
 var templates embed.FS
 
 // Handler implements the templates.Handler interface for Dev Containers.
 type Handler struct{}
 
+// SchemaFS returns the embedded filesystem containing schema.json.
+func (h *Handler) SchemaFS() fs.FS { return templates }
+
+// SchemaPath returns the path to the embedded schema definition.
+func (h *Handler) SchemaPath() string { return "schema.json" }
+
 // GetTasks returns the plan to create the Dev Container configuration.
-// It creates both the JSON file and the Dockerfile.
-func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
+// It creates the JSON file and, unless the dockerfile variable is false, the Dockerfile.
+func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task, error) {
 	var plan []types.Task
 
 	// Initialize sequence for the Dev Environment band (1500+)
@@ -39,6 +47,8 @@ func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
 		OutputPath:   ".devcontainer/Dockerfile",
 		Desc:         "Create .devcontainer/Dockerfile",
 		TaskPrio:     int(p), // Now 1500
+		When:         `ne .Metadata.dockerfile "false"`,
+		Schedule:     types.Schedule{Resources: []string{filepath.Join(targetPath, ".devcontainer/Dockerfile")}},
 	})
 
 	// Task 2: Create the devcontainer.json file
@@ -52,6 +62,7 @@ func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
 		OutputPath:   ".devcontainer/devcontainer.json",
 		Desc:         "Create .devcontainer/devcontainer.json",
 		TaskPrio:     int(p), // Now 1501
+		Schedule:     types.Schedule{Resources: []string{filepath.Join(targetPath, ".devcontainer/devcontainer.json")}},
 	})
 
 	return plan, nil
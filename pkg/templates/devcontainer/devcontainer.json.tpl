@@ -1,15 +1,25 @@
-{{- /* 1. Scan manifest once to determine required languages */ -}}
+{{- /* 1. Determine required languages once (see the shared "languages" partial) */ -}}
 {{- $hasGo := false -}}
 {{- $hasNode := false -}}
+{{- $hasSvelte := false -}}
 {{- $hasJava := false -}}
-{{- range .Projects -}}
-    {{- if eq .Language "go" }}{{ $hasGo = true }}{{ end -}}
-    {{- if eq .Language "svelte" }}{{ $hasNode = true }}{{ end -}}
-    {{- if eq .Language "spring" }}{{ $hasJava = true }}{{ end -}}
+{{- $hasPython := false -}}
+{{- $hasRust := false -}}
+{{- range split "," (include "languages" .) -}}
+    {{- if eq . "go" }}{{ $hasGo = true }}{{ end -}}
+    {{- if eq . "svelte" }}{{ $hasNode = true }}{{ $hasSvelte = true }}{{ end -}}
+    {{- if eq . "node" }}{{ $hasNode = true }}{{ end -}}
+    {{- if eq . "spring" }}{{ $hasJava = true }}{{ end -}}
+    {{- if eq . "python" }}{{ $hasPython = true }}{{ end -}}
+    {{- if eq . "rust" }}{{ $hasRust = true }}{{ end -}}
 {{- end -}}
 {
   "name": "scbake Dev Container",
+{{- if eq (index .Metadata "dockerfile") "false" }}
+  "image": "mcr.microsoft.com/devcontainers/base:debian-12",
+{{- else }}
   "dockerFile": "Dockerfile",
+{{- end }}
   "remoteUser": "vscode",
   "features": {
     "ghcr.io/devcontainers/features/common-utils:2": {
@@ -37,6 +47,18 @@
       "installGradle": "false"
     }
     {{- end -}}
+    {{- if $hasPython -}}
+    ,
+    "ghcr.io/devcontainers/features/python:1": {
+      "version": {{ index .Metadata "python_version" | default "3.12" | quote }}
+    }
+    {{- end -}}
+    {{- if $hasRust -}}
+    ,
+    "ghcr.io/devcontainers/features/rust:1": {
+      "version": "latest"
+    }
+    {{- end -}}
   },
   "customizations": {
     "vscode": {
@@ -50,7 +72,7 @@
         {{- if $hasGo -}}
         , "golang.go"
         {{- end -}}
-        {{- if $hasNode -}}
+        {{- if $hasSvelte -}}
         , "svelte.svelte-vscode"
         , "dbaeumer.vscode-eslint"
         {{- end -}}
@@ -58,6 +80,12 @@
         , "vscjava.vscode-java-pack"
         , "vscjava.vscode-maven"
         {{- end -}}
+        {{- if $hasPython -}}
+        , "ms-python.python"
+        {{- end -}}
+        {{- if $hasRust -}}
+        , "rust-lang.rust-analyzer"
+        {{- end -}}
       ]
     }
   },
@@ -4,9 +4,12 @@
 package devcontainer
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
+	"strings"
 	"testing"
 )
 
@@ -96,3 +99,50 @@ func TestDevContainer_TemplateIntegrity(t *testing.T) {
 		}
 	}
 }
+
+// TestDevContainer_DockerfileOptional verifies that dockerfile=false skips the
+// Dockerfile and points devcontainer.json at the base image instead.
+func TestDevContainer_DockerfileOptional(t *testing.T) {
+	handler := &Handler{}
+	plan, err := handler.GetTasks("", "", "")
+	if err != nil {
+		t.Fatalf("Failed to get tasks: %v", err)
+	}
+
+	dockerTask := plan[0].(*tasks.CreateTemplateTask)
+	jsonTask := plan[1].(*tasks.CreateTemplateTask)
+
+	for _, tt := range []struct {
+		value      string
+		wantDocker bool
+	}{
+		{"true", true},
+		{"false", false},
+	} {
+		t.Run("dockerfile="+tt.value, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			m := &types.Manifest{Metadata: map[string]string{"dockerfile": tt.value}}
+			tc := types.TaskContext{TargetPath: tmpDir, Manifest: m}
+
+			run, err := tasks.EvalCondition(dockerTask.When, m)
+			if err != nil {
+				t.Fatalf("EvalCondition failed: %v", err)
+			}
+			if run != tt.wantDocker {
+				t.Errorf("Dockerfile condition = %v, want %v", run, tt.wantDocker)
+			}
+
+			if err := jsonTask.Execute(tc); err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			//nolint:gosec // Test temp directory
+			content, _ := os.ReadFile(filepath.Join(tmpDir, ".devcontainer", "devcontainer.json"))
+			if hasDocker := strings.Contains(string(content), `"dockerFile": "Dockerfile"`); hasDocker != tt.wantDocker {
+				t.Errorf("dockerFile present = %v, want %v\n%s", hasDocker, tt.wantDocker, content)
+			}
+			if hasImage := strings.Contains(string(content), `"image": `); hasImage == tt.wantDocker {
+				t.Errorf("image present = %v, want %v\n%s", hasImage, !tt.wantDocker, content)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ package editorconfig
 import (
 	"embed"
 	"fmt"
+	"io/fs"
+	"path/filepath"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
 )
@@ -16,8 +18,11 @@ var templates embed.FS
 // Handler implements the templates.Handler interface for EditorConfig.
 type Handler struct{}
 
+// TemplateFS returns the embedded template files.
+func (h *Handler) TemplateFS() fs.FS { return templates }
+
 // GetTasks returns the plan to create the standard .editorconfig file.
-func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
+func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task, error) {
 	var plan []types.Task
 
 	// Initialize sequence for the Universal Config band (1000-1099)
@@ -36,6 +41,7 @@ func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
 		OutputPath:   ".editorconfig",
 		Desc:         "Create standardized .editorconfig",
 		TaskPrio:     int(p), // Now 1000
+		Schedule:     types.Schedule{Resources: []string{filepath.Join(targetPath, ".editorconfig")}},
 	})
 
 	return plan, nil
@@ -4,6 +4,7 @@
 package editorconfig
 
 import (
+	"io/fs"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
 	"testing"
@@ -43,7 +44,7 @@ func TestGetTasks_EditorConfig(t *testing.T) {
 	}
 
 	// 3. Validate TemplateFS readability
-	if _, err := task.TemplateFS.ReadFile(task.TemplatePath); err != nil {
+	if _, err := fs.ReadFile(task.TemplateFS, task.TemplatePath); err != nil {
 		t.Errorf("Task TemplateFS is invalid or template is missing: %v", err)
 	}
 }
@@ -25,6 +25,9 @@ func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
 		return nil, fmt.Errorf("failed to create priority sequence: %w", err)
 	}
 
+	// The git tasks declare no resources: staging and committing see every
+	// file, so they never run alongside another task.
+
 	// Task 1: Initialize Git repository.
 	// We predict creation of GitDir so the transaction system can rollback the entire repo creation on failure.
 	prio, err := seq.Next()
@@ -6,6 +6,8 @@ package golinter
 import (
 	"embed"
 	"fmt"
+	"io/fs"
+	"path/filepath"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
 )
@@ -16,8 +18,16 @@ var templates embed.FS
 // Handler implements the templates.Handler interface for Go linting.
 type Handler struct{}
 
+// TemplateFS returns the embedded template files.
+func (h *Handler) TemplateFS() fs.FS { return templates }
+
+// Dependencies requires a Go project.
+func (h *Handler) Dependencies() types.Dependencies {
+	return types.Dependencies{RequiresLanguages: []string{"go"}}
+}
+
 // GetTasks returns the plan to create the Go linter configuration file.
-func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
+func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task, error) {
 	var plan []types.Task
 
 	// Initialize sequence for the Linter band (1200-1399)
@@ -36,6 +46,7 @@ func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
 		OutputPath:   ".golangci.yml",
 		Desc:         "Create Go linter configuration (.golangci.yml)",
 		TaskPrio:     int(p), // Now 1200
+		Schedule:     types.Schedule{Resources: []string{filepath.Join(targetPath, ".golangci.yml")}},
 	})
 
 	return plan, nil
@@ -4,6 +4,7 @@
 package golinter
 
 import (
+	"io/fs"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
 	"testing"
@@ -46,7 +47,7 @@ func TestGetTasks_GoLinter(t *testing.T) {
 
 	// 3. Verify TemplateFS and Readability
 	// Logic: Ensure the bound FS is not nil and contains the required asset.
-	if _, err := task.TemplateFS.ReadFile(task.TemplatePath); err != nil {
+	if _, err := fs.ReadFile(task.TemplateFS, task.TemplatePath); err != nil {
 		t.Errorf("Task TemplateFS is invalid or template file is missing: %v", err)
 	}
 }
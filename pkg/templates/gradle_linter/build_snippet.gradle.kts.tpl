@@ -0,0 +1,8 @@
+
+// Checkstyle (added by scbake gradle_linter); reads config/checkstyle/checkstyle.xml
+apply(plugin = "checkstyle")
+
+configure<CheckstyleExtension> {
+	toolVersion = "10.12.5"
+	maxWarnings = 0
+}
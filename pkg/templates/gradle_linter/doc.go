@@ -0,0 +1,2 @@
+// Package gradlelinter provides the task handler for setting up Checkstyle linting in Gradle (Kotlin DSL) builds.
+package gradlelinter
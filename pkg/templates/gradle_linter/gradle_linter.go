@@ -0,0 +1,95 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package gradlelinter
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"scbake/internal/types"
+	"scbake/pkg/tasks"
+)
+
+//go:embed checkstyle.xml.tpl build_snippet.gradle.kts.tpl
+var templates embed.FS
+
+// buildScript is the Kotlin DSL build file the Checkstyle plugin is applied in.
+const buildScript = "build.gradle.kts"
+
+// Handler implements the templates.Handler interface for Gradle linting.
+type Handler struct{}
+
+// TemplateFS returns the embedded template files.
+func (h *Handler) TemplateFS() fs.FS { return templates }
+
+// Dependencies requires a Spring Gradle project, whose build.gradle.kts
+// receives the Checkstyle plugin.
+func (h *Handler) Dependencies() types.Dependencies {
+	return types.Dependencies{RequiresLanguages: []string{"spring"}, RequiresBuildTools: []string{"gradle"}}
+}
+
+// GetTasks returns the plan to create the Checkstyle config and apply the
+// plugin in build.gradle.kts.
+func (h *Handler) GetTasks(targetPath string, templateDir string, registryCacheDir string) ([]types.Task, error) {
+	var plan []types.Task
+
+	// Initialize sequence for the Linter band (1200-1399)
+	seq, err := types.NewPrioritySequence(types.PrioLinter, types.MaxLinter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create priority sequence: %w", err)
+	}
+
+	// Task 1: Create the Checkstyle config file at the plugin's default location
+	p, err := seq.Next()
+	if err != nil {
+		return nil, err
+	}
+	plan = append(plan, &tasks.CreateTemplateTask{
+		TemplateFS:   templates,
+		TemplatePath: "checkstyle.xml.tpl",
+		OutputPath:   filepath.Join("config", "checkstyle", "checkstyle.xml"),
+		Desc:         "Create Gradle Checkstyle configuration",
+		TaskPrio:     int(p), // Now 1200
+		Schedule:     types.Schedule{Resources: []string{filepath.Join(targetPath, "config", "checkstyle", "checkstyle.xml")}},
+	})
+
+	// Task 2: Apply the Checkstyle plugin in the existing build.gradle.kts
+	p, err = seq.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	snippet, err := tasks.ReadTemplate(templates, "build_snippet.gradle.kts.tpl", templateDir, registryCacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	plan = append(plan, &buildScriptTask{AppendFileTask: tasks.AppendFileTask{
+		FilePath: buildScript,
+		Content:  string(snippet),
+		Desc:     "Apply Gradle Checkstyle plugin in " + buildScript,
+		TaskPrio: int(p), // Now 1201
+		Schedule: types.Schedule{Resources: []string{filepath.Join(targetPath, buildScript)}},
+	}})
+
+	return plan, nil
+}
+
+// buildScriptTask appends to build.gradle.kts only if it exists, so the
+// template never creates a build script in a Maven project.
+type buildScriptTask struct {
+	tasks.AppendFileTask
+}
+
+func (t *buildScriptTask) Execute(tc types.TaskContext) error {
+	if _, err := os.Stat(filepath.Join(tc.TargetPath, t.FilePath)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s not found: gradle_linter requires a Gradle (Kotlin DSL) project", t.FilePath)
+		}
+		return fmt.Errorf("could not check for %s: %w", t.FilePath, err)
+	}
+	return t.AppendFileTask.Execute(tc)
+}
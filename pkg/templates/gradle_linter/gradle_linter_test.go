@@ -0,0 +1,81 @@
+// Copyright 2025 Emin Salih Açıkgöz
+// SPDX-License-Identifier: gpl3-or-later
+
+package gradlelinter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"scbake/internal/types"
+)
+
+func TestHandler_GetTasks(t *testing.T) {
+	taskList, err := (&Handler{}).GetTasks(".", "", "")
+	if err != nil {
+		t.Fatalf("GetTasks failed: %v", err)
+	}
+	if len(taskList) != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", len(taskList))
+	}
+	if p1, p2 := taskList[0].Priority(), taskList[1].Priority(); p1 != 1200 || p2 != 1201 {
+		t.Errorf("Expected priorities 1200 and 1201, got %d and %d", p1, p2)
+	}
+}
+
+func TestHandler_Execute(t *testing.T) {
+	dir := t.TempDir()
+	buildFile := filepath.Join(dir, "build.gradle.kts")
+	if err := os.WriteFile(buildFile, []byte("plugins {\n\tjava\n}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tc := types.TaskContext{Ctx: context.Background(), TargetPath: dir, Manifest: &types.Manifest{}}
+	for run := 0; run < 2; run++ {
+		taskList, err := (&Handler{}).GetTasks(dir, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, task := range taskList {
+			if err := task.Execute(tc); err != nil {
+				t.Fatalf("%s failed: %v", task.Description(), err)
+			}
+		}
+	}
+
+	//nolint:gosec // Test reads the file it created
+	data, err := os.ReadFile(buildFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	if !strings.HasPrefix(content, "plugins {\n\tjava\n}\n") {
+		t.Errorf("Existing build script should be kept:\n%s", content)
+	}
+	if n := strings.Count(content, `apply(plugin = "checkstyle")`); n != 1 {
+		t.Errorf("Checkstyle plugin should be applied once, found %d times:\n%s", n, content)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "config", "checkstyle", "checkstyle.xml")); err != nil {
+		t.Errorf("Checkstyle config not created: %v", err)
+	}
+}
+
+func TestHandler_RequiresBuildScript(t *testing.T) {
+	dir := t.TempDir()
+	taskList, err := (&Handler{}).GetTasks(dir, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tc := types.TaskContext{Ctx: context.Background(), TargetPath: dir, Manifest: &types.Manifest{}}
+	err = taskList[1].Execute(tc)
+	if err == nil || !strings.Contains(err.Error(), "requires a Gradle (Kotlin DSL) project") {
+		t.Errorf("Expected missing build script error, got %v", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(dir, "build.gradle.kts")); !os.IsNotExist(statErr) {
+		t.Error("build.gradle.kts should not be created")
+	}
+}
@@ -6,6 +6,8 @@ package makefile
 import (
 	"embed"
 	"fmt"
+	"io/fs"
+	"path/filepath"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
 )
@@ -17,13 +19,13 @@ var templates embed.FS
 type Handler struct{}
 
 // SchemaFS returns the embedded filesystem containing schema.json.
-func (h *Handler) SchemaFS() embed.FS { return templates }
+func (h *Handler) SchemaFS() fs.FS { return templates }
 
 // SchemaPath returns the path to the embedded schema definition.
 func (h *Handler) SchemaPath() string { return "schema.json" }
 
 // GetTasks returns the plan to create the smart Makefile.
-func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
+func (h *Handler) GetTasks(targetPath string, _ string, _ string) ([]types.Task, error) {
 	var plan []types.Task
 
 	// Initialize sequence for the Build System band (1400-1499)
@@ -42,6 +44,7 @@ func (h *Handler) GetTasks(_ string, _ string, _ string) ([]types.Task, error) {
 		OutputPath:   "Makefile",
 		Desc:         "Create smart Makefile",
 		TaskPrio:     int(p), // Now 1400
+		Schedule:     types.Schedule{Resources: []string{filepath.Join(targetPath, "Makefile")}},
 	})
 
 	return plan, nil
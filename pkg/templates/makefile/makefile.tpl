@@ -21,7 +21,7 @@ help:
 build:
 {{- range .Projects }}
 	@echo "Building {{.Name}} ({{.Language}})..."
-	(cd {{.Path}} && $(MAKE_BUILD_COMMAND_{{.Language}}))
+	(cd {{.Path}} && $(MAKE_BUILD_COMMAND_{{.Language}}{{with .BuildTool}}_{{.}}{{end}}))
 {{- end }}
 
 # --- Test Target ---
@@ -38,7 +38,7 @@ clean:
 	@echo "Cleaning projects..."
 {{- range .Projects }}
 	@echo "Cleaning {{.Name}}..."
-	(cd {{.Path}} && $(MAKE_CLEAN_COMMAND_{{.Language}}))
+	(cd {{.Path}} && $(MAKE_CLEAN_COMMAND_{{.Language}}{{with .BuildTool}}_{{.}}{{end}}))
 {{- end }}
 
 # --- Main Lint Targets ---
@@ -47,14 +47,14 @@ lint:
 	@echo "Running lint checks..."
 {{- range .Projects }}
 	@echo "Linting {{.Name}}..."
-	(cd {{.Path}} && $(MAKE_LINT_COMMAND_{{.Language}}))
+	(cd {{.Path}} && $(MAKE_LINT_COMMAND_{{.Language}}{{with .BuildTool}}_{{.}}{{end}}))
 {{- end }}
 
 lint-fix:
 	@echo "Running lint fixes..."
 {{- range .Projects }}
 	@echo "Fixing {{.Name}}..."
-	(cd {{.Path}} && $(MAKE_LINT_FIX_COMMAND_{{.Language}}))
+	(cd {{.Path}} && $(MAKE_LINT_FIX_COMMAND_{{.Language}}{{with .BuildTool}}_{{.}}{{end}}))
 {{- end }}
 
 # -----------------------------------------------------------
@@ -63,7 +63,7 @@ lint-fix:
 
 # Go Commands (Uses golangci-lint config assumed to be present)
 # FIX: Corrected LINT and LINT_FIX commands to use 'run' and include './...'
-MAKE_BUILD_COMMAND_go := go build -v .
+MAKE_BUILD_COMMAND_go := go build -v ./...
 MAKE_CLEAN_COMMAND_go := go clean
 MAKE_LINT_COMMAND_go := golangci-lint run ./...
 MAKE_LINT_FIX_COMMAND_go := golangci-lint run --fix --timeout=5m ./...
@@ -74,8 +74,44 @@ MAKE_CLEAN_COMMAND_svelte := rm -rf dist node_modules
 MAKE_LINT_COMMAND_svelte := npm run lint
 MAKE_LINT_FIX_COMMAND_svelte := npm run lint -- --fix
 
+# Node.js Commands (per package manager; lint type-checks with tsc)
+MAKE_BUILD_COMMAND_node_npm := npm run build
+MAKE_CLEAN_COMMAND_node_npm := rm -rf dist node_modules
+MAKE_LINT_COMMAND_node_npm := npm run lint
+MAKE_LINT_FIX_COMMAND_node_npm := npm run lint
+MAKE_BUILD_COMMAND_node_pnpm := pnpm run build
+MAKE_CLEAN_COMMAND_node_pnpm := rm -rf dist node_modules
+MAKE_LINT_COMMAND_node_pnpm := pnpm run lint
+MAKE_LINT_FIX_COMMAND_node_pnpm := pnpm run lint
+MAKE_BUILD_COMMAND_node_yarn := yarn run build
+MAKE_CLEAN_COMMAND_node_yarn := rm -rf dist node_modules
+MAKE_LINT_COMMAND_node_yarn := yarn run lint
+MAKE_LINT_FIX_COMMAND_node_yarn := yarn run lint
+
+# Python Commands (Uses the dev extras of pyproject.toml: build, ruff)
+MAKE_BUILD_COMMAND_python := python3 -m build
+MAKE_CLEAN_COMMAND_python := rm -rf build dist .pytest_cache .ruff_cache
+MAKE_LINT_COMMAND_python := ruff check .
+MAKE_LINT_FIX_COMMAND_python := ruff check --fix .
+
+# Rust Commands (Uses Cargo and Clippy)
+MAKE_BUILD_COMMAND_rust := cargo build --release
+MAKE_CLEAN_COMMAND_rust := cargo clean
+MAKE_LINT_COMMAND_rust := cargo clippy --all-targets -- -D warnings
+MAKE_LINT_FIX_COMMAND_rust := cargo clippy --all-targets --fix --allow-dirty --allow-staged
+
 # Spring/Java Commands (Uses Maven Checkstyle plugin, assumed to be present)
 MAKE_BUILD_COMMAND_spring := ./mvnw clean package -DskipTests
 MAKE_CLEAN_COMMAND_spring := ./mvnw clean
 MAKE_LINT_COMMAND_spring := ./mvnw checkstyle:check
-MAKE_LINT_FIX_COMMAND_spring := echo "Fix command not applicable for Maven Checkstyle"
\ No newline at end of file
+MAKE_LINT_FIX_COMMAND_spring := echo "Fix command not applicable for Maven Checkstyle"
+MAKE_BUILD_COMMAND_spring_maven := $(MAKE_BUILD_COMMAND_spring)
+MAKE_CLEAN_COMMAND_spring_maven := $(MAKE_CLEAN_COMMAND_spring)
+MAKE_LINT_COMMAND_spring_maven := $(MAKE_LINT_COMMAND_spring)
+MAKE_LINT_FIX_COMMAND_spring_maven := $(MAKE_LINT_FIX_COMMAND_spring)
+
+# Spring/Java Commands with Gradle (Uses Gradle Checkstyle plugin, assumed to be present)
+MAKE_BUILD_COMMAND_spring_gradle := ./gradlew build -x test
+MAKE_CLEAN_COMMAND_spring_gradle := ./gradlew clean
+MAKE_LINT_COMMAND_spring_gradle := ./gradlew checkstyleMain checkstyleTest
+MAKE_LINT_FIX_COMMAND_spring_gradle := echo "Fix command not applicable for Gradle Checkstyle"
\ No newline at end of file
@@ -4,8 +4,12 @@
 package makefile
 
 import (
+	"io/fs"
+	"os"
+	"path/filepath"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
+	"strings"
 	"testing"
 )
 
@@ -45,7 +49,7 @@ func TestGetTasks_Makefile(t *testing.T) {
 	}
 
 	// 3. Verify TemplateFS accessibility
-	if _, err := task.TemplateFS.ReadFile(task.TemplatePath); err != nil {
+	if _, err := fs.ReadFile(task.TemplateFS, task.TemplatePath); err != nil {
 		t.Errorf("Task TemplateFS is invalid or template is missing: %v", err)
 	}
 }
@@ -61,3 +65,40 @@ func TestMakefile_TemplateExists(t *testing.T) {
 		t.Errorf("Embedded template '%s' is missing from binary: %v", task.TemplatePath, err)
 	}
 }
+
+// TestRender_BuildToolCommands checks that each project uses the commands of
+// its language and recorded build tool.
+func TestRender_BuildToolCommands(t *testing.T) {
+	handler := &Handler{}
+	plan, _ := handler.GetTasks("", "", "")
+	task := plan[0].(*tasks.CreateTemplateTask)
+
+	tmpDir := t.TempDir()
+	m := &types.Manifest{Projects: []types.Project{
+		{Name: "api", Language: "go", Path: "api"},
+		{Name: "orders", Language: "spring", Path: "orders", BuildTool: "maven"},
+		{Name: "stock", Language: "spring", Path: "stock", BuildTool: "gradle"},
+		{Name: "gateway", Language: "node", Path: "gateway", BuildTool: "pnpm"},
+	}}
+	if err := task.Execute(types.TaskContext{TargetPath: tmpDir, Manifest: m}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	//nolint:gosec // Test temp directory
+	content, err := os.ReadFile(filepath.Join(tmpDir, task.OutputPath))
+	if err != nil {
+		t.Fatalf("Makefile not written: %v", err)
+	}
+	for _, want := range []string{
+		"(cd api && $(MAKE_BUILD_COMMAND_go))",
+		"(cd orders && $(MAKE_BUILD_COMMAND_spring_maven))",
+		"(cd stock && $(MAKE_LINT_COMMAND_spring_gradle))",
+		"MAKE_BUILD_COMMAND_spring_gradle := ./gradlew build -x test",
+		"(cd gateway && $(MAKE_BUILD_COMMAND_node_pnpm))",
+		"MAKE_BUILD_COMMAND_node_pnpm := pnpm run build",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Makefile missing %q", want)
+		}
+	}
+}
@@ -6,6 +6,8 @@ package mavenlinter
 import (
 	"embed"
 	"fmt"
+	"io/fs"
+	"path/filepath"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
 )
@@ -16,8 +18,17 @@ var templates embed.FS
 // Handler implements the templates.Handler interface for Maven linting.
 type Handler struct{}
 
+// TemplateFS returns the embedded template files.
+func (h *Handler) TemplateFS() fs.FS { return templates }
+
+// Dependencies requires a Spring Maven project, whose pom.xml receives the
+// Checkstyle plugin.
+func (h *Handler) Dependencies() types.Dependencies {
+	return types.Dependencies{RequiresLanguages: []string{"spring"}, RequiresBuildTools: []string{"maven"}}
+}
+
 // GetTasks returns the plan to create Checkstyle config and inject the plugin into pom.xml.
-func (h *Handler) GetTasks(_ string, templateDir string, registryCacheDir string) ([]types.Task, error) {
+func (h *Handler) GetTasks(targetPath string, templateDir string, registryCacheDir string) ([]types.Task, error) {
 	var plan []types.Task
 
 	// Initialize sequence for the Linter band (1200-1399)
@@ -37,6 +48,7 @@ func (h *Handler) GetTasks(_ string, templateDir string, registryCacheDir string
 		OutputPath:   "checkstyle.xml",
 		Desc:         "Create Maven Checkstyle configuration",
 		TaskPrio:     int(p), // Now 1200
+		Schedule:     types.Schedule{Resources: []string{filepath.Join(targetPath, "checkstyle.xml")}},
 	})
 
 	// Task 2: Inject the Checkstyle plugin into the existing pom.xml
@@ -57,6 +69,7 @@ func (h *Handler) GetTasks(_ string, templateDir string, registryCacheDir string
 		XMLContent:  string(pluginSnippet),
 		Desc:        "Inject Maven Checkstyle plugin into pom.xml",
 		TaskPrio:    int(p), // Now 1201
+		Schedule:    types.Schedule{Resources: []string{filepath.Join(targetPath, "pom.xml")}},
 	})
 
 	return plan, nil
@@ -4,8 +4,8 @@
 package templates
 
 import (
-	"embed"
 	"fmt"
+	"io/fs"
 	"sort"
 	"sync"
 
@@ -17,6 +17,7 @@ import (
 	"scbake/pkg/templates/editorconfig"
 	"scbake/pkg/templates/git"
 	golinter "scbake/pkg/templates/go_linter"
+	gradlelinter "scbake/pkg/templates/gradle_linter"
 	"scbake/pkg/templates/makefile"
 	mavenlinter "scbake/pkg/templates/maven_linter"
 	sveltelinter "scbake/pkg/templates/svelte_linter"
@@ -33,10 +34,28 @@ type Handler interface {
 // against the schema before executing any tasks.
 type SchemaProvider interface {
 	Handler
-	SchemaFS() embed.FS
+	SchemaFS() fs.FS
 	SchemaPath() string
 }
 
+// FilesProvider is an optional interface a Handler can implement to expose
+// its embedded template files, which --template-dir and the registry cache
+// override at the same relative paths. scbake doctor uses them to find
+// override files that match no template; handlers implementing
+// SchemaProvider expose theirs through SchemaFS.
+type FilesProvider interface {
+	Handler
+	TemplateFS() fs.FS
+}
+
+// DependencyProvider is an optional interface a Handler can implement to
+// declare the templates and languages it requires, conflicts with or
+// suggests. scbake resolves them before building the plan.
+type DependencyProvider interface {
+	Handler
+	Dependencies() types.Dependencies
+}
+
 var (
 	handlersLock sync.RWMutex
 	handlers     = map[string]Handler{
@@ -45,6 +64,7 @@ var (
 		"editorconfig":  &editorconfig.Handler{},
 		"go_linter":     &golinter.Handler{},
 		"maven_linter":  &mavenlinter.Handler{},
+		"gradle_linter": &gradlelinter.Handler{},
 		"svelte_linter": &sveltelinter.Handler{},
 		"community":     &community.Handler{},
 		"compliance":    &compliance.Handler{},
@@ -73,9 +93,9 @@ func GetHandler(tmplName string) (Handler, error) {
 }
 
 // GetSchema looks up the handler by name and, if it implements SchemaProvider,
-// reads and parses its embedded schema.json. Returns nil, nil when the handler
-// does not provide a schema (validation is skipped).
-func GetSchema(tmplName string) (*embed.FS, string, error) {
+// returns the filesystem and path of its schema.json. Returns nil when the
+// handler does not provide a schema (validation is skipped).
+func GetSchema(tmplName string) (fs.FS, string, error) {
 	handlersLock.RLock()
 	defer handlersLock.RUnlock()
 
@@ -89,9 +109,29 @@ func GetSchema(tmplName string) (*embed.FS, string, error) {
 		return nil, "", nil
 	}
 
-	fs := sp.SchemaFS()
 	path := sp.SchemaPath()
-	return &fs, path, nil
+	if path == "" {
+		return nil, "", nil
+	}
+	return sp.SchemaFS(), path, nil
+}
+
+// GetDependencies looks up the handler by name and, if it implements
+// DependencyProvider, returns its declared dependencies.
+func GetDependencies(tmplName string) (types.Dependencies, error) {
+	handlersLock.RLock()
+	defer handlersLock.RUnlock()
+
+	h, ok := handlers[tmplName]
+	if !ok {
+		return types.Dependencies{}, fmt.Errorf("unknown template: %s", tmplName)
+	}
+
+	dp, ok := h.(DependencyProvider)
+	if !ok {
+		return types.Dependencies{}, nil
+	}
+	return dp.Dependencies(), nil
 }
 
 // ListTemplates returns the sorted names of all supported templates.
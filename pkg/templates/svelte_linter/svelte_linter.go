@@ -6,6 +6,8 @@ package sveltelinter
 import (
 	"embed"
 	"fmt"
+	"io/fs"
+	"path/filepath"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
 )
@@ -16,8 +18,16 @@ var templates embed.FS
 // Handler implements the templates.Handler interface for Svelte linting.
 type Handler struct{}
 
+// TemplateFS returns the embedded template files.
+func (h *Handler) TemplateFS() fs.FS { return templates }
+
+// Dependencies requires a Svelte project, whose package.json receives the ESLint setup.
+func (h *Handler) Dependencies() types.Dependencies {
+	return types.Dependencies{RequiresLanguages: []string{"svelte"}}
+}
+
 // GetTasks returns the plan to add ESLint configuration and dependencies for Svelte.
-func (h *Handler) GetTasks(_ string, _, _ string) ([]types.Task, error) {
+func (h *Handler) GetTasks(targetPath string, _, _ string) ([]types.Task, error) {
 	var plan []types.Task
 
 	// Initialize sequence for the Linter band (1200-1399)
@@ -37,6 +47,7 @@ func (h *Handler) GetTasks(_ string, _, _ string) ([]types.Task, error) {
 		OutputPath:   "eslint.config.js",
 		Desc:         "Create Svelte ESLint 9 configuration",
 		TaskPrio:     int(p), // Now 1200
+		Schedule:     types.Schedule{Resources: []string{filepath.Join(targetPath, "eslint.config.js")}},
 	})
 
 	// Task 2: Install necessary ESLint dependencies
@@ -59,6 +70,7 @@ func (h *Handler) GetTasks(_ string, _, _ string) ([]types.Task, error) {
 		Desc:        "Install Svelte ESLint dependencies",
 		TaskPrio:    int(p), // Now 1201
 		RunInTarget: true,
+		Schedule:    types.Schedule{Resources: []string{targetPath}},
 	})
 
 	// Task 3: Add robust 'lint' and 'lint:fix' scripts to package.json
@@ -77,6 +89,7 @@ func (h *Handler) GetTasks(_ string, _, _ string) ([]types.Task, error) {
 		Desc:        "Add standard lint scripts to package.json",
 		TaskPrio:    int(p), // Now 1202
 		RunInTarget: true,
+		Schedule:    types.Schedule{Resources: []string{targetPath}},
 	})
 
 	return plan, nil
@@ -4,6 +4,7 @@
 package sveltelinter
 
 import (
+	"io/fs"
 	"scbake/internal/types"
 	"scbake/pkg/tasks"
 	"testing"
@@ -92,7 +93,7 @@ func TestSvelteLinter_TemplateIntegrity(t *testing.T) {
 	plan, _ := handler.GetTasks("", "", "")
 	task := plan[0].(*tasks.CreateTemplateTask)
 
-	if _, err := task.TemplateFS.ReadFile(task.TemplatePath); err != nil {
+	if _, err := fs.ReadFile(task.TemplateFS, task.TemplatePath); err != nil {
 		t.Errorf("Task TemplateFS is invalid or template is missing: %v", err)
 	}
 }